@@ -39,16 +39,47 @@ const (
 	batchPrefix               = "42434800"
 )
 
+// Codec encodes and decodes with a set of definitions, such as those returned by the
+// server_definitions method of a server, for networks that know fields or transaction
+// types the embedded definitions don't. The package-level functions use the embedded
+// definitions.
+type Codec struct {
+	definitions *definitions.Definitions
+}
+
+// NewCodec returns a Codec that encodes and decodes with defs, or with the embedded
+// definitions if defs is nil.
+func NewCodec(defs *definitions.Definitions) *Codec {
+	if defs == nil {
+		defs = definitions.Get()
+	}
+	return &Codec{definitions: defs}
+}
+
+// Definitions returns the definitions of the codec.
+func (c *Codec) Definitions() *definitions.Definitions {
+	return c.definitions
+}
+
+// defaultCodec is the codec of the package-level functions.
+var defaultCodec = NewCodec(nil)
+
 // Encode converts a JSON transaction object to a hex string in the canonical binary format.
 // The binary format is defined in XRPL's core codebase.
 func Encode(json map[string]any) (string, error) {
-	st := types.NewSTObject(serdes.NewBinarySerializer(serdes.NewFieldIDCodec(definitions.Get())))
+	return defaultCodec.Encode(json)
+}
+
+// Encode converts a JSON transaction object to a hex string in the canonical binary format,
+// using the definitions of the codec.
+func (c *Codec) Encode(json map[string]any) (string, error) {
+	st := types.NewSTObjectWithDefinitions(serdes.NewBinarySerializer(serdes.NewFieldIDCodec(c.definitions)), c.definitions)
 
 	// Iterate over the keys in the provided JSON
 	for k := range json {
 
 		// Get the FieldIdNameMap from the definitions package
-		fh := definitions.Get().Fields[k]
+		fh := c.definitions.Fields[k]
 
 		// If the field is not found in the FieldIdNameMap, delete it from the JSON
 
@@ -70,6 +101,12 @@ func Encode(json map[string]any) (string, error) {
 // signature towards a multi-signed transaction.
 // (Only encodes fields that are intended to be signed.)
 func EncodeForMultisigning(json map[string]any, xrpAccountID string) (string, error) {
+	return defaultCodec.EncodeForMultisigning(json, xrpAccountID)
+}
+
+// EncodeForMultisigning is like the package-level EncodeForMultisigning, but uses the
+// definitions of the codec.
+func (c *Codec) EncodeForMultisigning(json map[string]any, xrpAccountID string) (string, error) {
 	st := &types.AccountID{}
 
 	// SigningPubKey is required for multi-signing but should be set to empty string.
@@ -81,7 +118,7 @@ func EncodeForMultisigning(json map[string]any, xrpAccountID string) (string, er
 		return "", err
 	}
 
-	encoded, err := Encode(removeNonSigningFields(json))
+	encoded, err := c.Encode(c.removeNonSigningFields(json))
 
 	if err != nil {
 		return "", err
@@ -92,8 +129,14 @@ func EncodeForMultisigning(json map[string]any, xrpAccountID string) (string, er
 
 // Encodes a transaction into binary format in preparation for signing.
 func EncodeForSigning(json map[string]any) (string, error) {
+	return defaultCodec.EncodeForSigning(json)
+}
+
+// EncodeForSigning is like the package-level EncodeForSigning, but uses the definitions
+// of the codec.
+func (c *Codec) EncodeForSigning(json map[string]any) (string, error) {
 
-	encoded, err := Encode(removeNonSigningFields(json))
+	encoded, err := c.Encode(c.removeNonSigningFields(json))
 
 	if err != nil {
 		return "", err
@@ -186,9 +229,9 @@ func EncodeForSigningBatch(json map[string]any) (string, error) {
 }
 
 // removeNonSigningFields removes the fields from a JSON transaction object that should not be signed.
-func removeNonSigningFields(json map[string]any) map[string]any {
+func (c *Codec) removeNonSigningFields(json map[string]any) map[string]any {
 	for k := range json {
-		fi, _ := definitions.Get().GetFieldInstanceByFieldName(k)
+		fi, _ := c.definitions.GetFieldInstanceByFieldName(k)
 
 		if fi != nil && !fi.IsSigningField {
 			delete(json, k)
@@ -200,12 +243,18 @@ func removeNonSigningFields(json map[string]any) map[string]any {
 
 // Decode decodes a hex string in the canonical binary format into a JSON transaction object.
 func Decode(hexEncoded string) (map[string]any, error) {
+	return defaultCodec.Decode(hexEncoded)
+}
+
+// Decode decodes a hex string in the canonical binary format into a JSON transaction object,
+// using the definitions of the codec.
+func (c *Codec) Decode(hexEncoded string) (map[string]any, error) {
 	b, err := hex.DecodeString(hexEncoded)
 	if err != nil {
 		return nil, err
 	}
-	p := serdes.NewBinaryParser(b, definitions.Get())
-	st := types.NewSTObject(serdes.NewBinarySerializer(serdes.NewFieldIDCodec(definitions.Get())))
+	p := serdes.NewBinaryParser(b, c.definitions)
+	st := types.NewSTObjectWithDefinitions(serdes.NewBinarySerializer(serdes.NewFieldIDCodec(c.definitions)), c.definitions)
 	m, err := st.ToJSON(p)
 	if err != nil {
 		return nil, err
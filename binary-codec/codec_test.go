@@ -1,8 +1,11 @@
 package binarycodec
 
 import (
+	"encoding/json"
+	"os"
 	"testing"
 
+	"github.com/Peersyst/xrpl-go/binary-codec/definitions"
 	"github.com/Peersyst/xrpl-go/binary-codec/types"
 	"github.com/stretchr/testify/require"
 )
@@ -755,3 +758,96 @@ func TestEncodeForSigningBatch(t *testing.T) {
 		})
 	}
 }
+
+// newTestDefinitions returns the embedded definitions extended with a UInt32 field
+// NewField and a transaction type NewTransaction, unknown to the embedded ones.
+func newTestDefinitions(t *testing.T) *definitions.Definitions {
+	t.Helper()
+
+	b, err := os.ReadFile("definitions/definitions.json")
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(b, &doc))
+	doc["FIELDS"] = append(doc["FIELDS"].([]any), []any{"NewField", map[string]any{
+		"nth": 99, "isVLEncoded": false, "isSerialized": true, "isSigningField": true, "type": "UInt32",
+	}})
+	doc["TRANSACTION_TYPES"].(map[string]any)["NewTransaction"] = 250
+
+	b, err = json.Marshal(doc)
+	require.NoError(t, err)
+	defs, err := definitions.New(b)
+	require.NoError(t, err)
+	return defs
+}
+
+func TestCodec(t *testing.T) {
+	tx := func() map[string]any {
+		return map[string]any{
+			"TransactionType": "NewTransaction",
+			"Account":         "rMBzp8CgpE441cp5PVyA9rpVV7oT8hP3ys",
+			"Fee":             "10",
+			"Sequence":        uint32(1),
+			"NewField":        uint32(42),
+			"SigningPubKey":   "03EE83BB432547885C219634A1BC407A9DB0474145D69737D09CCDC63E1DEE7FE3",
+			"Memos": []any{
+				map[string]any{"Memo": map[string]any{"MemoData": "AB"}},
+			},
+		}
+	}
+
+	c := NewCodec(newTestDefinitions(t))
+
+	encoded, err := c.Encode(tx())
+	require.NoError(t, err)
+
+	decoded, err := c.Decode(encoded)
+	require.NoError(t, err)
+	require.Equal(t, "NewTransaction", decoded["TransactionType"])
+	require.Equal(t, uint32(42), decoded["NewField"])
+	require.Equal(t, []any{map[string]any{"Memo": map[string]any{"MemoData": "AB"}}}, decoded["Memos"])
+
+	signing, err := c.EncodeForSigning(tx())
+	require.NoError(t, err)
+	require.Equal(t, txSigPrefix+encoded, signing)
+
+	// The embedded definitions don't know the transaction type, nor the field.
+	_, err = Encode(tx())
+	require.Error(t, err)
+	_, err = Decode(encoded)
+	require.Error(t, err)
+}
+
+func TestCodec_Permissions(t *testing.T) {
+	tx := func() map[string]any {
+		return map[string]any{
+			"TransactionType": "DelegateSet",
+			"Account":         "rMBzp8CgpE441cp5PVyA9rpVV7oT8hP3ys",
+			"Authorize":       "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+			"Fee":             "10",
+			"Sequence":        uint32(1),
+			"SigningPubKey":   "03EE83BB432547885C219634A1BC407A9DB0474145D69737D09CCDC63E1DEE7FE3",
+			"Permissions": []any{
+				map[string]any{"Permission": map[string]any{"PermissionValue": "NewTransaction"}},
+				map[string]any{"Permission": map[string]any{"PermissionValue": "TrustlineAuthorize"}},
+			},
+		}
+	}
+
+	c := NewCodec(newTestDefinitions(t))
+
+	encoded, err := c.Encode(tx())
+	require.NoError(t, err)
+
+	decoded, err := c.Decode(encoded)
+	require.NoError(t, err)
+	require.Equal(t, tx()["Permissions"], decoded["Permissions"])
+
+	// The embedded definitions don't know the permission of the transaction type.
+	_, err = Encode(tx())
+	require.Error(t, err)
+}
+
+func TestNewCodec_Default(t *testing.T) {
+	require.Equal(t, definitions.Get(), NewCodec(nil).Definitions())
+}
@@ -2,6 +2,7 @@ package definitions
 
 import (
 	_ "embed"
+	"fmt"
 
 	"github.com/ugorji/go/codec"
 )
@@ -15,6 +16,9 @@ var (
 )
 
 type Definitions struct {
+	// Hash identifies the definitions of a server, as returned by its server_definitions
+	// method. It is empty for the embedded definitions.
+	Hash                   string
 	Types                  map[string]int32
 	LedgerEntryTypes       map[string]int32
 	Fields                 fieldInstanceMap
@@ -25,11 +29,14 @@ type Definitions struct {
 	DelegatablePermissions map[string]int32
 }
 
+// Get returns the definitions embedded in the library, used by default to encode and
+// decode.
 func Get() *Definitions {
 	return definitions
 }
 
 type definitionsDoc struct {
+	Hash               string           `json:"hash"`
 	Types              map[string]int32 `json:"TYPES"`
 	LedgerEntryTypes   map[string]int32 `json:"LEDGER_ENTRY_TYPES"`
 	Fields             fieldInstanceMap `json:"FIELDS"`
@@ -37,22 +44,35 @@ type definitionsDoc struct {
 	TransactionTypes   map[string]int32 `json:"TRANSACTION_TYPES"`
 }
 
-// Loads JSON from the definitions file and converts it to a preferred format.
-// The definitions file contains information required for the XRP Ledger's
-// canonical binary serialization format:
-// `Serialization <https://xrpl.org/serialization.html>`_
-func loadDefinitions() {
-
+// New builds definitions from a JSON document in the format of the definitions.json
+// file, which is also the result of the server_definitions method. It allows encoding
+// and decoding with the fields and transaction types of a network, such as those added
+// by an amendment the embedded definitions don't know yet.
+func New(doc []byte) (d *Definitions, err error) {
 	var jh codec.JsonHandle
 
 	jh.MapKeyAsString = true
 	jh.SignedInteger = true
 
-	dec := codec.NewDecoderBytes(docBytes, &jh)
+	// The field instances are decoded by CodecDecodeSelf, which can only report its
+	// errors by panicking.
+	defer func() {
+		if r := recover(); r != nil {
+			d, err = nil, fmt.Errorf("%w: %v", ErrInvalidDefinitions, r)
+		}
+	}()
+
+	dec := codec.NewDecoderBytes(doc, &jh)
 	var data definitionsDoc
-	dec.MustDecode(&data)
+	if err := dec.Decode(&data); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDefinitions, err)
+	}
+	if len(data.Types) == 0 || len(data.Fields) == 0 {
+		return nil, ErrInvalidDefinitions
+	}
 
-	definitions = &Definitions{
+	d = &Definitions{
+		Hash:               data.Hash,
 		Types:              data.Types,
 		Fields:             data.Fields,
 		LedgerEntryTypes:   data.LedgerEntryTypes,
@@ -60,9 +80,22 @@ func loadDefinitions() {
 		TransactionTypes:   data.TransactionTypes,
 	}
 
-	addFieldHeadersAndOrdinals()
-	createFieldIDNameMap()
-	initializePermissions()
+	d.addFieldHeadersAndOrdinals()
+	d.createFieldIDNameMap()
+	d.initializePermissions()
+	return d, nil
+}
+
+// Loads JSON from the definitions file and converts it to a preferred format.
+// The definitions file contains information required for the XRP Ledger's
+// canonical binary serialization format:
+// `Serialization <https://xrpl.org/serialization.html>`_
+func loadDefinitions() {
+	d, err := New(docBytes)
+	if err != nil {
+		panic(err)
+	}
+	definitions = d
 }
 
 func convertToFieldInstanceMap(m [][]interface{}) map[string]*FieldInstance {
@@ -70,7 +103,10 @@ func convertToFieldInstanceMap(m [][]interface{}) map[string]*FieldInstance {
 
 	for _, j := range m {
 		k := j[0].(string)
-		fi, _ := castFieldInfo(j[1])
+		fi, err := castFieldInfo(j[1])
+		if err != nil {
+			panic(err)
+		}
 		nm[k] = &FieldInstance{
 			FieldName: k,
 			FieldInfo: &fi,
@@ -95,32 +131,32 @@ func castFieldInfo(v interface{}) (FieldInfo, error) {
 	return FieldInfo{}, ErrUnableToCastFieldInfo
 }
 
-func addFieldHeadersAndOrdinals() {
-	for k := range definitions.Fields {
-		t, _ := definitions.GetTypeCodeByTypeName(definitions.Fields[k].Type)
+func (d *Definitions) addFieldHeadersAndOrdinals() {
+	for k := range d.Fields {
+		t, _ := d.GetTypeCodeByTypeName(d.Fields[k].Type)
 
-		if fi, ok := definitions.Fields[k]; ok {
+		if fi, ok := d.Fields[k]; ok {
 			fi.FieldHeader = &FieldHeader{
 				TypeCode:  t,
-				FieldCode: definitions.Fields[k].Nth,
+				FieldCode: d.Fields[k].Nth,
 			}
-			fi.Ordinal = (t<<16 | definitions.Fields[k].Nth)
+			fi.Ordinal = (t<<16 | d.Fields[k].Nth)
 		}
 	}
 }
 
-func createFieldIDNameMap() {
-	definitions.FieldIDNameMap = make(map[FieldHeader]string, len(definitions.Fields))
-	for k := range definitions.Fields {
-		fh, _ := definitions.GetFieldHeaderByFieldName(k)
+func (d *Definitions) createFieldIDNameMap() {
+	d.FieldIDNameMap = make(map[FieldHeader]string, len(d.Fields))
+	for k := range d.Fields {
+		fh, _ := d.GetFieldHeaderByFieldName(k)
 
-		definitions.FieldIDNameMap[*fh] = k
+		d.FieldIDNameMap[*fh] = k
 	}
 }
 
 // Initializes granular permissions and delegatable permissions mappings for account permission delegation.
-func initializePermissions() {
-	definitions.GranularPermissions = map[string]int32{
+func (d *Definitions) initializePermissions() {
+	d.GranularPermissions = map[string]int32{
 		"TrustlineAuthorize":     65537,
 		"TrustlineFreeze":        65538,
 		"TrustlineUnfreeze":      65539,
@@ -135,13 +171,13 @@ func initializePermissions() {
 		"MPTokenIssuanceUnlock":  65548,
 	}
 
-	definitions.DelegatablePermissions = make(map[string]int32)
+	d.DelegatablePermissions = make(map[string]int32)
 
-	for name, value := range definitions.GranularPermissions {
-		definitions.DelegatablePermissions[name] = value
+	for name, value := range d.GranularPermissions {
+		d.DelegatablePermissions[name] = value
 	}
 
-	for txType, value := range definitions.TransactionTypes {
-		definitions.DelegatablePermissions[txType] = value + 1
+	for txType, value := range d.TransactionTypes {
+		d.DelegatablePermissions[txType] = value + 1
 	}
 }
@@ -64,3 +64,52 @@ func TestGet(t *testing.T) {
 	loadDefinitions()
 	require.Equal(t, definitions, Get())
 }
+
+func TestNew(t *testing.T) {
+	doc := `{
+		"hash": "0123ABCD",
+		"TYPES": {"Done": -1, "UInt16": 1, "UInt32": 2, "STObject": 14},
+		"LEDGER_ENTRY_TYPES": {"AccountRoot": 97},
+		"FIELDS": [
+			["TransactionType", {"nth": 2, "isVLEncoded": false, "isSerialized": true, "isSigningField": true, "type": "UInt16"}],
+			["NewField", {"nth": 99, "isVLEncoded": false, "isSerialized": true, "isSigningField": true, "type": "UInt32"}]
+		],
+		"TRANSACTION_RESULTS": {"tesSUCCESS": 0},
+		"TRANSACTION_TYPES": {"Payment": 0, "NewTransaction": 90}
+	}`
+
+	d, err := New([]byte(doc))
+	require.NoError(t, err)
+	require.Equal(t, "0123ABCD", d.Hash)
+	require.Equal(t, &FieldHeader{TypeCode: 2, FieldCode: 99}, d.Fields["NewField"].FieldHeader)
+	require.Equal(t, int32(2<<16|99), d.Fields["NewField"].Ordinal)
+	require.Equal(t, "NewField", d.FieldIDNameMap[FieldHeader{TypeCode: 2, FieldCode: 99}])
+	require.Equal(t, int32(91), d.DelegatablePermissions["NewTransaction"])
+
+	name, err := d.GetFieldNameByFieldHeader(FieldHeader{TypeCode: 2, FieldCode: 99})
+	require.NoError(t, err)
+	require.Equal(t, "NewField", name)
+
+	// The embedded definitions are left untouched.
+	_, err = Get().GetFieldNameByFieldHeader(FieldHeader{TypeCode: 2, FieldCode: 99})
+	require.Error(t, err)
+}
+
+func TestNew_Invalid(t *testing.T) {
+	tt := []struct {
+		description string
+		doc         string
+	}{
+		{description: "not json", doc: `not json`},
+		{description: "no fields", doc: `{"TYPES": {"UInt32": 2}}`},
+		{description: "malformed field", doc: `{"TYPES": {"UInt32": 2}, "FIELDS": [["Field", {"nth": "1"}]]}`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.description, func(t *testing.T) {
+			d, err := New([]byte(tc.doc))
+			require.ErrorIs(t, err, ErrInvalidDefinitions)
+			require.Nil(t, d)
+		})
+	}
+}
@@ -10,6 +10,8 @@ var (
 
 	// ErrUnableToCastFieldInfo is returned when the field info cannot be cast.
 	ErrUnableToCastFieldInfo = errors.New("unable to cast to field info")
+	// ErrInvalidDefinitions is returned when definitions can't be built from a JSON document.
+	ErrInvalidDefinitions = errors.New("invalid definitions")
 )
 
 // Dynamic errors
@@ -6,7 +6,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/Peersyst/xrpl-go/binary-codec/definitions"
 	"github.com/Peersyst/xrpl-go/binary-codec/serdes"
 )
 
@@ -26,12 +25,17 @@ type LedgerData struct {
 // DecodeLedgerData decodes a hex string in the canonical binary format into a LedgerData object.
 // The hex string should represent a ledger data object.
 func DecodeLedgerData(data string) (LedgerData, error) {
+	return defaultCodec.DecodeLedgerData(data)
+}
+
+// DecodeLedgerData is like the package-level DecodeLedgerData, with the definitions of the codec.
+func (c *Codec) DecodeLedgerData(data string) (LedgerData, error) {
 	decoded, err := hex.DecodeString(data)
 	if err != nil {
 		return LedgerData{}, err
 	}
 
-	parser := serdes.NewBinaryParser(decoded, definitions.Get())
+	parser := serdes.NewBinaryParser(decoded, c.definitions)
 	var ledgerData LedgerData
 
 	ledgerIndex, err := parser.ReadBytes(4)
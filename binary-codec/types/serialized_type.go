@@ -22,11 +22,18 @@ type SerializedType interface {
 // the appropriate methods of that type to be called.
 // If the input string does not match a known type, the function returns nil.
 func GetSerializedType(t string) SerializedType {
+	return getSerializedType(t, nil)
+}
+
+// getSerializedType is like GetSerializedType, but the returned types that depend on
+// the definitions, such as STObject, use defs. A nil defs stands for the embedded
+// definitions.
+func getSerializedType(t string, defs *definitions.Definitions) SerializedType {
 	switch t {
 	case "UInt8":
-		return &UInt8{}
+		return &UInt8{definitions: defs}
 	case "UInt16":
-		return &UInt16{}
+		return &UInt16{definitions: defs}
 	case "UInt32":
 		return &UInt32{}
 	case "UInt64":
@@ -46,9 +53,10 @@ func GetSerializedType(t string) SerializedType {
 	case "Blob":
 		return &Blob{}
 	case "STObject":
-		return NewSTObject(serdes.NewBinarySerializer(serdes.NewFieldIDCodec(definitions.Get())))
+		defs = definitionsOrDefault(defs)
+		return NewSTObjectWithDefinitions(serdes.NewBinarySerializer(serdes.NewFieldIDCodec(defs)), defs)
 	case "STArray":
-		return &STArray{}
+		return &STArray{definitions: defs}
 	case "PathSet":
 		return &PathSet{}
 	case "XChainBridge":
@@ -57,6 +65,16 @@ func GetSerializedType(t string) SerializedType {
 		return &Issue{}
 	case "Currency":
 		return &Currency{}
+	case "PermissionValue":
+		return &PermissionValue{definitions: defs}
 	}
 	return nil
 }
+
+// definitionsOrDefault returns defs, or the embedded definitions if defs is nil.
+func definitionsOrDefault(defs *definitions.Definitions) *definitions.Definitions {
+	if defs == nil {
+		return definitions.Get()
+	}
+	return defs
+}
@@ -14,7 +14,11 @@ const (
 )
 
 // STArray represents an array of STObject instances.
-type STArray struct{}
+type STArray struct {
+	// definitions are those of the objects of the array, the embedded definitions are
+	// used if nil.
+	definitions *definitions.Definitions
+}
 
 var ErrNotSTObjectInSTArray = errors.New("not STObject in STArray. Array fields must be STObjects")
 
@@ -38,7 +42,8 @@ func (t *STArray) FromJSON(json any) ([]byte, error) {
 
 	var sink []byte
 	for _, v := range json.([]any) {
-		st := NewSTObject(serdes.NewBinarySerializer(serdes.NewFieldIDCodec(definitions.Get())))
+		defs := definitionsOrDefault(t.definitions)
+		st := NewSTObjectWithDefinitions(serdes.NewBinarySerializer(serdes.NewFieldIDCodec(defs)), defs)
 		b, err := st.FromJSON(v)
 		if err != nil {
 			return nil, err
@@ -71,7 +76,7 @@ func (t *STArray) ToJSON(p interfaces.BinaryParser, _ ...int) (any, error) {
 			break
 		}
 		fn := fi.FieldName
-		st := GetSerializedType(fi.Type)
+		st := getSerializedType(fi.Type, definitionsOrDefault(t.definitions))
 		res, err := st.ToJSON(p)
 		if err != nil {
 			return nil, err
@@ -12,11 +12,20 @@ import (
 // and complex structures of the Ripple protocol.
 type STObject struct {
 	binarySerializer interfaces.BinarySerializer
+	definitions      *definitions.Definitions
 }
 
-// NewSTObject returns a new STObject with the given binary serializer.
+// NewSTObject returns a new STObject with the given binary serializer, using the
+// embedded definitions.
 func NewSTObject(bs interfaces.BinarySerializer) *STObject {
-	return &STObject{binarySerializer: bs}
+	return &STObject{binarySerializer: bs, definitions: definitions.Get()}
+}
+
+// NewSTObjectWithDefinitions returns a new STObject with the given binary serializer,
+// which resolves fields and enumerations with defs, e.g. those of a server that knows
+// fields the embedded definitions don't. bs should write field headers with defs too.
+func NewSTObjectWithDefinitions(bs interfaces.BinarySerializer, defs *definitions.Definitions) *STObject {
+	return &STObject{binarySerializer: bs, definitions: definitionsOrDefault(defs)}
 }
 
 // FromJSON converts a JSON object into a serialized byte slice.
@@ -27,7 +36,8 @@ func (t *STObject) FromJSON(json any) ([]byte, error) {
 	if _, ok := json.(map[string]any); !ok {
 		return nil, errNotValidJSON
 	}
-	fimap, err := createFieldInstanceMapFromJson(json.(map[string]any))
+	defs := definitionsOrDefault(t.definitions)
+	fimap, err := createFieldInstanceMapFromJson(defs, json.(map[string]any))
 
 	if err != nil {
 		return nil, err
@@ -40,7 +50,7 @@ func (t *STObject) FromJSON(json any) ([]byte, error) {
 			continue
 		}
 
-		st := GetSerializedType(v.Type)
+		st := getSerializedType(v.Type, defs)
 		b, err := st.FromJSON(fimap[v])
 		if err != nil {
 			return nil, err
@@ -57,6 +67,7 @@ func (t *STObject) FromJSON(json any) ([]byte, error) {
 // back to a JSON value. It will continue parsing until it encounters an end marker for an object
 // or an array, or until the parser has no more data.
 func (t *STObject) ToJSON(p interfaces.BinaryParser, _ ...int) (any, error) {
+	defs := definitionsOrDefault(t.definitions)
 	m := make(map[string]any)
 
 	for p.HasMore() {
@@ -70,7 +81,7 @@ func (t *STObject) ToJSON(p interfaces.BinaryParser, _ ...int) (any, error) {
 			break
 		}
 
-		st := GetSerializedType(fi.Type)
+		st := getSerializedType(fi.Type, defs)
 
 		var res any
 		if fi.IsVLEncoded {
@@ -89,7 +100,7 @@ func (t *STObject) ToJSON(p interfaces.BinaryParser, _ ...int) (any, error) {
 				return nil, err
 			}
 		}
-		res, err = enumToStr(fi.FieldName, res)
+		res, err = enumToStr(defs, fi.FieldName, res)
 		if err != nil {
 			return nil, err
 		}
@@ -106,17 +117,17 @@ func (t *STObject) ToJSON(p interfaces.BinaryParser, _ ...int) (any, error) {
 // Special handling for PermissionValue fields: converts string permission names to numeric values.
 //
 //lint:ignore U1000 // ignore this for now
-func createFieldInstanceMapFromJson(json map[string]any) (map[definitions.FieldInstance]any, error) {
+func createFieldInstanceMapFromJson(defs *definitions.Definitions, json map[string]any) (map[definitions.FieldInstance]any, error) {
 	m := make(map[definitions.FieldInstance]any, len(json))
 
 	for k, v := range json {
-		fi, err := definitions.Get().GetFieldInstanceByFieldName(k)
+		fi, err := defs.GetFieldInstanceByFieldName(k)
 
 		if err != nil {
 			return nil, err
 		}
 
-		v, err = parseSpecialFields(k, v)
+		v, err = parseSpecialFields(defs, k, v)
 		if err != nil {
 			return nil, err
 		}
@@ -127,10 +138,10 @@ func createFieldInstanceMapFromJson(json map[string]any) (map[definitions.FieldI
 }
 
 // parseSpecialFields is a helper function that handles special fields that need type parsing.
-func parseSpecialFields(k string, v any) (any, error) {
+func parseSpecialFields(defs *definitions.Definitions, k string, v any) (any, error) {
 	if k == "PermissionValue" {
 		if strValue, ok := v.(string); ok {
-			permissionValue, err := definitions.Get().GetDelegatablePermissionValueByName(strValue)
+			permissionValue, err := defs.GetDelegatablePermissionValueByName(strValue)
 			if err != nil {
 				return nil, err
 			}
@@ -166,24 +177,24 @@ func getSortedKeys(m map[definitions.FieldInstance]any) []definitions.FieldInsta
 // and returns a string representation of the value if the field is an enumerated type
 // (i.e., TransactionType, TransactionResult, LedgerEntryType, PermissionValue).
 // If the field is not an enumerated type, the original value is returned.
-func enumToStr(fieldName string, value any) (any, error) {
+func enumToStr(defs *definitions.Definitions, fieldName string, value any) (any, error) {
 	switch fieldName {
 	case "TransactionType":
 		// TODO: Check if this is still needed
 		//nolint:gosec // G115: Potential hardcoded credentials (gosec)
-		return definitions.Get().GetTransactionTypeNameByTransactionTypeCode(int32(value.(int)))
+		return defs.GetTransactionTypeNameByTransactionTypeCode(int32(value.(int)))
 	case "TransactionResult":
 		// TODO: Check if this is still needed
 		//nolint:gosec // G115: Potential hardcoded credentials (gosec)
-		return definitions.Get().GetTransactionResultNameByTransactionResultTypeCode(int32(value.(int)))
+		return defs.GetTransactionResultNameByTransactionResultTypeCode(int32(value.(int)))
 	case "LedgerEntryType":
 		// TODO: Check if this is still needed
 		//nolint:gosec // G115: Potential hardcoded credentials (gosec)
-		return definitions.Get().GetLedgerEntryTypeNameByLedgerEntryTypeCode(int32(value.(int)))
+		return defs.GetLedgerEntryTypeNameByLedgerEntryTypeCode(int32(value.(int)))
 	case "PermissionValue":
 		// Convert permission value to permission name if available, otherwise return numeric value
 		//nolint:gosec // G115: Potential hardcoded credentials (gosec)
-		if name, err := definitions.Get().GetDelegatablePermissionNameByValue(int32(value.(uint32))); err == nil {
+		if name, err := defs.GetDelegatablePermissionNameByValue(int32(value.(uint32))); err == nil {
 			return name, nil
 		}
 		return value, nil
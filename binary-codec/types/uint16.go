@@ -9,7 +9,11 @@ import (
 )
 
 // UInt16 represents a 16-bit unsigned integer.
-type UInt16 struct{}
+type UInt16 struct {
+	// definitions resolve transaction and ledger entry type names, the embedded
+	// definitions are used if nil.
+	definitions *definitions.Definitions
+}
 
 // FromJSON converts a JSON value into a serialized byte slice representing a 16-bit unsigned integer.
 // If the input value is a string, it's assumed to be a transaction type or ledger entry type name, and the
@@ -17,9 +21,10 @@ type UInt16 struct{}
 func (u *UInt16) FromJSON(value any) ([]byte, error) {
 
 	if _, ok := value.(string); ok {
-		tc, err := definitions.Get().GetTransactionTypeCodeByTransactionTypeName(value.(string))
+		defs := definitionsOrDefault(u.definitions)
+		tc, err := defs.GetTransactionTypeCodeByTransactionTypeName(value.(string))
 		if err != nil {
-			tc, err = definitions.Get().GetLedgerEntryTypeCodeByLedgerEntryTypeName(value.(string))
+			tc, err = defs.GetLedgerEntryTypeCodeByLedgerEntryTypeName(value.(string))
 			if err != nil {
 				return nil, err
 			}
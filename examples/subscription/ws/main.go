@@ -41,8 +41,14 @@ func main() {
 		return
 	}
 
-	client.OnError(func(err error) {
-		fmt.Println("Error: ", err)
+	client.OnDisconnected(func(err error) {
+		if err != nil {
+			fmt.Println("Connection lost: ", err)
+		}
+	})
+
+	client.OnResubscribed(func() {
+		fmt.Println("Reconnected and resubscribed")
 	})
 
 	client.OnLedgerClosed(func(ledger *streamtypes.LedgerStream) {
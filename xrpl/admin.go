@@ -0,0 +1,80 @@
+package xrpl
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+)
+
+var (
+	// ErrAdminRequest is returned when an admin method is sent to an endpoint that is
+	// not known to accept admin methods.
+	ErrAdminRequest = errors.New("admin method refused on a non-admin endpoint")
+	// ErrSecretRequest is returned when a request carrying secret keys, such as sign, is
+	// sent to an endpoint that is not known to be trusted with them.
+	ErrSecretRequest = errors.New("request with secret keys refused on a non-admin endpoint")
+)
+
+// AdminRequest is implemented by the requests of admin methods, which servers only
+// accept on their admin ports, such as ledger_accept or stop. Clients refuse them
+// unless their endpoint is an admin one, see IsLocalURL.
+type AdminRequest interface {
+	Request
+	AdminOnly() bool
+}
+
+// IsAdminRequest reports whether req is the request of an admin method.
+func IsAdminRequest(req Request) bool {
+	r, ok := req.(AdminRequest)
+	return ok && r.AdminOnly()
+}
+
+// SecretRequest is implemented by requests that can carry secret keys for the server to
+// sign with, such as sign or submit in sign-and-submit mode. Clients only send them to
+// admin endpoints, as they would leak the keys to any other server.
+type SecretRequest interface {
+	Request
+	HasSecret() bool
+}
+
+// IsSecretRequest reports whether req carries secret keys.
+func IsSecretRequest(req Request) bool {
+	r, ok := req.(SecretRequest)
+	return ok && r.HasSecret()
+}
+
+// CheckEndpoint returns ErrAdminRequest or ErrSecretRequest if req must not be sent to
+// an endpoint, admin telling whether the endpoint is an admin one.
+func CheckEndpoint(req Request, admin bool) error {
+	if admin {
+		return nil
+	}
+	if IsAdminRequest(req) {
+		return ErrAdminRequest
+	}
+	if IsSecretRequest(req) {
+		return ErrSecretRequest
+	}
+	return nil
+}
+
+// IsLocalURL reports whether rawURL points to the local machine: localhost, a loopback
+// address or the unspecified address. Clients consider local endpoints admin ones
+// unless configured otherwise. rawURL may omit the scheme.
+func IsLocalURL(rawURL string) bool {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "//" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	host := u.Hostname()
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && (ip.IsLoopback() || ip.IsUnspecified())
+}
@@ -0,0 +1,72 @@
+package xrpl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testAdminRequest struct {
+	testRequest
+}
+
+func (testAdminRequest) AdminOnly() bool { return true }
+
+func TestIsAdminRequest(t *testing.T) {
+	require.True(t, IsAdminRequest(testAdminRequest{}))
+	require.False(t, IsAdminRequest(testRequest{}))
+}
+
+type testSecretRequest struct {
+	testRequest
+	secret string
+}
+
+func (r testSecretRequest) HasSecret() bool { return r.secret != "" }
+
+func TestCheckEndpoint(t *testing.T) {
+	tt := []struct {
+		name    string
+		req     Request
+		admin   bool
+		wantErr error
+	}{
+		{name: "public request", req: testRequest{}},
+		{name: "admin request", req: testAdminRequest{}, wantErr: ErrAdminRequest},
+		{name: "admin request on admin endpoint", req: testAdminRequest{}, admin: true},
+		{name: "request with secret", req: testSecretRequest{secret: "s"}, wantErr: ErrSecretRequest},
+		{name: "request without secret", req: testSecretRequest{}},
+		{name: "request with secret on admin endpoint", req: testSecretRequest{secret: "s"}, admin: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.wantErr, CheckEndpoint(tc.req, tc.admin))
+		})
+	}
+}
+
+func TestIsLocalURL(t *testing.T) {
+	tt := []struct {
+		url   string
+		local bool
+	}{
+		{url: "http://localhost:5005/", local: true},
+		{url: "ws://LOCALHOST:6006", local: true},
+		{url: "localhost", local: true},
+		{url: "http://127.0.0.1:5005", local: true},
+		{url: "ws://0.0.0.0:6006", local: true},
+		{url: "http://[::1]:5005", local: true},
+		{url: "127.0.0.1:5005", local: true},
+		{url: "https://s.altnet.rippletest.net:51234", local: false},
+		{url: "wss://s1.ripple.com", local: false},
+		{url: "http://10.0.0.1:5005", local: false},
+		{url: "", local: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.url, func(t *testing.T) {
+			require.Equal(t, tc.local, IsLocalURL(tc.url))
+		})
+	}
+}
@@ -0,0 +1,268 @@
+// Package autofill fills in the network dependent fields of a transaction (Sequence, Fee,
+// LastLedgerSequence, NetworkID, ...) before it is signed.
+//
+// The Engine only needs an xrpl.Requester, so the JSON-RPC and websocket clients share
+// the exact same implementation instead of maintaining one copy each.
+package autofill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	commonconstants "github.com/Peersyst/xrpl-go/xrpl/common"
+	account "github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	ledger "github.com/Peersyst/xrpl-go/xrpl/queries/ledger"
+	"github.com/Peersyst/xrpl-go/xrpl/sequence"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+// Config holds the client settings the Engine depends on.
+type Config struct {
+	// NetworkID is set on transactions that don't specify one. Zero leaves it unset.
+	NetworkID uint32
+	// MaxFeeXRP caps the fee of every transaction, except those with a special transaction cost.
+	MaxFeeXRP float32
+	// FeeCushion multiplies the network fee to leave room for load increases. It is the
+	// cushion of the default FeeStrategy.
+	FeeCushion float32
+	// FeeStrategy estimates the network fee of transactions. Nil uses the BaseFeeStrategy
+	// with FeeCushion.
+	FeeStrategy FeeStrategy
+	// FeeReporter, if not nil, is called with every fee set, and the reason it was chosen.
+	FeeReporter FeeReporter
+	// Sequences hands out the sequences of transactions. Nil queries account_info for
+	// every transaction.
+	Sequences *sequence.Allocator
+}
+
+// Engine autofills transactions using the queries it sends through a Requester.
+type Engine struct {
+	r   xrpl.Requester
+	cfg Config
+}
+
+// NewEngine returns an Engine that sends its queries through r.
+func NewEngine(r xrpl.Requester, cfg Config) *Engine {
+	return &Engine{
+		r:   r,
+		cfg: cfg,
+	}
+}
+
+// Autofill fills in the missing fields in a transaction.
+func (e *Engine) Autofill(ctx context.Context, tx *transaction.FlatTransaction) error {
+	_, hasSequence := (*tx)["Sequence"]
+	err := e.autofill(ctx, tx)
+	if err != nil && !hasSequence {
+		e.releaseSequence(*tx)
+	}
+	return err
+}
+
+func (e *Engine) autofill(ctx context.Context, tx *transaction.FlatTransaction) error {
+	if err := e.setValidTransactionAddresses(tx); err != nil {
+		return err
+	}
+
+	err := e.setTransactionFlags(tx)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := (*tx)["NetworkID"]; !ok {
+		if e.cfg.NetworkID != 0 {
+			(*tx)["NetworkID"] = e.cfg.NetworkID
+		}
+	}
+	if _, ok := (*tx)["Sequence"]; !ok {
+		err := e.setTransactionNextValidSequenceNumber(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+	if _, ok := (*tx)["Fee"]; !ok {
+		err := e.calculateFeePerTransactionType(ctx, tx, 0)
+		if err != nil {
+			return err
+		}
+	}
+	if _, ok := (*tx)["LastLedgerSequence"]; !ok {
+		err := e.setLastLedgerSequence(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if txType, ok := (*tx)["TransactionType"].(string); ok {
+		if acc, ok := (*tx)["Account"].(types.Address); txType == transaction.AccountDeleteTx.String() && ok {
+			err := e.checkAccountDeleteBlockers(ctx, acc)
+			if err != nil {
+				return err
+			}
+		}
+		if txType == transaction.PaymentTx.String() {
+			err := e.checkPaymentAmounts(tx)
+			if err != nil {
+				return err
+			}
+		}
+		if txType == transaction.BatchTx.String() {
+			err := e.autofillRawTransactions(ctx, tx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AutofillMultisigned fills in the missing fields in a multisigned transaction.
+// It fills in the missing fields in the transaction and calculates the fee per number of signers.
+func (e *Engine) AutofillMultisigned(ctx context.Context, tx *transaction.FlatTransaction, nSigners uint64) error {
+	err := e.Autofill(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	return e.calculateFeePerTransactionType(ctx, tx, nSigners)
+}
+
+// TODO: Implement this when IsValidXAddress is implemented
+func (e *Engine) getClassicAccountAndTag(address string) (string, uint32) {
+	return address, 0
+}
+
+func (e *Engine) convertTransactionAddressToClassicAddress(tx *transaction.FlatTransaction, fieldName string) {
+	if address, ok := (*tx)[fieldName].(string); ok {
+		classicAddress, _ := e.getClassicAccountAndTag(address)
+		(*tx)[fieldName] = classicAddress
+	}
+}
+
+func (e *Engine) validateTransactionAddress(tx *transaction.FlatTransaction, addressField, tagField string) error {
+	classicAddress, tag := e.getClassicAccountAndTag((*tx)[addressField].(string))
+	(*tx)[addressField] = classicAddress
+
+	if tag != uint32(0) {
+		if txTag, ok := (*tx)[tagField].(uint32); ok && txTag != tag {
+			return fmt.Errorf("the %s, if present, must be equal to the tag of the %s", addressField, tagField)
+		}
+		(*tx)[tagField] = tag
+	}
+
+	return nil
+}
+
+// Sets valid addresses for the transaction.
+func (e *Engine) setValidTransactionAddresses(tx *transaction.FlatTransaction) error {
+	// Validate if "Account" address is an xAddress
+	if err := e.validateTransactionAddress(tx, "Account", "SourceTag"); err != nil {
+		return err
+	}
+
+	if _, ok := (*tx)["Destination"]; ok {
+		if err := e.validateTransactionAddress(tx, "Destination", "DestinationTag"); err != nil {
+			return err
+		}
+	}
+
+	// DepositPreuaht
+	e.convertTransactionAddressToClassicAddress(tx, "Authorize")
+	e.convertTransactionAddressToClassicAddress(tx, "Unauthorize")
+	// EscrowCancel, EscrowFinish
+	e.convertTransactionAddressToClassicAddress(tx, "Owner")
+	// SetRegularKey
+	e.convertTransactionAddressToClassicAddress(tx, "RegularKey")
+
+	return nil
+}
+
+// Sets a transaction's flags to its numeric representation.
+// TODO: Add flag support for AMMDeposit, AMMWithdraw,
+// NFTTOkenCreateOffer, NFTokenMint, OfferCreate, XChainModifyBridge (not supported).
+func (e *Engine) setTransactionFlags(tx *transaction.FlatTransaction) error {
+	flags, ok := (*tx)["Flags"].(uint32)
+	if !ok && flags > 0 {
+		(*tx)["Flags"] = int(0)
+		return nil
+	}
+
+	_, ok = (*tx)["TransactionType"].(string)
+	if !ok {
+		return errors.New("transaction type is missing in transaction")
+	}
+
+	return nil
+}
+
+// Sets the next valid sequence number for a given transaction.
+func (e *Engine) setTransactionNextValidSequenceNumber(ctx context.Context, tx *transaction.FlatTransaction) error {
+	if _, ok := (*tx)["Account"].(string); !ok {
+		return errors.New("missing Account in transaction")
+	}
+	if e.cfg.Sequences != nil {
+		seq, err := e.cfg.Sequences.Next(ctx, e.r, types.Address((*tx)["Account"].(string)))
+		if err != nil {
+			return err
+		}
+		(*tx)["Sequence"] = seq
+		return nil
+	}
+	res, err := xrpl.Query[account.InfoResponse](ctx, e.r, &account.InfoRequest{
+		Account:     types.Address((*tx)["Account"].(string)),
+		LedgerIndex: common.LedgerTitle("current"),
+	})
+	if err != nil {
+		return err
+	}
+
+	(*tx)["Sequence"] = uint32(res.AccountData.Sequence)
+	return nil
+}
+
+// Sets the latest validated ledger sequence for the transaction.
+// Modifies the `LastLedgerSequence` field in the tx.
+func (e *Engine) setLastLedgerSequence(ctx context.Context, tx *transaction.FlatTransaction) error {
+	res, err := xrpl.Query[ledger.Response](ctx, e.r, &ledger.Request{
+		LedgerIndex: common.LedgerTitle("validated"),
+	})
+	if err != nil {
+		return err
+	}
+
+	(*tx)["LastLedgerSequence"] = res.LedgerIndex.Uint32() + commonconstants.LedgerOffset
+	return nil
+}
+
+// Checks for any blockers that prevent the deletion of an account.
+// Returns nil if there are no blockers, otherwise returns an error.
+func (e *Engine) checkAccountDeleteBlockers(ctx context.Context, address types.Address) error {
+	accObjects, err := xrpl.Query[account.ObjectsResponse](ctx, e.r, &account.ObjectsRequest{
+		Account:              address,
+		LedgerIndex:          common.LedgerTitle("validated"),
+		DeletionBlockersOnly: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(accObjects.AccountObjects) > 0 {
+		return fmt.Errorf("account %s cannot be deleted; there are Escrows, PayChannels, RippleStates, or Checks associated with the account", address)
+	}
+	return nil
+}
+
+func (e *Engine) checkPaymentAmounts(tx *transaction.FlatTransaction) error {
+	if _, ok := (*tx)["DeliverMax"]; ok {
+		if _, ok := (*tx)["Amount"]; !ok {
+			(*tx)["Amount"] = (*tx)["DeliverMax"]
+		} else if (*tx)["Amount"] != (*tx)["DeliverMax"] {
+			return errors.New("payment transaction: Amount and DeliverMax fields must be identical when both are provided")
+		}
+	}
+	return nil
+}
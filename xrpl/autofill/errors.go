@@ -0,0 +1,20 @@
+package autofill
+
+import "errors"
+
+// Static errors
+var (
+	ErrMissingTxSignatureOrSigningPubKey = errors.New("transaction must have a TxnSignature or SigningPubKey set")
+	ErrMissingWallet                     = errors.New("wallet must be provided when submitting an unsigned transaction")
+
+	ErrRawTransactionsFieldIsNotAnArray = errors.New("RawTransactions field is not an array")
+	ErrRawTransactionFieldIsNotAnObject = errors.New("RawTransaction field is not an object")
+
+	ErrSigningPubKeyFieldMustBeEmpty = errors.New("SigningPubKey field must be empty")
+	ErrTxnSignatureFieldMustBeEmpty  = errors.New("TxnSignature field must be empty")
+	ErrSignersFieldMustBeEmpty       = errors.New("Signers field must be empty")
+	ErrAccountFieldIsNotAString      = errors.New("Account field is not a string")
+
+	ErrSignerIsNotAnObject = errors.New("Signers entry is not an object")
+	ErrSignerDataIsEmpty   = errors.New("signer data is empty")
+)
@@ -0,0 +1,171 @@
+package autofill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/currency"
+	server "github.com/Peersyst/xrpl-go/xrpl/queries/server"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+)
+
+// feeStrategy returns the configured FeeStrategy, the BaseFeeStrategy with FeeCushion if
+// there is none.
+func (e *Engine) feeStrategy() FeeStrategy {
+	if e.cfg.FeeStrategy != nil {
+		return e.cfg.FeeStrategy
+	}
+	return BaseFeeStrategy{Cushion: e.cfg.FeeCushion}
+}
+
+// Calculates the fee per transaction type.
+//
+// Enhanced implementation that replicates xrpl.js calculateFeePerTransactionType logic,
+// including special cases for EscrowFinish, AccountDelete, AMMCreate, Batch, and multi-signing.
+func (e *Engine) calculateFeePerTransactionType(ctx context.Context, tx *transaction.FlatTransaction, nSigners uint64) error {
+	// Get base network fee
+	est, err := e.feeStrategy().EstimateFee(ctx, e.r, *tx)
+	if err != nil {
+		return err
+	}
+
+	baseFeeUint := est.Drops
+	baseFee := baseFeeUint
+
+	// Get transaction type
+	transactionType := ""
+	if txType, ok := (*tx)["TransactionType"]; ok {
+		if str, ok := txType.(string); ok {
+			transactionType = str
+		}
+	}
+
+	// Check if this is a special transaction cost type
+	isSpecialTxCost := transactionType == "AccountDelete" || transactionType == "AMMCreate"
+
+	switch transactionType {
+	case "EscrowFinish":
+		if fulfillment, ok := (*tx)["Fulfillment"]; ok && fulfillment != nil {
+			if fulfillmentStr, ok := fulfillment.(string); ok && fulfillmentStr != "" {
+				fulfillmentBytesSize := (len(fulfillmentStr) + 1) / 2 // Math.ceil(length / 2)
+				if fulfillmentBytesSize < 0 {
+					return fmt.Errorf("invalid fulfillment length")
+				}
+				// BaseFee × (33 + ceil(Fulfillment size in bytes / 16))
+				chunks := (uint64(fulfillmentBytesSize) + 15) / 16 // ceil division
+				baseFee = baseFeeUint * (33 + chunks)
+			}
+		}
+	case "AccountDelete", "AMMCreate":
+		reserveFee, err := e.fetchOwnerReserveFee(ctx)
+		if err != nil {
+			return err
+		}
+		baseFee = reserveFee
+	case "Batch":
+		rawTxFees, err := e.calculateBatchFees(ctx, tx)
+		if err != nil {
+			return err
+		}
+		baseFee = baseFeeUint*2 + rawTxFees
+	}
+
+	// Multi-signed Transaction: BaseFee × (1 + Number of Signatures Provided)
+	if nSigners > 0 {
+		signersFee := baseFeeUint * nSigners
+		baseFee += signersFee
+	}
+
+	// Apply max fee limit (but not for special transaction cost types)
+	var totalFee uint64
+	capped := false
+	if isSpecialTxCost {
+		totalFee = baseFee
+	} else {
+		maxFeeDrops, err := currency.XrpToDrops(fmt.Sprintf("%.6f", e.cfg.MaxFeeXRP))
+		if err != nil {
+			return err
+		}
+		maxFeeUint, err := strconv.ParseUint(maxFeeDrops, 10, 64)
+		if err != nil {
+			return err
+		}
+		if baseFee < maxFeeUint {
+			totalFee = baseFee
+		} else {
+			totalFee = maxFeeUint
+			capped = baseFee > maxFeeUint
+		}
+	}
+
+	(*tx)["Fee"] = strconv.FormatUint(totalFee, 10)
+	if e.cfg.FeeReporter != nil {
+		e.cfg.FeeReporter(*tx, FeeReport{Estimate: est, Drops: totalFee, Capped: capped})
+	}
+	return nil
+}
+
+// fetchOwnerReserveFee fetches the owner reserve fee from the server state.
+// Replicates the JavaScript fetchOwnerReserveFee function.
+func (e *Engine) fetchOwnerReserveFee(ctx context.Context) (uint64, error) {
+	response, err := xrpl.Query[server.StateResponse](ctx, e.r, &server.StateRequest{})
+	if err != nil {
+		return 0, err
+	}
+
+	reserveInc := response.State.ValidatedLedger.ReserveInc
+	if reserveInc == 0 {
+		return 0, errors.New("could not fetch Owner Reserve")
+	}
+
+	return uint64(reserveInc), nil
+}
+
+// calculateBatchFees calculates the total fees for all inner transactions in a Batch.
+// Replicates the JavaScript logic for Batch transaction fee calculation.
+func (e *Engine) calculateBatchFees(ctx context.Context, tx *transaction.FlatTransaction) (uint64, error) {
+	var totalFees uint64
+
+	// Get RawTransactions from the batch transaction
+	rawTransactions, ok := (*tx)["RawTransactions"].([]map[string]any)
+	if !ok {
+		return 0, errors.New("RawTransactions field missing from Batch transaction")
+	}
+
+	// Iterate through each raw transaction
+	for _, rawTx := range rawTransactions {
+		// Extract the actual transaction from the wrapper
+		innerTx, ok := rawTx["RawTransaction"].(map[string]any)
+		if !ok {
+			return 0, errors.New("RawTransaction field missing from wrapper")
+		}
+
+		// Calculate fee for this inner transaction (no multi-signing for inner transactions)
+		innerTxFlat := transaction.FlatTransaction(innerTx)
+		err := e.calculateFeePerTransactionType(ctx, &innerTxFlat, 0)
+		if err != nil {
+			return 0, err
+		}
+
+		// Extract the calculated fee
+		feeStr, ok := innerTx["Fee"].(string)
+		if !ok {
+			return 0, errors.New("fee field missing after calculation")
+		}
+
+		innerTx["Fee"] = "0"
+
+		// Convert fee string to uint64 and add to total
+		feeUint, err := strconv.ParseUint(feeStr, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse fee '%s': %w", feeStr, err)
+		}
+
+		totalFees += feeUint
+	}
+
+	return totalFees, nil
+}
@@ -0,0 +1,181 @@
+package autofill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/currency"
+	server "github.com/Peersyst/xrpl-go/xrpl/queries/server"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+)
+
+// FeeEstimate is the network fee chosen by a FeeStrategy.
+type FeeEstimate struct {
+	// Drops is the fee of a reference transaction, in drops. The transaction type specific
+	// costs (multi-signing, EscrowFinish fulfillments, Batch, ...) and the MaxFeeXRP cap
+	// are applied on top of it.
+	Drops uint64
+	// Reason explains how Drops was chosen, e.g. for logging.
+	Reason string
+}
+
+// FeeReport describes the fee autofill set on a transaction, and why it was chosen.
+type FeeReport struct {
+	// Estimate is the estimate of the FeeStrategy, whose Reason explains its Drops.
+	Estimate FeeEstimate
+	// Drops is the fee set on the transaction, in drops: the estimate with the
+	// transaction type specific costs, capped by MaxFeeXRP.
+	Drops uint64
+	// Capped reports whether the fee was lowered to MaxFeeXRP.
+	Capped bool
+}
+
+// FeeReporter is called with every fee autofill sets, e.g. to log why it was chosen.
+type FeeReporter func(tx transaction.FlatTransaction, report FeeReport)
+
+// FeeStrategy estimates the network fee of a transaction from the queries it sends
+// through r.
+type FeeStrategy interface {
+	EstimateFee(ctx context.Context, r xrpl.Requester, tx transaction.FlatTransaction) (FeeEstimate, error)
+}
+
+// BaseFeeStrategy pays the base fee of the last validated ledger, scaled by the load
+// factor of the server and by Cushion. It is the default strategy.
+type BaseFeeStrategy struct {
+	// Cushion multiplies the fee to leave room for load increases. Zero is no cushion.
+	Cushion float32
+}
+
+// EstimateFee implements FeeStrategy with server_info.
+func (s BaseFeeStrategy) EstimateFee(ctx context.Context, r xrpl.Requester, _ transaction.FlatTransaction) (FeeEstimate, error) {
+	res, err := xrpl.Query[server.InfoResponse](ctx, r, &server.InfoRequest{})
+	if err != nil {
+		return FeeEstimate{}, err
+	}
+
+	if res.Info.ValidatedLedger.BaseFeeXRP == 0 {
+		return FeeEstimate{}, errors.New("could not get BaseFeeXrp from ServerInfo")
+	}
+
+	loadFactor := res.Info.LoadFactor
+	if res.Info.LoadFactor == 0 {
+		loadFactor = 1
+	}
+	cushion := s.Cushion
+	if cushion == 0 {
+		cushion = 1
+	}
+
+	fee := res.Info.ValidatedLedger.BaseFeeXRP * float32(loadFactor) * cushion
+
+	// Round fee to NUM_DECIMAL_PLACES
+	roundedFee := float32(math.Round(float64(fee)*math.Pow10(int(currency.MaxFractionLength)))) / float32(math.Pow10(int(currency.MaxFractionLength)))
+
+	drops, err := currency.XrpToDrops(fmt.Sprintf("%.*f", currency.MaxFractionLength, roundedFee))
+	if err != nil {
+		return FeeEstimate{}, err
+	}
+	n, err := strconv.ParseUint(drops, 10, 64)
+	if err != nil {
+		return FeeEstimate{}, err
+	}
+	return FeeEstimate{
+		Drops:  n,
+		Reason: fmt.Sprintf("base fee of %g XRP with load factor %d and cushion %g", res.Info.ValidatedLedger.BaseFeeXRP, loadFactor, cushion),
+	}, nil
+}
+
+// OpenLedgerFeeStrategy pays the open ledger fee, the escalated fee a transaction needs
+// to get into the current open ledger instead of waiting in the queue. Use it for time
+// sensitive transactions during fee spikes.
+type OpenLedgerFeeStrategy struct {
+	// Cushion multiplies the fee, in case the open ledger fills up further before the
+	// transaction is applied. Zero is no cushion.
+	Cushion float32
+}
+
+// EstimateFee implements FeeStrategy with the fee command.
+func (s OpenLedgerFeeStrategy) EstimateFee(ctx context.Context, r xrpl.Requester, _ transaction.FlatTransaction) (FeeEstimate, error) {
+	res, err := xrpl.Query[server.FeeResponse](ctx, r, &server.FeeRequest{})
+	if err != nil {
+		return FeeEstimate{}, err
+	}
+	cushion := s.Cushion
+	if cushion == 0 {
+		cushion = 1
+	}
+
+	fee := max(res.Drops.OpenLedgerFee.Uint64(), res.Drops.MinimumFee.Uint64(), res.Drops.BaseFee.Uint64())
+	// Rounds off the float32 error of the cushion before rounding up to the next drop.
+	cushioned := math.Round(float64(fee)*float64(cushion)*1e3) / 1e3
+	return FeeEstimate{
+		Drops: uint64(math.Ceil(cushioned)),
+		Reason: fmt.Sprintf("open ledger fee of %d drops with cushion %g (open ledger %s/%s transactions, queue %s/%s)",
+			res.Drops.OpenLedgerFee, cushion, res.CurrentLedgerSize, res.ExpectedLedgerSize, res.CurrentQueueSize, res.MaxQueueSize),
+	}, nil
+}
+
+// MedianFeeStrategy pays the median fee of the transactions in the last validated
+// ledger, at least the minimum fee to enter the queue.
+type MedianFeeStrategy struct{}
+
+// EstimateFee implements FeeStrategy with the fee command.
+func (MedianFeeStrategy) EstimateFee(ctx context.Context, r xrpl.Requester, _ transaction.FlatTransaction) (FeeEstimate, error) {
+	res, err := xrpl.Query[server.FeeResponse](ctx, r, &server.FeeRequest{})
+	if err != nil {
+		return FeeEstimate{}, err
+	}
+
+	if res.Drops.MedianFee < res.Drops.MinimumFee {
+		return FeeEstimate{
+			Drops:  res.Drops.MinimumFee.Uint64(),
+			Reason: fmt.Sprintf("minimum queue fee of %d drops, above the median fee of %d drops", res.Drops.MinimumFee, res.Drops.MedianFee),
+		}, nil
+	}
+	return FeeEstimate{
+		Drops:  res.Drops.MedianFee.Uint64(),
+		Reason: fmt.Sprintf("median fee of %d drops (queue %s/%s)", res.Drops.MedianFee, res.CurrentQueueSize, res.MaxQueueSize),
+	}, nil
+}
+
+// TransactionTypeFeeStrategy picks the strategy of a transaction by its type, e.g. the
+// OpenLedgerFeeStrategy for OfferCreate and the BaseFeeStrategy for the rest.
+type TransactionTypeFeeStrategy struct {
+	// Strategies holds the strategy of each overridden transaction type.
+	Strategies map[transaction.TxType]FeeStrategy
+	// Default is the strategy of the other transaction types.
+	// Default: BaseFeeStrategy without cushion
+	Default FeeStrategy
+}
+
+// EstimateFee implements FeeStrategy with the strategy of the type of tx.
+func (s TransactionTypeFeeStrategy) EstimateFee(ctx context.Context, r xrpl.Requester, tx transaction.FlatTransaction) (FeeEstimate, error) {
+	txType := transactionType(tx)
+	if strategy, ok := s.Strategies[txType]; ok {
+		est, err := strategy.EstimateFee(ctx, r, tx)
+		if err != nil {
+			return FeeEstimate{}, err
+		}
+		est.Reason = fmt.Sprintf("%s override: %s", txType, est.Reason)
+		return est, nil
+	}
+	if s.Default == nil {
+		return BaseFeeStrategy{}.EstimateFee(ctx, r, tx)
+	}
+	return s.Default.EstimateFee(ctx, r, tx)
+}
+
+// transactionType returns the TransactionType of tx, set as a string or a TxType.
+func transactionType(tx transaction.FlatTransaction) transaction.TxType {
+	switch t := tx["TransactionType"].(type) {
+	case string:
+		return transaction.TxType(t)
+	case transaction.TxType:
+		return t
+	}
+	return ""
+}
@@ -0,0 +1,135 @@
+package autofill
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func serverInfoMessage(baseFeeXRP float32, loadFactor float32) map[string]any {
+	return map[string]any{"result": map[string]any{"info": map[string]any{
+		"validated_ledger": map[string]any{"base_fee_xrp": baseFeeXRP},
+		"load_factor":      loadFactor,
+	}}}
+}
+
+func feeMessage(openLedgerFee, medianFee string) map[string]any {
+	return map[string]any{"result": map[string]any{
+		"current_ledger_size":  "56",
+		"current_queue_size":   "12",
+		"expected_ledger_size": "40",
+		"max_queue_size":       "2000",
+		"drops": map[string]any{
+			"base_fee":        "10",
+			"median_fee":      medianFee,
+			"minimum_fee":     "15",
+			"open_ledger_fee": openLedgerFee,
+		},
+	}}
+}
+
+func TestFeeStrategies(t *testing.T) {
+	tests := []struct {
+		name           string
+		strategy       FeeStrategy
+		tx             transaction.FlatTransaction
+		serverMessages []map[string]any
+		expected       FeeEstimate
+	}{
+		{
+			name:           "Base fee",
+			strategy:       BaseFeeStrategy{Cushion: 1.2},
+			serverMessages: []map[string]any{serverInfoMessage(0.00001, 2)},
+			expected:       FeeEstimate{Drops: 24, Reason: "base fee of 1e-05 XRP with load factor 2 and cushion 1.2"},
+		},
+		{
+			name:           "Open ledger fee",
+			strategy:       OpenLedgerFeeStrategy{Cushion: 1.1},
+			serverMessages: []map[string]any{feeMessage("2650", "5000")},
+			expected: FeeEstimate{
+				Drops:  2915,
+				Reason: "open ledger fee of 2650 drops with cushion 1.1 (open ledger 56/40 transactions, queue 12/2000)",
+			},
+		},
+		{
+			name:           "Median fee",
+			strategy:       MedianFeeStrategy{},
+			serverMessages: []map[string]any{feeMessage("2650", "5000")},
+			expected:       FeeEstimate{Drops: 5000, Reason: "median fee of 5000 drops (queue 12/2000)"},
+		},
+		{
+			name:           "Median fee below the minimum fee",
+			strategy:       MedianFeeStrategy{},
+			serverMessages: []map[string]any{feeMessage("2650", "11")},
+			expected:       FeeEstimate{Drops: 15, Reason: "minimum queue fee of 15 drops, above the median fee of 11 drops"},
+		},
+		{
+			name: "Transaction type override",
+			strategy: TransactionTypeFeeStrategy{
+				Strategies: map[transaction.TxType]FeeStrategy{transaction.OfferCreateTx: OpenLedgerFeeStrategy{}},
+			},
+			tx:             transaction.FlatTransaction{"TransactionType": "OfferCreate"},
+			serverMessages: []map[string]any{feeMessage("2650", "5000")},
+			expected: FeeEstimate{
+				Drops:  2650,
+				Reason: "OfferCreate override: open ledger fee of 2650 drops with cushion 1 (open ledger 56/40 transactions, queue 12/2000)",
+			},
+		},
+		{
+			name: "Transaction type default",
+			strategy: TransactionTypeFeeStrategy{
+				Strategies: map[transaction.TxType]FeeStrategy{transaction.OfferCreateTx: OpenLedgerFeeStrategy{}},
+				Default:    MedianFeeStrategy{},
+			},
+			tx:             transaction.FlatTransaction{"TransactionType": transaction.PaymentTx},
+			serverMessages: []map[string]any{feeMessage("2650", "5000")},
+			expected:       FeeEstimate{Drops: 5000, Reason: "median fee of 5000 drops (queue 12/2000)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			est, err := tt.strategy.EstimateFee(context.Background(), newMockRequester(tt.serverMessages), tt.tx)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, est)
+		})
+	}
+}
+
+func TestEngine_FeeStrategy(t *testing.T) {
+	r := newMockRequester([]map[string]any{
+		feeMessage("2650", "5000"),
+		feeMessage("800000", "5000"),
+	})
+	var reports []FeeReport
+	e := NewEngine(r, Config{
+		MaxFeeXRP:   1,
+		FeeStrategy: OpenLedgerFeeStrategy{},
+		FeeReporter: func(_ transaction.FlatTransaction, report FeeReport) {
+			reports = append(reports, report)
+		},
+	})
+
+	// Multi-signed by 2: 3 times the open ledger fee.
+	tx := transaction.FlatTransaction{"TransactionType": "OfferCreate"}
+	require.NoError(t, e.calculateFeePerTransactionType(context.Background(), &tx, 2))
+	require.Equal(t, "7950", tx["Fee"])
+
+	// Still capped by MaxFeeXRP.
+	require.NoError(t, e.calculateFeePerTransactionType(context.Background(), &tx, 2))
+	require.Equal(t, "1000000", tx["Fee"])
+
+	require.Equal(t, []FeeReport{
+		{
+			Estimate: FeeEstimate{Drops: 2650, Reason: "open ledger fee of 2650 drops with cushion 1 (open ledger 56/40 transactions, queue 12/2000)"},
+			Drops:    7950,
+		},
+		{
+			Estimate: FeeEstimate{Drops: 800000, Reason: "open ledger fee of 800000 drops with cushion 1 (open ledger 56/40 transactions, queue 12/2000)"},
+			Drops:    1000000,
+			Capped:   true,
+		},
+	}, reports)
+}
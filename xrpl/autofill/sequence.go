@@ -0,0 +1,82 @@
+package autofill
+
+import (
+	"context"
+	"errors"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/sequence"
+	"github.com/Peersyst/xrpl-go/xrpl/submission"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+// sequenceSubmitted reports the engine result of a submitted transaction blob to the
+// sequence allocator of the engine, if any. See sequence.Allocator.Submitted.
+func (e *Engine) sequenceSubmitted(txBlob, engineResult string) {
+	if e.cfg.Sequences == nil {
+		return
+	}
+	addr, seq, ok := blobSequence(txBlob)
+	if ok && e.cfg.Sequences.Submitted(addr, seq, engineResult) {
+		// Without a wait there is no filler: the next transaction takes the gap instead.
+		e.cfg.Sequences.Release(addr, seq)
+	}
+}
+
+// sequenceGapFiller reports the outcome of a transaction blob submitted and waited for,
+// err being the error of the wait, to the sequence allocator of the engine, if any. If the
+// transaction expired, or was rejected with tefMAX_LEDGER, and left a gap in the sequences
+// of its account, it returns the blob of a no-op AccountSet filling it, autofilled and
+// signed with signer. It returns an empty blob otherwise.
+func (e *Engine) sequenceGapFiller(ctx context.Context, txBlob string, err error, signer xrpl.Signer) (string, error) {
+	if e.cfg.Sequences == nil {
+		return "", nil
+	}
+	addr, seq, ok := blobSequence(txBlob)
+	if !ok {
+		return "", nil
+	}
+
+	var rejected *submission.RejectedError
+	gap := false
+	switch {
+	case errors.As(err, &rejected):
+		gap = e.cfg.Sequences.Submitted(addr, seq, rejected.EngineResult)
+	case errors.Is(err, submission.ErrExpired):
+		gap = e.cfg.Sequences.Expired(addr, seq)
+	}
+	if gap {
+		return e.SignedTxBlob(ctx, sequence.NoopAccountSet(addr, seq), true, signer)
+	}
+	return "", nil
+}
+
+// releaseSequence gives the sequence autofilled in tx back to the sequence allocator of
+// the engine, if any, when tx won't be submitted.
+func (e *Engine) releaseSequence(tx transaction.FlatTransaction) {
+	if e.cfg.Sequences == nil {
+		return
+	}
+	addr, addrOk := tx["Account"].(string)
+	seq, seqOk := tx["Sequence"].(uint32)
+	if addrOk && seqOk {
+		e.cfg.Sequences.Release(types.Address(addr), seq)
+	}
+}
+
+// blobSequence returns the account and sequence of a transaction blob. Transactions using
+// a ticket have no sequence.
+func blobSequence(txBlob string) (types.Address, uint32, bool) {
+	tx, err := binarycodec.Decode(txBlob)
+	if err != nil {
+		return "", 0, false
+	}
+	addr, addrOk := tx["Account"].(string)
+	seq, seqOk := tx["Sequence"].(uint32)
+	if !addrOk || !seqOk || seq == 0 {
+		return "", 0, false
+	}
+	return types.Address(addr), seq, true
+}
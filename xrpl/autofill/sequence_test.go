@@ -0,0 +1,110 @@
+package autofill
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/xrpl/sequence"
+	"github.com/Peersyst/xrpl-go/xrpl/submission"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// encodingSigner signs transactions by encoding them, or fails with err.
+type encodingSigner struct {
+	err error
+}
+
+func (s encodingSigner) Sign(tx map[string]any) (string, string, error) {
+	if s.err != nil {
+		return "", "", s.err
+	}
+	blob, err := binarycodec.Encode(tx)
+	return blob, "", err
+}
+
+func testAccountSet() transaction.FlatTransaction {
+	return transaction.FlatTransaction{
+		"TransactionType":    "AccountSet",
+		"Account":            "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf",
+		"Fee":                "12",
+		"LastLedgerSequence": uint32(100),
+		"SigningPubKey":      "",
+	}
+}
+
+func blobSequenceOf(t *testing.T, blob string) uint32 {
+	t.Helper()
+	tx, err := binarycodec.Decode(blob)
+	require.NoError(t, err)
+	return tx["Sequence"].(uint32)
+}
+
+func TestEngine_Sequences(t *testing.T) {
+	ctx := context.Background()
+	r := newMockRequester([]map[string]any{
+		{"result": map[string]any{"account_data": map[string]any{"Sequence": uint32(42)}}},
+		// Autofill of the no-op AccountSet.
+		{"result": map[string]any{"info": map[string]any{
+			"validated_ledger": map[string]any{"base_fee_xrp": float32(0.00001)},
+			"load_factor":      float32(1),
+		}}},
+		{"result": map[string]any{"ledger_index": 90}},
+	})
+	e := NewEngine(r, Config{MaxFeeXRP: 2, FeeCushion: 1, Sequences: sequence.NewAllocator()})
+
+	var blobs []string
+	for range 3 {
+		blob, err := e.SignedTxBlob(ctx, testAccountSet(), true, encodingSigner{})
+		require.NoError(t, err)
+		blobs = append(blobs, blob)
+	}
+	require.EqualValues(t, 42, blobSequenceOf(t, blobs[0]))
+	require.EqualValues(t, 44, blobSequenceOf(t, blobs[2]))
+
+	// A sequence that failed to be signed is handed out again.
+	_, err := e.SignedTxBlob(ctx, testAccountSet(), true, encodingSigner{err: errors.New("no key")})
+	require.Error(t, err)
+	// So is the one of a rejected transaction.
+	filler, err := e.sequenceGapFiller(ctx, blobs[2], &submission.RejectedError{EngineResult: "temBAD_FEE"}, encodingSigner{})
+	require.NoError(t, err)
+	require.Empty(t, filler)
+	blob, err := e.SignedTxBlob(ctx, testAccountSet(), true, encodingSigner{})
+	require.NoError(t, err)
+	require.EqualValues(t, 44, blobSequenceOf(t, blob))
+
+	// The first transaction expired: its sequence is filled with a no-op AccountSet.
+	filler, err = e.sequenceGapFiller(ctx, blobs[0], submission.ErrExpired, encodingSigner{})
+	require.NoError(t, err)
+	tx, err := binarycodec.Decode(filler)
+	require.NoError(t, err)
+	require.Equal(t, "AccountSet", tx["TransactionType"])
+	require.EqualValues(t, 42, tx["Sequence"])
+	require.Equal(t, "10", tx["Fee"])
+
+	// Other errors leave the sequences as they are.
+	filler, err = e.sequenceGapFiller(ctx, blobs[1], errors.New("connection closed"), encodingSigner{})
+	require.NoError(t, err)
+	require.Empty(t, filler)
+	require.Equal(t, 3, r.calls)
+}
+
+func TestEngine_sequenceSubmitted(t *testing.T) {
+	ctx := context.Background()
+	r := newMockRequester([]map[string]any{
+		{"result": map[string]any{"account_data": map[string]any{"Sequence": uint32(42)}}},
+		{"result": map[string]any{"account_data": map[string]any{"Sequence": uint32(50)}}},
+	})
+	e := NewEngine(r, Config{Sequences: sequence.NewAllocator()})
+
+	blob, err := e.SignedTxBlob(ctx, testAccountSet(), true, encodingSigner{})
+	require.NoError(t, err)
+	require.EqualValues(t, 42, blobSequenceOf(t, blob))
+
+	e.sequenceSubmitted(blob, "tefPAST_SEQ")
+	blob, err = e.SignedTxBlob(ctx, testAccountSet(), true, encodingSigner{})
+	require.NoError(t, err)
+	require.EqualValues(t, 50, blobSequenceOf(t, blob))
+}
@@ -0,0 +1,65 @@
+package autofill
+
+import (
+	"context"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+)
+
+// SignedTxBlob ensures the transaction is fully signed and returns the transaction blob.
+// If the transaction is already signed, it encodes and returns it. Otherwise, it autofills (if enabled)
+// and signs the transaction using the provided signer, e.g. a wallet.
+func (e *Engine) SignedTxBlob(ctx context.Context, tx transaction.FlatTransaction, autofill bool, signer xrpl.Signer) (string, error) {
+	// Check if the transaction is already signed: both fields must be non-empty.
+	sig, sigOk := tx["TxnSignature"].(string)
+	pubKey, pubKeyOk := tx["SigningPubKey"].(string)
+	if sigOk && sig != "" && pubKeyOk && pubKey != "" {
+		blob, err := binarycodec.Encode(tx)
+		if err != nil {
+			return "", err
+		}
+		return blob, nil
+	}
+
+	// If not signed, ensure a signer is provided.
+	if signer == nil {
+		return "", ErrMissingWallet
+	}
+
+	// Optionally autofill the transaction.
+	_, hasSequence := tx["Sequence"]
+	if autofill {
+		if err := e.Autofill(ctx, &tx); err != nil {
+			return "", err
+		}
+	}
+
+	// Sign the transaction.
+	txBlob, _, err := signer.Sign(tx)
+	if err != nil {
+		if !hasSequence {
+			e.releaseSequence(tx)
+		}
+		return "", err
+	}
+	return txBlob, nil
+}
+
+// ValidateTxBlob decodes a transaction blob and checks it carries either a
+// TxnSignature or a SigningPubKey, as required before submitting it.
+func ValidateTxBlob(txBlob string) error {
+	tx, err := binarycodec.Decode(txBlob)
+	if err != nil {
+		return err
+	}
+
+	_, okTxSig := tx["TxnSignature"].(string)
+	_, okPubKey := tx["SigningPubKey"].(string)
+
+	if !okTxSig && !okPubKey {
+		return ErrMissingTxSignatureOrSigningPubKey
+	}
+	return nil
+}
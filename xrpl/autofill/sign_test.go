@@ -0,0 +1,84 @@
+package autofill
+
+import (
+	"context"
+	"testing"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSigningPubKey = "03AB40A0490F9B7ED8DF29D246BF2D6269820A0EE7742ACDD457BEA7C7D0931EDB"
+	testTxnSignature  = "3045022100D184EB4AE5956FF600E7536EE459345C7BBCF097A84CC61A93B9AF7197EDB98702201CEA8009B7BEEBAA2AACC0359B41C427C1C5B550A4CA4B80CF2174AF2D6D5DCE"
+)
+
+func testPayment() transaction.FlatTransaction {
+	return transaction.FlatTransaction{
+		"Account":         "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn",
+		"Destination":     "ra5nK24KXen9AHvsdFTKHSANinZseWnPcX",
+		"Amount":          "1000000",
+		"Fee":             "10",
+		"TransactionType": "Payment",
+		"Sequence":        uint32(359),
+	}
+}
+
+func TestValidateTxBlob(t *testing.T) {
+	tests := []struct {
+		name        string
+		fields      map[string]any
+		expectedErr error
+	}{
+		{
+			name:   "pass - TxnSignature only",
+			fields: map[string]any{"TxnSignature": testTxnSignature},
+		},
+		{
+			name:   "pass - SigningPubKey only",
+			fields: map[string]any{"SigningPubKey": testSigningPubKey},
+		},
+		{
+			name:        "fail - unsigned",
+			fields:      map[string]any{},
+			expectedErr: ErrMissingTxSignatureOrSigningPubKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := testPayment()
+			for k, v := range tt.fields {
+				tx[k] = v
+			}
+			blob, err := binarycodec.Encode(tx)
+			require.NoError(t, err)
+
+			err = ValidateTxBlob(blob)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestEngine_SignedTxBlob(t *testing.T) {
+	e := NewEngine(newMockRequester(nil), Config{})
+
+	t.Run("pass - already signed", func(t *testing.T) {
+		tx := testPayment()
+		tx["SigningPubKey"] = testSigningPubKey
+		tx["TxnSignature"] = testTxnSignature
+
+		expected, err := binarycodec.Encode(tx)
+		require.NoError(t, err)
+
+		blob, err := e.SignedTxBlob(context.Background(), tx, true, nil)
+		require.NoError(t, err)
+		require.Equal(t, expected, blob)
+	})
+
+	t.Run("fail - unsigned without wallet", func(t *testing.T) {
+		_, err := e.SignedTxBlob(context.Background(), testPayment(), false, nil)
+		require.ErrorIs(t, err, ErrMissingWallet)
+	})
+}
@@ -0,0 +1,107 @@
+package autofill
+
+import (
+	"context"
+	"errors"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/xrpl"
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+)
+
+// WaitFunc submits a signed transaction blob and waits for its validation, such as the
+// SubmitTxBlobAndWaitContext method of the clients.
+type WaitFunc func(ctx context.Context, txBlob string, failHard bool) (*requests.TxResponse, error)
+
+// SubmitTxBlob checks the transaction blob is signed, and submits it.
+func (e *Engine) SubmitTxBlob(ctx context.Context, txBlob string, failHard bool) (*requests.SubmitResponse, error) {
+	if err := ValidateTxBlob(txBlob); err != nil {
+		return nil, err
+	}
+	return xrpl.Query[requests.SubmitResponse](ctx, e.r, &requests.SubmitRequest{
+		TxBlob:   txBlob,
+		FailHard: failHard,
+	})
+}
+
+// SubmitTx signs the transaction (if necessary), autofilling it if opts.Autofill, and
+// submits it. The engine result is reported to the sequence allocator of the engine, if any.
+func (e *Engine) SubmitTx(ctx context.Context, tx transaction.FlatTransaction, opts *xrpl.SubmitOptions) (*requests.SubmitResponse, error) {
+	txBlob, err := e.SignedTxBlob(ctx, tx, opts.Autofill, opts.TxSigner())
+	if err != nil {
+		return nil, err
+	}
+	return e.submit(ctx, txBlob, opts.FailHard)
+}
+
+// SubmitTxAndWait signs the transaction (if necessary), autofilling it if opts.Autofill,
+// then submits it and waits for its validation with wait. If the transaction failed and
+// left a gap in the sequences of its account, a no-op AccountSet filling it is submitted,
+// and its engine result reported to the sequence allocator of the engine.
+func (e *Engine) SubmitTxAndWait(ctx context.Context, tx transaction.FlatTransaction, opts *xrpl.SubmitOptions, wait WaitFunc) (*requests.TxResponse, error) {
+	txBlob, err := e.SignedTxBlob(ctx, tx, opts.Autofill, opts.TxSigner())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := wait(ctx, txBlob, opts.FailHard)
+	if err != nil {
+		return nil, errors.Join(err, e.fillSequenceGap(ctx, txBlob, err, opts.TxSigner()))
+	}
+	return res, nil
+}
+
+// SubmitMultisigned checks every signer of the multisigned transaction blob carries its
+// signing data, and submits it.
+func (e *Engine) SubmitMultisigned(ctx context.Context, txBlob string, failHard bool) (*requests.SubmitMultisignedResponse, error) {
+	tx, err := binarycodec.Decode(txBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	signers, _ := tx["Signers"].([]any)
+	for _, s := range signers {
+		signer, ok := s.(map[string]any)
+		if !ok {
+			return nil, ErrSignerIsNotAnObject
+		}
+		signerData, ok := signer["Signer"].(map[string]any)
+		if !ok {
+			return nil, ErrSignerIsNotAnObject
+		}
+		if signerData["SigningPubKey"] == "" && signerData["TxnSignature"] == "" {
+			return nil, ErrSignerDataIsEmpty
+		}
+	}
+
+	return xrpl.Query[requests.SubmitMultisignedResponse](ctx, e.r, &requests.SubmitMultisignedRequest{
+		Tx:       tx,
+		FailHard: failHard,
+	})
+}
+
+// submit submits a signed transaction blob and reports its engine result to the sequence
+// allocator of the engine, if any.
+func (e *Engine) submit(ctx context.Context, txBlob string, failHard bool) (*requests.SubmitResponse, error) {
+	res, err := xrpl.Query[requests.SubmitResponse](ctx, e.r, &requests.SubmitRequest{
+		TxBlob:   txBlob,
+		FailHard: failHard,
+	})
+	if err != nil {
+		return nil, err
+	}
+	e.sequenceSubmitted(txBlob, res.EngineResult)
+	return res, nil
+}
+
+// fillSequenceGap submits the no-op AccountSet filling the gap the transaction blob left
+// in the sequences of its account, if any, err being the error of its wait.
+func (e *Engine) fillSequenceGap(ctx context.Context, txBlob string, err error, signer xrpl.Signer) error {
+	filler, err := e.sequenceGapFiller(ctx, txBlob, err, signer)
+	if err != nil || filler == "" {
+		return err
+	}
+	_, err = e.submit(ctx, filler, false)
+	return err
+}
@@ -0,0 +1,84 @@
+package autofill
+
+import (
+	"context"
+	"testing"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/xrpl"
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	"github.com/Peersyst/xrpl-go/xrpl/sequence"
+	"github.com/Peersyst/xrpl-go/xrpl/submission"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_SubmitTxAndWait(t *testing.T) {
+	ctx := context.Background()
+	r := newMockRequester([]map[string]any{
+		{"result": map[string]any{"account_data": map[string]any{"Sequence": uint32(42)}}},
+		// Autofill and submission of the no-op AccountSet filling the gap.
+		{"result": map[string]any{"info": map[string]any{
+			"validated_ledger": map[string]any{"base_fee_xrp": float32(0.00001)},
+			"load_factor":      float32(1),
+		}}},
+		{"result": map[string]any{"ledger_index": 90}},
+		{"result": map[string]any{"engine_result": "tefPAST_SEQ"}},
+		// Resync of the account after the result of the filler.
+		{"result": map[string]any{"account_data": map[string]any{"Sequence": uint32(50)}}},
+	})
+	e := NewEngine(r, Config{MaxFeeXRP: 2, FeeCushion: 1, Sequences: sequence.NewAllocator()})
+	opts := &xrpl.SubmitOptions{Autofill: true, Signer: encodingSigner{}}
+
+	var waited []string
+	_, err := e.SubmitTxAndWait(ctx, testAccountSet(), opts, func(ctx context.Context, txBlob string, _ bool) (*requests.TxResponse, error) {
+		waited = append(waited, txBlob)
+		// Another transaction takes the next sequence meanwhile, so the expiry leaves a gap.
+		_, err := e.SignedTxBlob(ctx, testAccountSet(), true, encodingSigner{})
+		require.NoError(t, err)
+		return nil, submission.ErrExpired
+	})
+	require.ErrorIs(t, err, submission.ErrExpired)
+	require.Len(t, waited, 1)
+	require.EqualValues(t, 42, blobSequenceOf(t, waited[0]))
+	require.Equal(t, 4, r.calls)
+
+	// The tefPAST_SEQ result of the filler resynced the account.
+
+	blob, err := e.SignedTxBlob(ctx, testAccountSet(), true, encodingSigner{})
+	require.NoError(t, err)
+	require.EqualValues(t, 50, blobSequenceOf(t, blob))
+}
+
+func TestEngine_SubmitMultisigned(t *testing.T) {
+	multisigned := func(pubKey, sig string) string {
+		blob, err := binarycodec.Encode(map[string]any{
+			"TransactionType": "AccountSet",
+			"Account":         "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf",
+			"Fee":             "36",
+			"Sequence":        uint32(1),
+			"SigningPubKey":   "",
+			"Signers": []any{
+				map[string]any{"Signer": map[string]any{
+					"Account":       "rMBzp8CgpE441cp5PVyA9rpVV7oT8hP3ys",
+					"SigningPubKey": pubKey,
+					"TxnSignature":  sig,
+				}},
+			},
+		})
+		require.NoError(t, err)
+		return blob
+	}
+
+	r := newMockRequester([]map[string]any{
+		{"result": map[string]any{"engine_result": "tesSUCCESS"}},
+	})
+	e := NewEngine(r, Config{})
+
+	_, err := e.SubmitMultisigned(context.Background(), multisigned("", ""), false)
+	require.ErrorIs(t, err, ErrSignerDataIsEmpty)
+	require.Zero(t, r.calls)
+
+	res, err := e.SubmitMultisigned(context.Background(), multisigned("03EE83BB432547885C219634A1BC407A9DB0474145D69737D09CCDC63E1DEE7FE3", "AB"), false)
+	require.NoError(t, err)
+	require.Equal(t, "tesSUCCESS", res.EngineResult)
+}
@@ -0,0 +1,228 @@
+package xrpl
+
+import (
+	"context"
+	"iter"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/binary-codec/definitions"
+	"github.com/Peersyst/xrpl-go/xrpl/common"
+	ledgerentry "github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	account "github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	accounttypes "github.com/Peersyst/xrpl-go/xrpl/queries/account/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/admin"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/amm"
+	channel "github.com/Peersyst/xrpl-go/xrpl/queries/channel"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/clio"
+	cliotypes "github.com/Peersyst/xrpl-go/xrpl/queries/clio/types"
+	querycommon "github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	ledger "github.com/Peersyst/xrpl-go/xrpl/queries/ledger"
+	ledgertypes "github.com/Peersyst/xrpl-go/xrpl/queries/ledger/types"
+	nft "github.com/Peersyst/xrpl-go/xrpl/queries/nft"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/oracle"
+	path "github.com/Peersyst/xrpl-go/xrpl/queries/path"
+	pathtypes "github.com/Peersyst/xrpl-go/xrpl/queries/path/types"
+	server "github.com/Peersyst/xrpl-go/xrpl/queries/server"
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	utility "github.com/Peersyst/xrpl-go/xrpl/queries/utility"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+)
+
+// SubmitOptions controls how SubmitTx and SubmitTxAndWait prepare a transaction.
+type SubmitOptions struct {
+	// Autofill fills in the missing fields of an unsigned transaction before signing it.
+	Autofill bool
+	// Wallet signs the transaction if it is not signed yet.
+	Wallet *wallet.Wallet
+	// Signer signs the transaction if it is not signed yet, in place of Wallet, e.g. a
+	// RemoteSigner.
+	Signer Signer
+	// FailHard asks the server not to retry or relay the transaction if it fails locally.
+	FailHard bool
+}
+
+// TxSigner returns the signer of unsigned transactions: Signer if set, otherwise Wallet,
+// or nil if neither is set.
+func (o *SubmitOptions) TxSigner() Signer {
+	if o.Signer != nil {
+		return o.Signer
+	}
+	if o.Wallet != nil {
+		return o.Wallet
+	}
+	return nil
+}
+
+// Querier is the set of typed queries every client exposes.
+type Querier interface {
+	GetAccountInfo(req *account.InfoRequest) (*account.InfoResponse, error)
+	GetAccountInfoContext(ctx context.Context, req *account.InfoRequest) (*account.InfoResponse, error)
+	GetAccountChannels(req *account.ChannelsRequest) (*account.ChannelsResponse, error)
+	GetAccountChannelsContext(ctx context.Context, req *account.ChannelsRequest) (*account.ChannelsResponse, error)
+	GetAccountObjects(req *account.ObjectsRequest) (*account.ObjectsResponse, error)
+	GetAccountObjectsContext(ctx context.Context, req *account.ObjectsRequest) (*account.ObjectsResponse, error)
+	GetAccountLines(req *account.LinesRequest) (*account.LinesResponse, error)
+	GetAccountLinesContext(ctx context.Context, req *account.LinesRequest) (*account.LinesResponse, error)
+	GetXrpBalance(address types.Address) (string, error)
+	GetXrpBalanceContext(ctx context.Context, address types.Address) (string, error)
+	GetAccountNFTs(req *account.NFTsRequest) (*account.NFTsResponse, error)
+	GetAccountNFTsContext(ctx context.Context, req *account.NFTsRequest) (*account.NFTsResponse, error)
+	GetAccountCurrencies(req *account.CurrenciesRequest) (*account.CurrenciesResponse, error)
+	GetAccountCurrenciesContext(ctx context.Context, req *account.CurrenciesRequest) (*account.CurrenciesResponse, error)
+	GetAccountOffers(req *account.OffersRequest) (*account.OffersResponse, error)
+	GetAccountOffersContext(ctx context.Context, req *account.OffersRequest) (*account.OffersResponse, error)
+	GetAccountTransactions(req *account.TransactionsRequest) (*account.TransactionsResponse, error)
+	GetAccountTransactionsContext(ctx context.Context, req *account.TransactionsRequest) (*account.TransactionsResponse, error)
+	GetGatewayBalances(req *account.GatewayBalancesRequest) (*account.GatewayBalancesResponse, error)
+	GetGatewayBalancesContext(ctx context.Context, req *account.GatewayBalancesRequest) (*account.GatewayBalancesResponse, error)
+	GetChannelVerify(req *channel.VerifyRequest) (*channel.VerifyResponse, error)
+	GetChannelVerifyContext(ctx context.Context, req *channel.VerifyRequest) (*channel.VerifyResponse, error)
+	GetLedgerIndex() (querycommon.LedgerIndex, error)
+	GetLedgerIndexContext(ctx context.Context) (querycommon.LedgerIndex, error)
+	GetClosedLedger() (*ledger.ClosedResponse, error)
+	GetClosedLedgerContext(ctx context.Context) (*ledger.ClosedResponse, error)
+	GetCurrentLedger() (*ledger.CurrentResponse, error)
+	GetCurrentLedgerContext(ctx context.Context) (*ledger.CurrentResponse, error)
+	GetLedgerData(req *ledger.DataRequest) (*ledger.DataResponse, error)
+	GetLedgerDataContext(ctx context.Context, req *ledger.DataRequest) (*ledger.DataResponse, error)
+	GetLedgerEntry(req *ledger.EntryRequest) (*ledger.EntryResponse, error)
+	GetLedgerEntryContext(ctx context.Context, req *ledger.EntryRequest) (*ledger.EntryResponse, error)
+	GetAMMInfo(req *amm.InfoRequest) (*amm.InfoResponse, error)
+	GetAMMInfoContext(ctx context.Context, req *amm.InfoRequest) (*amm.InfoResponse, error)
+	GetLedger(req *ledger.Request) (*ledger.Response, error)
+	GetLedgerContext(ctx context.Context, req *ledger.Request) (*ledger.Response, error)
+	GetNFTBuyOffers(req *nft.NFTokenBuyOffersRequest) (*nft.NFTokenBuyOffersResponse, error)
+	GetNFTBuyOffersContext(ctx context.Context, req *nft.NFTokenBuyOffersRequest) (*nft.NFTokenBuyOffersResponse, error)
+	GetNFTSellOffers(req *nft.NFTokenSellOffersRequest) (*nft.NFTokenSellOffersResponse, error)
+	GetNFTSellOffersContext(ctx context.Context, req *nft.NFTokenSellOffersRequest) (*nft.NFTokenSellOffersResponse, error)
+	GetNFTInfo(req *clio.NFTInfoRequest) (*clio.NFTInfoResponse, error)
+	GetNFTInfoContext(ctx context.Context, req *clio.NFTInfoRequest) (*clio.NFTInfoResponse, error)
+	GetNFTHistory(req *clio.NFTHistoryRequest) (*clio.NFTHistoryResponse, error)
+	GetNFTHistoryContext(ctx context.Context, req *clio.NFTHistoryRequest) (*clio.NFTHistoryResponse, error)
+	GetNFTsByIssuer(req *clio.NFTsByIssuerRequest) (*clio.NFTsByIssuerResponse, error)
+	GetNFTsByIssuerContext(ctx context.Context, req *clio.NFTsByIssuerRequest) (*clio.NFTsByIssuerResponse, error)
+	GetMPTHolders(req *clio.MPTHoldersRequest) (*clio.MPTHoldersResponse, error)
+	GetMPTHoldersContext(ctx context.Context, req *clio.MPTHoldersRequest) (*clio.MPTHoldersResponse, error)
+	GetClioLedger(req *clio.LedgerRequest) (*clio.LedgerResponse, error)
+	GetClioLedgerContext(ctx context.Context, req *clio.LedgerRequest) (*clio.LedgerResponse, error)
+	GetBookOffers(req *path.BookOffersRequest) (*path.BookOffersResponse, error)
+	GetBookOffersContext(ctx context.Context, req *path.BookOffersRequest) (*path.BookOffersResponse, error)
+	GetDepositAuthorized(req *path.DepositAuthorizedRequest) (*path.DepositAuthorizedResponse, error)
+	GetDepositAuthorizedContext(ctx context.Context, req *path.DepositAuthorizedRequest) (*path.DepositAuthorizedResponse, error)
+	FindPathCreate(req *path.FindCreateRequest) (*path.FindResponse, error)
+	FindPathCreateContext(ctx context.Context, req *path.FindCreateRequest) (*path.FindResponse, error)
+	FindPathClose(req *path.FindCloseRequest) (*path.FindResponse, error)
+	FindPathCloseContext(ctx context.Context, req *path.FindCloseRequest) (*path.FindResponse, error)
+	FindPathStatus(req *path.FindStatusRequest) (*path.FindResponse, error)
+	FindPathStatusContext(ctx context.Context, req *path.FindStatusRequest) (*path.FindResponse, error)
+	GetRipplePathFind(req *path.RipplePathFindRequest) (*path.RipplePathFindResponse, error)
+	GetRipplePathFindContext(ctx context.Context, req *path.RipplePathFindRequest) (*path.RipplePathFindResponse, error)
+	GetServerInfo(req *server.InfoRequest) (*server.InfoResponse, error)
+	GetServerInfoContext(ctx context.Context, req *server.InfoRequest) (*server.InfoResponse, error)
+	GetAllFeatures(req *server.FeatureAllRequest) (*server.FeatureAllResponse, error)
+	GetAllFeaturesContext(ctx context.Context, req *server.FeatureAllRequest) (*server.FeatureAllResponse, error)
+	GetFeature(req *server.FeatureOneRequest) (*server.FeatureResponse, error)
+	GetFeatureContext(ctx context.Context, req *server.FeatureOneRequest) (*server.FeatureResponse, error)
+	GetFee(req *server.FeeRequest) (*server.FeeResponse, error)
+	GetFeeContext(ctx context.Context, req *server.FeeRequest) (*server.FeeResponse, error)
+	GetManifest(req *server.ManifestRequest) (*server.ManifestResponse, error)
+	GetManifestContext(ctx context.Context, req *server.ManifestRequest) (*server.ManifestResponse, error)
+	GetServerState(req *server.StateRequest) (*server.StateResponse, error)
+	GetServerStateContext(ctx context.Context, req *server.StateRequest) (*server.StateResponse, error)
+	GetServerDefinitions(req *server.DefinitionsRequest) (*server.DefinitionsResponse, error)
+	GetServerDefinitionsContext(ctx context.Context, req *server.DefinitionsRequest) (*server.DefinitionsResponse, error)
+	GetAggregatePrice(req *oracle.GetAggregatePriceRequest) (*oracle.GetAggregatePriceResponse, error)
+	GetAggregatePriceContext(ctx context.Context, req *oracle.GetAggregatePriceRequest) (*oracle.GetAggregatePriceResponse, error)
+	Ping(req *utility.PingRequest) (*utility.PingResponse, error)
+	PingContext(ctx context.Context, req *utility.PingRequest) (*utility.PingResponse, error)
+	GetRandom(req *utility.RandomRequest) (*utility.RandomResponse, error)
+	GetRandomContext(ctx context.Context, req *utility.RandomRequest) (*utility.RandomResponse, error)
+}
+
+// Paginator is the set of iterators every client exposes over paginated queries.
+// They follow the markers of the responses until the last page, see the paginate package.
+type Paginator interface {
+	AccountTransactionsAll(ctx context.Context, req *account.TransactionsRequest) iter.Seq2[account.Transaction, error]
+	AccountLinesAll(ctx context.Context, req *account.LinesRequest) iter.Seq2[accounttypes.TrustLine, error]
+	AccountObjectsAll(ctx context.Context, req *account.ObjectsRequest) iter.Seq2[ledgerentry.FlatLedgerObject, error]
+	AccountOffersAll(ctx context.Context, req *account.OffersRequest) iter.Seq2[accounttypes.OfferResult, error]
+	AccountNFTsAll(ctx context.Context, req *account.NFTsRequest) iter.Seq2[accounttypes.NFT, error]
+	AccountChannelsAll(ctx context.Context, req *account.ChannelsRequest) iter.Seq2[accounttypes.ChannelResult, error]
+	LedgerDataAll(ctx context.Context, req *ledger.DataRequest) iter.Seq2[ledgertypes.State, error]
+	BookOffersAll(ctx context.Context, req *path.BookOffersRequest) iter.Seq2[pathtypes.BookOffer, error]
+	NFTsByIssuerAll(ctx context.Context, req *clio.NFTsByIssuerRequest) iter.Seq2[cliotypes.NFToken, error]
+	NFTHistoryAll(ctx context.Context, req *clio.NFTHistoryRequest) iter.Seq2[clio.NFTHistoryTransactions, error]
+	MPTHoldersAll(ctx context.Context, req *clio.MPTHoldersRequest) iter.Seq2[cliotypes.MPTHolder, error]
+}
+
+// Submitter is the set of methods every client exposes to prepare and submit transactions.
+type Submitter interface {
+	Autofill(tx *transaction.FlatTransaction) error
+	AutofillContext(ctx context.Context, tx *transaction.FlatTransaction) error
+	AutofillMultisigned(tx *transaction.FlatTransaction, nSigners uint64) error
+	AutofillMultisignedContext(ctx context.Context, tx *transaction.FlatTransaction, nSigners uint64) error
+
+	SubmitTx(tx transaction.FlatTransaction, opts *SubmitOptions) (*requests.SubmitResponse, error)
+	SubmitTxContext(ctx context.Context, tx transaction.FlatTransaction, opts *SubmitOptions) (*requests.SubmitResponse, error)
+	SubmitTxAndWait(tx transaction.FlatTransaction, opts *SubmitOptions) (*requests.TxResponse, error)
+	SubmitTxAndWaitContext(ctx context.Context, tx transaction.FlatTransaction, opts *SubmitOptions) (*requests.TxResponse, error)
+	SubmitTxBlob(txBlob string, failHard bool) (*requests.SubmitResponse, error)
+	SubmitTxBlobContext(ctx context.Context, txBlob string, failHard bool) (*requests.SubmitResponse, error)
+	SubmitTxBlobAndWait(txBlob string, failHard bool) (*requests.TxResponse, error)
+	SubmitTxBlobAndWaitContext(ctx context.Context, txBlob string, failHard bool) (*requests.TxResponse, error)
+	SubmitMultisigned(txBlob string, failHard bool) (*requests.SubmitMultisignedResponse, error)
+	SubmitMultisignedContext(ctx context.Context, txBlob string, failHard bool) (*requests.SubmitMultisignedResponse, error)
+}
+
+// Admin is the set of admin methods of the JSON-RPC and websocket clients, such as
+// ledger_accept to close ledgers in stand-alone mode. Servers only accept them on their
+// admin ports, and clients refuse them unless their endpoint is an admin one.
+type Admin interface {
+	LedgerAccept() (*admin.LedgerAcceptResponse, error)
+	LedgerAcceptContext(ctx context.Context) (*admin.LedgerAcceptResponse, error)
+	WalletPropose(req *admin.WalletProposeRequest) (*admin.WalletProposeResponse, error)
+	WalletProposeContext(ctx context.Context, req *admin.WalletProposeRequest) (*admin.WalletProposeResponse, error)
+	ValidationCreate(req *admin.ValidationCreateRequest) (*admin.ValidationCreateResponse, error)
+	ValidationCreateContext(ctx context.Context, req *admin.ValidationCreateRequest) (*admin.ValidationCreateResponse, error)
+	GetPeers() (*admin.PeersResponse, error)
+	GetPeersContext(ctx context.Context) (*admin.PeersResponse, error)
+	ConnectPeer(req *admin.ConnectRequest) (*admin.ConnectResponse, error)
+	ConnectPeerContext(ctx context.Context, req *admin.ConnectRequest) (*admin.ConnectResponse, error)
+	LogLevel(req *admin.LogLevelRequest) (*admin.LogLevelResponse, error)
+	LogLevelContext(ctx context.Context, req *admin.LogLevelRequest) (*admin.LogLevelResponse, error)
+	CanDelete(req *admin.CanDeleteRequest) (*admin.CanDeleteResponse, error)
+	CanDeleteContext(ctx context.Context, req *admin.CanDeleteRequest) (*admin.CanDeleteResponse, error)
+	LedgerCleaner(req *admin.LedgerCleanerRequest) (*admin.LedgerCleanerResponse, error)
+	LedgerCleanerContext(ctx context.Context, req *admin.LedgerCleanerRequest) (*admin.LedgerCleanerResponse, error)
+	LedgerRequest(req *admin.LedgerRequestRequest) (*admin.LedgerRequestResponse, error)
+	LedgerRequestContext(ctx context.Context, req *admin.LedgerRequestRequest) (*admin.LedgerRequestResponse, error)
+	GetCounts(req *admin.GetCountsRequest) (*admin.GetCountsResponse, error)
+	GetCountsContext(ctx context.Context, req *admin.GetCountsRequest) (*admin.GetCountsResponse, error)
+	GetConsensusInfo() (*admin.ConsensusInfoResponse, error)
+	GetConsensusInfoContext(ctx context.Context) (*admin.ConsensusInfoResponse, error)
+	FeatureVote(req *admin.FeatureVoteRequest) (*admin.FeatureVoteResponse, error)
+	FeatureVoteContext(ctx context.Context, req *admin.FeatureVoteRequest) (*admin.FeatureVoteResponse, error)
+	Stop() (*admin.StopResponse, error)
+	StopContext(ctx context.Context) (*admin.StopResponse, error)
+}
+
+// Client is the transport-agnostic surface shared by the JSON-RPC and websocket clients,
+// so application code can be written once and run over either transport.
+type Client interface {
+	Querier
+	Paginator
+	Submitter
+
+	// Requester returns the client as a Requester, for helpers that only need to send raw requests.
+	Requester() Requester
+
+	FaucetProvider() common.FaucetProvider
+	FundWallet(wallet *wallet.Wallet) error
+
+	// Definitions returns the binary codec definitions of the server, cached by hash.
+	Definitions(ctx context.Context) (*definitions.Definitions, error)
+	// Codec returns a codec that encodes and decodes with the definitions of the server.
+	Codec(ctx context.Context) (*binarycodec.Codec, error)
+}
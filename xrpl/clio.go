@@ -0,0 +1,142 @@
+package xrpl
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/clio"
+	cliotypes "github.com/Peersyst/xrpl-go/xrpl/queries/clio/types"
+)
+
+var (
+	// ErrClioOnly matches the ClioOnlyError of any method, with errors.Is.
+	ErrClioOnly = errors.New("method only served by Clio servers")
+)
+
+// ClioOnlyError is returned when a Clio-only method, such as nft_info, is sent to a
+// rippled server.
+type ClioOnlyError struct {
+	Method string
+}
+
+func (e *ClioOnlyError) Error() string {
+	return e.Method + " is only served by Clio servers"
+}
+
+// Is reports whether target is ErrClioOnly.
+func (e *ClioOnlyError) Is(target error) bool {
+	return target == ErrClioOnly
+}
+
+// ClioRequest is implemented by the requests of methods only Clio servers serve, such
+// as nft_info or mpt_holders.
+type ClioRequest interface {
+	Request
+	ClioOnly() bool
+}
+
+// IsClioRequest reports whether req is the request of a Clio-only method.
+func IsClioRequest(req Request) bool {
+	r, ok := req.(ClioRequest)
+	return ok && r.ClioOnly()
+}
+
+// WrapClioError returns a ClioOnlyError instead of err if req is a Clio-only request
+// the server answered with unknownCmd, as rippled does. It returns err otherwise.
+func WrapClioError(req Request, err error) error {
+	if err != nil && IsClioRequest(req) && ErrorCode(err) == "unknownCmd" {
+		return &ClioOnlyError{Method: req.Method()}
+	}
+	return err
+}
+
+// Capabilities describe the server behind an endpoint, as probed with server_info.
+type Capabilities struct {
+	// Clio is set for Clio servers, and unset for rippled.
+	Clio bool
+	// Version is the version of the server software.
+	Version string
+	// Forwarding is set for Clio servers connected to a rippled ETL source, to which they
+	// forward the requests they cannot answer themselves.
+	Forwarding bool
+	// Cache is the state of the ledger cache of Clio servers.
+	Cache *cliotypes.Cache
+}
+
+// ProbeCapabilities asks the server behind r, with server_info, whether it is a Clio or
+// a rippled server.
+func ProbeCapabilities(ctx context.Context, r Requester) (*Capabilities, error) {
+	var res struct {
+		Info struct {
+			cliotypes.ServerInfo `json:",squash"`
+			BuildVersion         string `json:"build_version"`
+		} `json:"info"`
+	}
+	resp, err := r.RequestContext(ctx, &clio.ServerInfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.GetResult(&res); err != nil {
+		return nil, err
+	}
+
+	info := &res.Info
+	if info.ClioVersion == "" {
+		return &Capabilities{Version: info.BuildVersion}, nil
+	}
+	return &Capabilities{
+		Clio:       true,
+		Version:    info.ClioVersion,
+		Forwarding: info.Forwarding(),
+		Cache:      info.Cache,
+	}, nil
+}
+
+// CapabilitiesCache holds the capabilities of a server once probed, so that clients
+// can refuse Clio-only requests to rippled servers without sending them.
+// The zero value is ready to use and safe for concurrent use.
+type CapabilitiesCache struct {
+	mu   sync.Mutex
+	caps *Capabilities
+}
+
+// Get returns the capabilities of the server behind r, probing it the first time, see
+// ProbeCapabilities.
+func (c *CapabilitiesCache) Get(ctx context.Context, r Requester) (*Capabilities, error) {
+	c.mu.Lock()
+	caps := c.caps
+	c.mu.Unlock()
+	if caps != nil {
+		return caps, nil
+	}
+	return c.Probe(ctx, r)
+}
+
+// Probe probes the server behind r again and caches its capabilities, e.g. to refresh
+// the forwarding and cache state of a Clio server.
+func (c *CapabilitiesCache) Probe(ctx context.Context, r Requester) (*Capabilities, error) {
+	caps, err := ProbeCapabilities(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.caps = caps
+	c.mu.Unlock()
+	return caps, nil
+}
+
+// Check returns a ClioOnlyError if req is a Clio-only request and the server is known
+// to be a rippled one. It returns nil until the server is probed.
+func (c *CapabilitiesCache) Check(req Request) error {
+	if !IsClioRequest(req) {
+		return nil
+	}
+	c.mu.Lock()
+	caps := c.caps
+	c.mu.Unlock()
+	if caps != nil && !caps.Clio {
+		return &ClioOnlyError{Method: req.Method()}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package xrpl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/clio"
+	cliotypes "github.com/Peersyst/xrpl-go/xrpl/queries/clio/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeCapabilities(t *testing.T) {
+	tests := []struct {
+		name string
+		info string
+		want *Capabilities
+	}{
+		{
+			name: "rippled",
+			info: `{"info": {"build_version": "2.3.0", "complete_ledgers": "1-100"}}`,
+			want: &Capabilities{Version: "2.3.0"},
+		},
+		{
+			name: "clio",
+			info: `{"info": {
+				"clio_version": "2.3.1",
+				"cache": {"size": 8160, "is_full": true, "latest_ledger_seq": 100},
+				"etl": {"etl_sources": [{"ip": "127.0.0.1", "is_connected": "true"}], "is_writer": false}
+			}}`,
+			want: &Capabilities{
+				Clio:       true,
+				Version:    "2.3.1",
+				Forwarding: true,
+				Cache:      &cliotypes.Cache{Size: 8160, IsFull: true, LatestLedgerSeq: 100},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := RequesterFunc(func(_ context.Context, req Request) (Response, error) {
+				require.Equal(t, "server_info", req.Method())
+				return testJSONResponse(tt.info), nil
+			})
+			caps, err := ProbeCapabilities(context.Background(), r)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, caps)
+		})
+	}
+}
+
+func TestCapabilitiesCache(t *testing.T) {
+	probes := 0
+	r := RequesterFunc(func(context.Context, Request) (Response, error) {
+		probes++
+		return testJSONResponse(`{"info": {"build_version": "2.3.0"}}`), nil
+	})
+
+	var cache CapabilitiesCache
+	nftInfo := &clio.NFTInfoRequest{NFTokenID: "00080000B4F4AFC5FBCBD76873F18006173D2193467D3EE70000099B00000000"}
+
+	// Until the server is probed, Clio-only requests are sent.
+	require.NoError(t, cache.Check(nftInfo))
+
+	_, err := cache.Get(context.Background(), r)
+	require.NoError(t, err)
+	_, err = cache.Get(context.Background(), r)
+	require.NoError(t, err)
+	require.Equal(t, 1, probes)
+
+	err = cache.Check(nftInfo)
+	require.Equal(t, &ClioOnlyError{Method: "nft_info"}, err)
+	require.ErrorIs(t, err, ErrClioOnly)
+	require.NoError(t, cache.Check(&clio.LedgerRequest{}))
+	require.Error(t, cache.Check(&clio.LedgerRequest{Diff: true}))
+}
+
+func TestWrapClioError(t *testing.T) {
+	unknown := &testCodedError{code: "unknownCmd"}
+	other := errors.New("actNotFound")
+
+	require.ErrorIs(t, WrapClioError(&clio.MPTHoldersRequest{}, unknown), ErrClioOnly)
+	require.Equal(t, other, WrapClioError(&clio.MPTHoldersRequest{}, other))
+	require.Equal(t, unknown, WrapClioError(testRequest{}, unknown))
+	require.NoError(t, WrapClioError(&clio.MPTHoldersRequest{}, nil))
+}
@@ -0,0 +1,55 @@
+package xrpl
+
+import (
+	"context"
+	"sync"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/binary-codec/definitions"
+	server "github.com/Peersyst/xrpl-go/xrpl/queries/server"
+)
+
+// DefinitionsCache holds the binary codec definitions of a server, fetched with the
+// server_definitions method. Once fetched, they are only sent again by the server when
+// their hash changes, e.g. after an amendment adds fields or transaction types.
+// The zero value is ready to use and safe for concurrent use.
+type DefinitionsCache struct {
+	mu   sync.Mutex
+	defs *definitions.Definitions
+}
+
+// Get returns the current definitions of the server behind r, asking it whether the
+// cached ones are still current.
+func (c *DefinitionsCache) Get(ctx context.Context, r Requester) (*definitions.Definitions, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	req := &server.DefinitionsRequest{}
+	if c.defs != nil {
+		req.Hash = c.defs.Hash
+	}
+	res, err := Query[server.DefinitionsResponse](ctx, r, req)
+	if err != nil {
+		return nil, err
+	}
+	if c.defs != nil && !res.HasDefinitions() && res.Hash == c.defs.Hash {
+		return c.defs, nil
+	}
+
+	defs, err := res.Definitions()
+	if err != nil {
+		return nil, err
+	}
+	c.defs = defs
+	return defs, nil
+}
+
+// Codec returns a codec that encodes and decodes with the current definitions of the
+// server behind r, see Get.
+func (c *DefinitionsCache) Codec(ctx context.Context, r Requester) (*binarycodec.Codec, error) {
+	defs, err := c.Get(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return binarycodec.NewCodec(defs), nil
+}
@@ -0,0 +1,74 @@
+package xrpl
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	server "github.com/Peersyst/xrpl-go/xrpl/queries/server"
+	"github.com/stretchr/testify/require"
+)
+
+// testJSONResponse is a Response whose result is decoded from JSON.
+type testJSONResponse []byte
+
+func (r testJSONResponse) GetResult(v any) error {
+	return json.Unmarshal(r, v)
+}
+
+func TestDefinitionsCache(t *testing.T) {
+	doc, err := os.ReadFile("../binary-codec/definitions/definitions.json")
+	require.NoError(t, err)
+
+	withHash := func(hash string) testJSONResponse {
+		var m map[string]any
+		require.NoError(t, json.Unmarshal(doc, &m))
+		m["hash"] = hash
+		b, err := json.Marshal(m)
+		require.NoError(t, err)
+		return b
+	}
+
+	var sent []string
+	responses := []testJSONResponse{
+		withHash("AAAA"),
+		testJSONResponse(`{"hash": "AAAA"}`),
+		withHash("BBBB"),
+	}
+	r := RequesterFunc(func(_ context.Context, req Request) (Response, error) {
+		sent = append(sent, req.(*server.DefinitionsRequest).Hash)
+		res := responses[0]
+		responses = responses[1:]
+		return res, nil
+	})
+
+	var cache DefinitionsCache
+
+	first, err := cache.Get(context.Background(), r)
+	require.NoError(t, err)
+	require.Equal(t, "AAAA", first.Hash)
+	require.Equal(t, "Sequence", first.FieldIDNameMap[*first.Fields["Sequence"].FieldHeader])
+
+	// The server confirms the cached hash: the cached definitions are reused.
+	second, err := cache.Get(context.Background(), r)
+	require.NoError(t, err)
+	require.Same(t, first, second)
+
+	// The server has new definitions.
+	c, err := cache.Codec(context.Background(), r)
+	require.NoError(t, err)
+	require.Equal(t, "BBBB", c.Definitions().Hash)
+
+	require.Equal(t, []string{"", "AAAA", "AAAA"}, sent)
+}
+
+func TestDefinitionsCache_NoDefinitions(t *testing.T) {
+	r := RequesterFunc(func(context.Context, Request) (Response, error) {
+		return testJSONResponse(`{"hash": "AAAA"}`), nil
+	})
+
+	var cache DefinitionsCache
+	_, err := cache.Get(context.Background(), r)
+	require.ErrorIs(t, err, server.ErrDefinitionsNotReturned)
+}
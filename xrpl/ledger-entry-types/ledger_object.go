@@ -21,6 +21,8 @@ const (
 	EscrowEntry                          EntryType = "Escrow"
 	FeeSettingsEntry                     EntryType = "FeeSettings"
 	LedgerHashesEntry                    EntryType = "LedgerHashes"
+	MPTokenEntry                         EntryType = "MPToken"
+	MPTokenIssuanceEntry                 EntryType = "MPTokenIssuance"
 	NegativeUNLEntry                     EntryType = "NegativeUNL"
 	NFTokenOfferEntry                    EntryType = "NFTokenOffer"
 	NFTokenPageEntry                     EntryType = "NFTokenPage"
@@ -73,6 +75,10 @@ func EmptyLedgerObject(t string) (Object, error) {
 		return &FeeSettings{}, nil
 	case LedgerHashesEntry:
 		return &Hashes{}, nil
+	case MPTokenEntry:
+		return &MPToken{}, nil
+	case MPTokenIssuanceEntry:
+		return &MPTokenIssuance{}, nil
 	case NegativeUNLEntry:
 		return &NegativeUNL{}, nil
 	case NFTokenOfferEntry:
@@ -118,6 +124,8 @@ func UnmarshalLedgerObject(data []byte) (Object, error) {
 		o = &AccountRoot{}
 	case AmendmentsEntry:
 		o = &Amendments{}
+	case AMMEntry:
+		o = &AMM{}
 	case BridgeEntry:
 		o = &Bridge{}
 	case CheckEntry:
@@ -138,6 +146,10 @@ func UnmarshalLedgerObject(data []byte) (Object, error) {
 		o = &FeeSettings{}
 	case LedgerHashesEntry:
 		o = &Hashes{}
+	case MPTokenEntry:
+		o = &MPToken{}
+	case MPTokenIssuanceEntry:
+		o = &MPTokenIssuance{}
 	case NegativeUNLEntry:
 		o = &NegativeUNL{}
 	case NFTokenOfferEntry:
@@ -0,0 +1,67 @@
+package ledger
+
+import "github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+
+const (
+	// If set, indicates that the issuer has authorized the holder to hold this MPT.
+	lsfMPTAuthorized uint32 = 0x00000002
+)
+
+// (Added by the MPTokensV1 amendment.)
+// An MPToken entry tracks the balance of a single MPT held by an account other than its
+// issuer. You create it with an MPTokenAuthorize transaction.
+//
+// ```json
+//
+//	{
+//	  "LedgerEntryType": "MPToken",
+//	  "Account": "rajgkBmMxmz161r8bWYH7CQAFZP5bA9oSG",
+//	  "Flags": 0,
+//	  "MPTokenIssuanceID": "000004C463C52827307480341125DA0577DEFC38405B0E3E",
+//	  "MPTAmount": "100000000",
+//	  "OwnerNode": "0000000000000000",
+//	  "PreviousTxnID": "E3FE6EA3D48F0C2B639448020EA4F03D4F4F8FFDB243A852A0F59177921B4879",
+//	  "PreviousTxnLgrSeq": 16
+//	}
+//
+// ```
+type MPToken struct {
+	// The unique ID for this ledger entry. In JSON, this field is represented with different names depending on the
+	// context and API method. (Note, even though this is specified as "optional" in the code, every ledger entry
+	// should have one unless it's legacy data from very early in the XRP Ledger's history.)
+	Index types.Hash256 `json:"index,omitempty"`
+	// The value 0x007F, mapped to the string MPToken, indicates that this is an MPToken entry.
+	LedgerEntryType EntryType
+	// Set of bit-flags for this ledger entry.
+	Flags uint32
+	// The owner (holder) of these MPTs.
+	Account types.Address
+	// The identifier of the MPTokenIssuance this entry holds a balance of.
+	MPTokenIssuanceID string
+	// The amount of tokens currently held by the owner, as a base-10 integer string.
+	MPTAmount string `json:",omitempty"`
+	// The amount of tokens currently locked up, for example in escrow, as a base-10 integer string.
+	LockedAmount string `json:",omitempty"`
+	// A hint indicating which page of the owner directory links to this entry, in case the
+	// directory consists of multiple pages.
+	OwnerNode string
+	// The identifying hash of the transaction that most recently modified this entry.
+	PreviousTxnID types.Hash256
+	// The index of the ledger that contains the transaction that most recently modified this entry.
+	PreviousTxnLgrSeq uint32
+}
+
+// EntryType returns the type of the ledger entry.
+func (*MPToken) EntryType() EntryType {
+	return MPTokenEntry
+}
+
+// SetLsfMPTLocked sets the MPT locked flag.
+func (m *MPToken) SetLsfMPTLocked() {
+	m.Flags |= lsfMPTLocked
+}
+
+// SetLsfMPTAuthorized sets the MPT authorized flag.
+func (m *MPToken) SetLsfMPTAuthorized() {
+	m.Flags |= lsfMPTAuthorized
+}
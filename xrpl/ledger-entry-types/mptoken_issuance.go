@@ -0,0 +1,119 @@
+package ledger
+
+import "github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+
+const (
+	// If set, indicates that all balances are locked.
+	lsfMPTLocked uint32 = 0x00000001
+	// If set, indicates that the issuer can lock an individual balance or all balances of this MPT.
+	lsfMPTCanLock uint32 = 0x00000002
+	// If set, indicates that individual holders must be authorized.
+	lsfMPTRequireAuth uint32 = 0x00000004
+	// If set, indicates that individual holders can place their balances into an escrow.
+	lsfMPTCanEscrow uint32 = 0x00000008
+	// If set, indicates that individual holders can trade their balances using the XRP Ledger DEX or AMM.
+	lsfMPTCanTrade uint32 = 0x00000010
+	// If set, indicates that tokens held by non-issuers can be transferred to other accounts.
+	lsfMPTCanTransfer uint32 = 0x00000020
+	// If set, indicates that the issuer may use the Clawback transaction to claw back value from individual holders.
+	lsfMPTCanClawback uint32 = 0x00000040
+)
+
+// (Added by the MPTokensV1 amendment.)
+// An MPTokenIssuance entry represents a single MPT issuance and holds data associated with the
+// issuance, such as the asset scale and the maximum amount. You create it with an
+// MPTokenIssuanceCreate transaction.
+//
+// ```json
+//
+//	{
+//	  "LedgerEntryType": "MPTokenIssuance",
+//	  "Flags": 131072,
+//	  "Issuer": "rJXyCjiLSW6FMJVfjmdGZW8avhHgwzHSKq",
+//	  "AssetScale": 2,
+//	  "MaximumAmount": "100000000",
+//	  "OutstandingAmount": "5000",
+//	  "TransferFee": 314,
+//	  "MPTokenMetadata": "CAFEBABE",
+//	  "OwnerNode": "0000000000000000",
+//	  "PreviousTxnID": "E3FE6EA3D48F0C2B639448020EA4F03D4F4F8FFDB243A852A0F59177921B4879",
+//	  "PreviousTxnLgrSeq": 16,
+//	  "Sequence": 1
+//	}
+//
+// ```
+type MPTokenIssuance struct {
+	// The unique ID for this ledger entry. In JSON, this field is represented with different names depending on the
+	// context and API method. (Note, even though this is specified as "optional" in the code, every ledger entry
+	// should have one unless it's legacy data from very early in the XRP Ledger's history.)
+	Index types.Hash256 `json:"index,omitempty"`
+	// The value 0x007E, mapped to the string MPTokenIssuance, indicates that this is an MPTokenIssuance entry.
+	LedgerEntryType EntryType
+	// Set of bit-flags for this ledger entry.
+	Flags uint32
+	// The address of the account that controls both the issuance amounts and characteristics of a particular fungible token.
+	Issuer types.Address
+	// The number of decimal places the token amounts are expressed in.
+	AssetScale uint8 `json:",omitempty"`
+	// The maximum number of tokens that can ever be issued, as a base-10 integer string.
+	MaximumAmount string `json:",omitempty"`
+	// The amount of tokens currently issued to non-issuer accounts, as a base-10 integer string.
+	OutstandingAmount string
+	// The amount of tokens currently locked up, for example in escrow, as a base-10 integer string.
+	LockedAmount string `json:",omitempty"`
+	// The fee charged by the issuer for secondary sales of the token, between 0 and 50,000.
+	TransferFee uint16 `json:",omitempty"`
+	// Arbitrary metadata about the token, as a hex string.
+	MPTokenMetadata string `json:",omitempty"`
+	// The ID of the permissioned domain that controls who can hold the token, if any.
+	DomainID types.Hash256 `json:",omitempty"`
+	// A hint indicating which page of the owner directory links to this entry, in case the
+	// directory consists of multiple pages.
+	OwnerNode string
+	// The identifying hash of the transaction that most recently modified this entry.
+	PreviousTxnID types.Hash256
+	// The index of the ledger that contains the transaction that most recently modified this entry.
+	PreviousTxnLgrSeq uint32
+	// The Sequence of the MPTokenIssuanceCreate transaction that created this issuance.
+	Sequence uint32
+}
+
+// EntryType returns the type of the ledger entry.
+func (*MPTokenIssuance) EntryType() EntryType {
+	return MPTokenIssuanceEntry
+}
+
+// SetLsfMPTLocked sets the MPT locked flag.
+func (m *MPTokenIssuance) SetLsfMPTLocked() {
+	m.Flags |= lsfMPTLocked
+}
+
+// SetLsfMPTCanLock sets the MPT can lock flag.
+func (m *MPTokenIssuance) SetLsfMPTCanLock() {
+	m.Flags |= lsfMPTCanLock
+}
+
+// SetLsfMPTRequireAuth sets the MPT require auth flag.
+func (m *MPTokenIssuance) SetLsfMPTRequireAuth() {
+	m.Flags |= lsfMPTRequireAuth
+}
+
+// SetLsfMPTCanEscrow sets the MPT can escrow flag.
+func (m *MPTokenIssuance) SetLsfMPTCanEscrow() {
+	m.Flags |= lsfMPTCanEscrow
+}
+
+// SetLsfMPTCanTrade sets the MPT can trade flag.
+func (m *MPTokenIssuance) SetLsfMPTCanTrade() {
+	m.Flags |= lsfMPTCanTrade
+}
+
+// SetLsfMPTCanTransfer sets the MPT can transfer flag.
+func (m *MPTokenIssuance) SetLsfMPTCanTransfer() {
+	m.Flags |= lsfMPTCanTransfer
+}
+
+// SetLsfMPTCanClawback sets the MPT can clawback flag.
+func (m *MPTokenIssuance) SetLsfMPTCanClawback() {
+	m.Flags |= lsfMPTCanClawback
+}
@@ -0,0 +1,62 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMPTokenIssuance_EntryType(t *testing.T) {
+	issuance := &MPTokenIssuance{}
+	require.Equal(t, issuance.EntryType(), MPTokenIssuanceEntry)
+}
+
+func TestMPTokenIssuance_SetFlags(t *testing.T) {
+	issuance := &MPTokenIssuance{}
+	issuance.SetLsfMPTLocked()
+	issuance.SetLsfMPTCanLock()
+	issuance.SetLsfMPTRequireAuth()
+	issuance.SetLsfMPTCanEscrow()
+	issuance.SetLsfMPTCanTrade()
+	issuance.SetLsfMPTCanTransfer()
+	issuance.SetLsfMPTCanClawback()
+	require.Equal(t, uint32(0x7F), issuance.Flags)
+}
+
+func TestMPTokenIssuance_Flatten(t *testing.T) {
+	issuance := &MPTokenIssuance{
+		LedgerEntryType:   MPTokenIssuanceEntry,
+		Flags:             lsfMPTCanTransfer,
+		Issuer:            types.Address("rJXyCjiLSW6FMJVfjmdGZW8avhHgwzHSKq"),
+		AssetScale:        2,
+		MaximumAmount:     "100000000",
+		OutstandingAmount: "5000",
+		TransferFee:       314,
+		MPTokenMetadata:   "CAFEBABE",
+		OwnerNode:         "0000000000000000",
+		PreviousTxnID:     types.Hash256("E3FE6EA3D48F0C2B639448020EA4F03D4F4F8FFDB243A852A0F59177921B4879"),
+		PreviousTxnLgrSeq: 16,
+		Sequence:          1,
+	}
+
+	expected := `{
+	"LedgerEntryType": "MPTokenIssuance",
+	"Flags": 32,
+	"Issuer": "rJXyCjiLSW6FMJVfjmdGZW8avhHgwzHSKq",
+	"AssetScale": 2,
+	"MaximumAmount": "100000000",
+	"OutstandingAmount": "5000",
+	"TransferFee": 314,
+	"MPTokenMetadata": "CAFEBABE",
+	"OwnerNode": "0000000000000000",
+	"PreviousTxnID": "E3FE6EA3D48F0C2B639448020EA4F03D4F4F8FFDB243A852A0F59177921B4879",
+	"PreviousTxnLgrSeq": 16,
+	"Sequence": 1
+}`
+
+	if err := testutil.SerializeAndDeserialize(t, issuance, expected); err != nil {
+		t.Error(err)
+	}
+}
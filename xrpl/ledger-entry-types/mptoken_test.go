@@ -0,0 +1,49 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMPToken_EntryType(t *testing.T) {
+	token := &MPToken{}
+	require.Equal(t, token.EntryType(), MPTokenEntry)
+}
+
+func TestMPToken_SetFlags(t *testing.T) {
+	token := &MPToken{}
+	token.SetLsfMPTLocked()
+	token.SetLsfMPTAuthorized()
+	require.Equal(t, lsfMPTLocked|lsfMPTAuthorized, token.Flags)
+}
+
+func TestMPToken_Flatten(t *testing.T) {
+	token := &MPToken{
+		LedgerEntryType:   MPTokenEntry,
+		Flags:             lsfMPTAuthorized,
+		Account:           types.Address("rajgkBmMxmz161r8bWYH7CQAFZP5bA9oSG"),
+		MPTokenIssuanceID: "000004C463C52827307480341125DA0577DEFC38405B0E3E",
+		MPTAmount:         "100000000",
+		OwnerNode:         "0000000000000000",
+		PreviousTxnID:     types.Hash256("E3FE6EA3D48F0C2B639448020EA4F03D4F4F8FFDB243A852A0F59177921B4879"),
+		PreviousTxnLgrSeq: 16,
+	}
+
+	expected := `{
+	"LedgerEntryType": "MPToken",
+	"Flags": 2,
+	"Account": "rajgkBmMxmz161r8bWYH7CQAFZP5bA9oSG",
+	"MPTokenIssuanceID": "000004C463C52827307480341125DA0577DEFC38405B0E3E",
+	"MPTAmount": "100000000",
+	"OwnerNode": "0000000000000000",
+	"PreviousTxnID": "E3FE6EA3D48F0C2B639448020EA4F03D4F4F8FFDB243A852A0F59177921B4879",
+	"PreviousTxnLgrSeq": 16
+}`
+
+	if err := testutil.SerializeAndDeserialize(t, token, expected); err != nil {
+		t.Error(err)
+	}
+}
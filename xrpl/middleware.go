@@ -0,0 +1,75 @@
+package xrpl
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Handler sends a request and returns its response. The last handler of a client's
+// chain sends the request to the server.
+type Handler func(ctx context.Context, req Request) (Response, error)
+
+// Middleware wraps a Handler to run code around every request of a client, e.g. for
+// logging, metrics or tracing. It may change the request before calling next, or
+// answer without calling it.
+type Middleware func(next Handler) Handler
+
+// Chain wraps h with mws. The first middleware is the outermost one: it sees the
+// request first and the response last.
+func Chain(h Handler, mws ...Middleware) Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// ErrorCode returns the rippled error code carried by err, e.g. "actNotFound", or
+// an empty string if err is not an error answered by the server.
+func ErrorCode(err error) string {
+	var coded interface{ ErrorCode() string }
+	if errors.As(err, &coded) {
+		return coded.ErrorCode()
+	}
+	return ""
+}
+
+// ErrorResult returns the fields of the error answered by the server carried by err, e.g.
+// the searched_all field of txnNotFound, or nil if err is not an error answered by the
+// server.
+func ErrorResult(err error) map[string]any {
+	var result interface{ ErrorResult() map[string]any }
+	if errors.As(err, &result) {
+		return result.ErrorResult()
+	}
+	return nil
+}
+
+// RequestInfo describes a request that went through an Observe middleware.
+type RequestInfo struct {
+	Method     string
+	APIVersion int
+	Latency    time.Duration
+	// ErrorCode is the rippled error code of the response, if the server answered with an error.
+	ErrorCode string
+	Err       error
+}
+
+// Observe returns a middleware calling fn after every request, e.g. to feed metrics
+// or traces.
+func Observe(fn func(ctx context.Context, info RequestInfo)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req Request) (Response, error) {
+			start := time.Now()
+			res, err := next(ctx, req)
+			fn(ctx, RequestInfo{
+				Method:     req.Method(),
+				APIVersion: req.APIVersion(),
+				Latency:    time.Since(start),
+				ErrorCode:  ErrorCode(err),
+				Err:        err,
+			})
+			return res, err
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package xrpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testRequest struct{}
+
+func (testRequest) Method() string  { return "ping" }
+func (testRequest) Validate() error { return nil }
+func (testRequest) APIVersion() int { return 2 }
+
+type testCodedError struct{ code string }
+
+func (e *testCodedError) Error() string     { return e.code }
+func (e *testCodedError) ErrorCode() string { return e.code }
+
+func TestChain(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req Request) (Response, error) {
+				calls = append(calls, name+" before")
+				res, err := next(ctx, req)
+				calls = append(calls, name+" after")
+				return res, err
+			}
+		}
+	}
+
+	h := Chain(func(ctx context.Context, req Request) (Response, error) {
+		calls = append(calls, "handler")
+		return nil, nil
+	}, trace("a"), trace("b"))
+
+	_, err := h(context.Background(), testRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a before", "b before", "handler", "b after", "a after"}, calls)
+}
+
+func TestErrorCode(t *testing.T) {
+	require.Equal(t, "", ErrorCode(nil))
+	require.Equal(t, "", ErrorCode(errors.New("connection refused")))
+	require.Equal(t, "actNotFound", ErrorCode(&testCodedError{code: "actNotFound"}))
+	require.Equal(t, "tooBusy", ErrorCode(fmt.Errorf("wrapped: %w", &testCodedError{code: "tooBusy"})))
+}
+
+type testResultError struct{ result map[string]any }
+
+func (e *testResultError) Error() string               { return "txnNotFound" }
+func (e *testResultError) ErrorResult() map[string]any { return e.result }
+
+func TestErrorResult(t *testing.T) {
+	require.Nil(t, ErrorResult(errors.New("connection refused")))
+	result := map[string]any{"error": "txnNotFound", "searched_all": true}
+	require.Equal(t, result, ErrorResult(fmt.Errorf("wrapped: %w", &testResultError{result: result})))
+}
+
+func TestObserve(t *testing.T) {
+	var info RequestInfo
+	h := Chain(func(ctx context.Context, req Request) (Response, error) {
+		return nil, &testCodedError{code: "noNetwork"}
+	}, Observe(func(_ context.Context, i RequestInfo) {
+		info = i
+	}))
+
+	_, err := h(context.Background(), testRequest{})
+	require.Error(t, err)
+	require.Equal(t, "ping", info.Method)
+	require.Equal(t, 2, info.APIVersion)
+	require.Equal(t, "noNetwork", info.ErrorCode)
+	require.Equal(t, err, info.Err)
+	require.Positive(t, info.Latency)
+}
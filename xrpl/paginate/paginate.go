@@ -0,0 +1,87 @@
+// Package paginate iterates over the results of paginated queries.
+//
+// Queries such as account_tx or ledger_data return their results in pages, and a marker
+// to request the next one. The iterators of this package follow the markers for you:
+//
+//	for tx, err := range paginate.AccountTransactions(ctx, client.Requester(), req) {
+//		if err != nil {
+//			return err
+//		}
+//		// ...
+//	}
+//
+// The Limit of the request, if any, is the size of every page. To stop early, break out
+// of the loop: no more pages are requested.
+package paginate
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"reflect"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+)
+
+var (
+	// ErrRepeatedMarker is returned when the server answers with the marker it was sent,
+	// which would make the iteration loop forever.
+	ErrRepeatedMarker = errors.New("server returned the marker of the previous page")
+)
+
+// Request constrains PR to be a pointer to the paginated request type R.
+type Request[R any] interface {
+	*R
+	xrpl.Request
+}
+
+// All iterates over the items of every page of req. marker returns the Marker field of
+// a request, and items the items and marker of a response.
+//
+// req is not modified: every iteration starts from a copy of it. The iteration stops
+// at the first error, which is yielded with the zero value of T, such as a failed
+// request or a done ctx.
+func All[R any, PR Request[R], Res any, T any](
+	ctx context.Context,
+	r xrpl.Requester,
+	req PR,
+	marker func(req PR) *any,
+	items func(res *Res) ([]T, any),
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		page := PR(new(R))
+		*page = *req
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			res, err := xrpl.Query[Res](ctx, r, page)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			pageItems, next := items(res)
+			for _, item := range pageItems {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if next == nil {
+				return
+			}
+			current := marker(page)
+			if reflect.DeepEqual(*current, next) {
+				yield(zero, ErrRepeatedMarker)
+				return
+			}
+			*current = next
+		}
+	}
+}
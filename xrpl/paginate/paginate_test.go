@@ -0,0 +1,163 @@
+package paginate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRequester answers requests with the given server messages, in order, and records
+// the requests it was sent.
+type mockRequester struct {
+	msgs []map[string]any
+	reqs []account.ChannelsRequest
+}
+
+func (m *mockRequester) RequestContext(_ context.Context, req xrpl.Request) (xrpl.Response, error) {
+	m.reqs = append(m.reqs, *req.(*account.ChannelsRequest))
+	if len(m.reqs) > len(m.msgs) {
+		return nil, errors.New("no more server messages")
+	}
+	msg := m.msgs[len(m.reqs)-1]
+
+	if e, ok := msg["error"].(string); ok {
+		return nil, errors.New(e)
+	}
+
+	// Round-trip through JSON so results are decoded the same way as over the wire.
+	b, err := json.Marshal(msg["result"])
+	if err != nil {
+		return nil, err
+	}
+	var res mockResponse
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type mockResponse map[string]any
+
+func (r mockResponse) GetResult(v any) error {
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{TagName: "json", Result: &v, DecodeHook: mapstructure.TextUnmarshallerHookFunc()})
+	if err != nil {
+		return err
+	}
+	return dec.Decode(map[string]any(r))
+}
+
+func channelsPage(marker any, ids ...string) map[string]any {
+	channels := make([]map[string]any, 0, len(ids))
+	for _, id := range ids {
+		channels = append(channels, map[string]any{"channel_id": id})
+	}
+	result := map[string]any{"channels": channels}
+	if marker != nil {
+		result["marker"] = marker
+	}
+	return map[string]any{"result": result}
+}
+
+func collect(t *testing.T, r xrpl.Requester, req *account.ChannelsRequest) ([]string, error) {
+	t.Helper()
+	var ids []string
+	for ch, err := range AccountChannels(context.Background(), r, req) {
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, ch.ChannelID)
+	}
+	return ids, nil
+}
+
+func TestAll_FollowsMarkers(t *testing.T) {
+	marker := map[string]any{"ledger": float64(10), "seq": float64(3)}
+	r := &mockRequester{msgs: []map[string]any{
+		channelsPage("page2", "a", "b"),
+		channelsPage(marker, "c", "d"),
+		channelsPage(nil, "e"),
+	}}
+	req := &account.ChannelsRequest{Account: "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", Limit: 2}
+
+	ids, err := collect(t, r, req)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c", "d", "e"}, ids)
+
+	require.Len(t, r.reqs, 3)
+	require.Nil(t, r.reqs[0].Marker)
+	require.Equal(t, "page2", r.reqs[1].Marker)
+	require.Equal(t, marker, r.reqs[2].Marker)
+	for _, sent := range r.reqs {
+		require.Equal(t, 2, sent.Limit)
+	}
+
+	// The request of the caller is left untouched.
+	require.Nil(t, req.Marker)
+}
+
+func TestAll_Break(t *testing.T) {
+	r := &mockRequester{msgs: []map[string]any{
+		channelsPage("page2", "a", "b"),
+		channelsPage(nil, "c"),
+	}}
+
+	for ch, err := range AccountChannels(context.Background(), r, &account.ChannelsRequest{}) {
+		require.NoError(t, err)
+		require.Equal(t, "a", ch.ChannelID)
+		break
+	}
+	require.Len(t, r.reqs, 1)
+}
+
+func TestAll_ErrorMidStream(t *testing.T) {
+	r := &mockRequester{msgs: []map[string]any{
+		channelsPage("page2", "a", "b"),
+		{"error": "slowDown"},
+	}}
+
+	ids, err := collect(t, r, &account.ChannelsRequest{})
+	require.EqualError(t, err, "slowDown")
+	require.Equal(t, []string{"a", "b"}, ids)
+}
+
+func TestAll_RepeatedMarker(t *testing.T) {
+	r := &mockRequester{msgs: []map[string]any{
+		channelsPage("page2", "a"),
+		channelsPage("page2", "b"),
+	}}
+
+	ids, err := collect(t, r, &account.ChannelsRequest{})
+	require.ErrorIs(t, err, ErrRepeatedMarker)
+	require.Equal(t, []string{"a", "b"}, ids)
+}
+
+func TestAll_ContextCanceled(t *testing.T) {
+	r := &mockRequester{msgs: []map[string]any{
+		channelsPage("page2", "a"),
+		channelsPage(nil, "b"),
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var ids []string
+	var err error
+	for ch, e := range AccountChannels(ctx, r, &account.ChannelsRequest{}) {
+		if e != nil {
+			err = e
+			break
+		}
+		ids = append(ids, ch.ChannelID)
+		cancel()
+	}
+
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, []string{"a"}, ids)
+	require.Len(t, r.reqs, 1)
+}
@@ -0,0 +1,113 @@
+package paginate
+
+import (
+	"context"
+	"iter"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	ledger "github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	accounttypes "github.com/Peersyst/xrpl-go/xrpl/queries/account/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/clio"
+	cliotypes "github.com/Peersyst/xrpl-go/xrpl/queries/clio/types"
+	ledgerqueries "github.com/Peersyst/xrpl-go/xrpl/queries/ledger"
+	ledgertypes "github.com/Peersyst/xrpl-go/xrpl/queries/ledger/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/path"
+	pathtypes "github.com/Peersyst/xrpl-go/xrpl/queries/path/types"
+)
+
+// AccountTransactions iterates over the transactions of every account_tx page.
+func AccountTransactions(ctx context.Context, r xrpl.Requester, req *account.TransactionsRequest) iter.Seq2[account.Transaction, error] {
+	return All(ctx, r, req,
+		func(req *account.TransactionsRequest) *any { return &req.Marker },
+		func(res *account.TransactionsResponse) ([]account.Transaction, any) {
+			return res.Transactions, res.Marker
+		},
+	)
+}
+
+// AccountLines iterates over the trust lines of every account_lines page.
+func AccountLines(ctx context.Context, r xrpl.Requester, req *account.LinesRequest) iter.Seq2[accounttypes.TrustLine, error] {
+	return All(ctx, r, req,
+		func(req *account.LinesRequest) *any { return &req.Marker },
+		func(res *account.LinesResponse) ([]accounttypes.TrustLine, any) { return res.Lines, res.Marker },
+	)
+}
+
+// AccountObjects iterates over the ledger objects of every account_objects page.
+func AccountObjects(ctx context.Context, r xrpl.Requester, req *account.ObjectsRequest) iter.Seq2[ledger.FlatLedgerObject, error] {
+	return All(ctx, r, req,
+		func(req *account.ObjectsRequest) *any { return &req.Marker },
+		func(res *account.ObjectsResponse) ([]ledger.FlatLedgerObject, any) {
+			return res.AccountObjects, res.Marker
+		},
+	)
+}
+
+// AccountOffers iterates over the offers of every account_offers page.
+func AccountOffers(ctx context.Context, r xrpl.Requester, req *account.OffersRequest) iter.Seq2[accounttypes.OfferResult, error] {
+	return All(ctx, r, req,
+		func(req *account.OffersRequest) *any { return &req.Marker },
+		func(res *account.OffersResponse) ([]accounttypes.OfferResult, any) { return res.Offers, res.Marker },
+	)
+}
+
+// AccountNFTs iterates over the NFTs of every account_nfts page.
+func AccountNFTs(ctx context.Context, r xrpl.Requester, req *account.NFTsRequest) iter.Seq2[accounttypes.NFT, error] {
+	return All(ctx, r, req,
+		func(req *account.NFTsRequest) *any { return &req.Marker },
+		func(res *account.NFTsResponse) ([]accounttypes.NFT, any) { return res.AccountNFTs, res.Marker },
+	)
+}
+
+// AccountChannels iterates over the payment channels of every account_channels page.
+func AccountChannels(ctx context.Context, r xrpl.Requester, req *account.ChannelsRequest) iter.Seq2[accounttypes.ChannelResult, error] {
+	return All(ctx, r, req,
+		func(req *account.ChannelsRequest) *any { return &req.Marker },
+		func(res *account.ChannelsResponse) ([]accounttypes.ChannelResult, any) {
+			return res.Channels, res.Marker
+		},
+	)
+}
+
+// LedgerData iterates over the ledger state entries of every ledger_data page.
+func LedgerData(ctx context.Context, r xrpl.Requester, req *ledgerqueries.DataRequest) iter.Seq2[ledgertypes.State, error] {
+	return All(ctx, r, req,
+		func(req *ledgerqueries.DataRequest) *any { return &req.Marker },
+		func(res *ledgerqueries.DataResponse) ([]ledgertypes.State, any) { return res.State, res.Marker },
+	)
+}
+
+// BookOffers iterates over the offers of every book_offers page.
+func BookOffers(ctx context.Context, r xrpl.Requester, req *path.BookOffersRequest) iter.Seq2[pathtypes.BookOffer, error] {
+	return All(ctx, r, req,
+		func(req *path.BookOffersRequest) *any { return &req.Marker },
+		func(res *path.BookOffersResponse) ([]pathtypes.BookOffer, any) { return res.Offers, res.Marker },
+	)
+}
+
+// NFTsByIssuer iterates over the NFTs of every page of the Clio nfts_by_issuer method.
+func NFTsByIssuer(ctx context.Context, r xrpl.Requester, req *clio.NFTsByIssuerRequest) iter.Seq2[cliotypes.NFToken, error] {
+	return All(ctx, r, req,
+		func(req *clio.NFTsByIssuerRequest) *any { return &req.Marker },
+		func(res *clio.NFTsByIssuerResponse) ([]cliotypes.NFToken, any) { return res.NFTs, res.Marker },
+	)
+}
+
+// NFTHistory iterates over the transactions of every page of the Clio nft_history method.
+func NFTHistory(ctx context.Context, r xrpl.Requester, req *clio.NFTHistoryRequest) iter.Seq2[clio.NFTHistoryTransactions, error] {
+	return All(ctx, r, req,
+		func(req *clio.NFTHistoryRequest) *any { return &req.Marker },
+		func(res *clio.NFTHistoryResponse) ([]clio.NFTHistoryTransactions, any) {
+			return res.Transactions, res.Marker
+		},
+	)
+}
+
+// MPTHolders iterates over the holders of every page of the Clio mpt_holders method.
+func MPTHolders(ctx context.Context, r xrpl.Requester, req *clio.MPTHoldersRequest) iter.Seq2[cliotypes.MPTHolder, error] {
+	return All(ctx, r, req,
+		func(req *clio.MPTHoldersRequest) *any { return &req.Marker },
+		func(res *clio.MPTHoldersResponse) ([]cliotypes.MPTHolder, any) { return res.MPTokens, res.Marker },
+	)
+}
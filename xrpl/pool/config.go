@@ -0,0 +1,164 @@
+package pool
+
+import (
+	"time"
+
+	"github.com/Peersyst/xrpl-go/xrpl/autofill"
+	"github.com/Peersyst/xrpl-go/xrpl/common"
+	"github.com/Peersyst/xrpl-go/xrpl/sequence"
+)
+
+const (
+	// DefaultHealthCheckInterval is how long endpoint health is trusted before it is checked again.
+	DefaultHealthCheckInterval = 30 * time.Second
+	// DefaultMaxLedgerAge is the oldest validated ledger a healthy endpoint may report.
+	DefaultMaxLedgerAge = 60 * time.Second
+	// DefaultPenaltyDuration is how long a failing endpoint is skipped after its first failure.
+	// The penalty doubles with every consecutive failure, up to DefaultMaxPenaltyDuration.
+	DefaultPenaltyDuration    = 5 * time.Second
+	DefaultMaxPenaltyDuration = 5 * time.Minute
+)
+
+type Config struct {
+	// Health config
+	healthCheckInterval time.Duration
+	maxLedgerAge        time.Duration
+
+	// Penalty box config
+	penaltyDuration    time.Duration
+	maxPenaltyDuration time.Duration
+
+	// Retry config
+	maxRetries int
+	retryDelay time.Duration
+
+	// Fee config
+	maxFeeXRP   float32
+	feeCushion  float32
+	feeStrategy autofill.FeeStrategy
+	feeReporter autofill.FeeReporter
+
+	// Sequence config
+	sequences *sequence.Allocator
+
+	// Faucet config
+	faucetProvider common.FaucetProvider
+}
+
+type ConfigOpt func(c *Config)
+
+// WithHealthCheckInterval sets how long endpoint health is trusted before it is checked again.
+// Default: 30 seconds
+func WithHealthCheckInterval(interval time.Duration) ConfigOpt {
+	return func(c *Config) {
+		c.healthCheckInterval = interval
+	}
+}
+
+// WithMaxLedgerAge sets the oldest validated ledger a healthy endpoint may report.
+// Default: 60 seconds
+func WithMaxLedgerAge(age time.Duration) ConfigOpt {
+	return func(c *Config) {
+		c.maxLedgerAge = age
+	}
+}
+
+// WithPenaltyDuration sets how long a failing endpoint is skipped after its first failure,
+// and the maximum the penalty grows to after consecutive failures.
+// Default: 5 seconds, up to 5 minutes
+func WithPenaltyDuration(penalty, maxPenalty time.Duration) ConfigOpt {
+	return func(c *Config) {
+		c.penaltyDuration = penalty
+		c.maxPenaltyDuration = maxPenalty
+	}
+}
+
+// WithMaxRetries sets the number of times SubmitTxBlobAndWait checks a transaction
+// whose LastLedgerSequence has passed, before giving up if the server lacks the
+// ledgers proving it expired.
+// Default: 10
+func WithMaxRetries(maxRetries int) ConfigOpt {
+	return func(c *Config) {
+		c.maxRetries = maxRetries
+	}
+}
+
+// WithRetryDelay sets the delay between checks of a submitted transaction.
+// Default: 1 second
+func WithRetryDelay(retryDelay time.Duration) ConfigOpt {
+	return func(c *Config) {
+		c.retryDelay = retryDelay
+	}
+}
+
+// WithSequenceAllocator sets the allocator handing out the sequences of autofilled
+// transactions, instead of querying account_info for each of them. Share it with the
+// other clients submitting for the same accounts.
+// Default: nil
+func WithSequenceAllocator(a *sequence.Allocator) ConfigOpt {
+	return func(c *Config) {
+		c.sequences = a
+	}
+}
+
+// WithMaxFeeXRP sets the maximum fee in XRP that the pool will use.
+// Default: 2
+func WithMaxFeeXRP(maxFeeXRP float32) ConfigOpt {
+	return func(c *Config) {
+		c.maxFeeXRP = maxFeeXRP
+	}
+}
+
+// WithFeeCushion sets the fee cushion of the pool.
+// Default: 1.2
+func WithFeeCushion(feeCushion float32) ConfigOpt {
+	return func(c *Config) {
+		c.feeCushion = feeCushion
+	}
+}
+
+// WithFeeStrategy sets the strategy estimating the network fee of autofilled transactions,
+// e.g. autofill.OpenLedgerFeeStrategy to skip the queue during fee spikes. The fee is
+// still capped by WithMaxFeeXRP.
+// Default: autofill.BaseFeeStrategy with the fee cushion
+func WithFeeStrategy(s autofill.FeeStrategy) ConfigOpt {
+	return func(c *Config) {
+		c.feeStrategy = s
+	}
+}
+
+// WithFeeReporter sets the function called with every fee set on autofilled transactions,
+// along with the reason the fee strategy chose it, e.g. to log it.
+func WithFeeReporter(r autofill.FeeReporter) ConfigOpt {
+	return func(c *Config) {
+		c.feeReporter = r
+	}
+}
+
+// WithFaucetProvider sets the faucet provider of the pool.
+func WithFaucetProvider(fp common.FaucetProvider) ConfigOpt {
+	return func(c *Config) {
+		c.faucetProvider = fp
+	}
+}
+
+func newConfig(opts ...ConfigOpt) *Config {
+	cfg := &Config{
+		healthCheckInterval: DefaultHealthCheckInterval,
+		maxLedgerAge:        DefaultMaxLedgerAge,
+		penaltyDuration:     DefaultPenaltyDuration,
+		maxPenaltyDuration:  DefaultMaxPenaltyDuration,
+
+		maxRetries: common.DefaultMaxRetries,
+		retryDelay: common.DefaultRetryDelay,
+
+		maxFeeXRP:  common.DefaultMaxFeeXRP,
+		feeCushion: common.DefaultFeeCushion,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return cfg
+}
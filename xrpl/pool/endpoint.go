@@ -0,0 +1,115 @@
+package pool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/rpc"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
+)
+
+// Endpoint is a server the pool can route requests to.
+type Endpoint struct {
+	// Name identifies the endpoint in statuses and errors, usually its URL.
+	Name      string
+	Requester xrpl.Requester
+}
+
+// RPCEndpoint returns an endpoint backed by a new JSON-RPC client for url. The client does
+// not retry failed requests, rpc.NoRetry, since the pool fails over to the next endpoint
+// instead. opts may set another retry policy with rpc.WithRetryPolicy.
+func RPCEndpoint(url string, opts ...rpc.ConfigOpt) (Endpoint, error) {
+	cfg, err := rpc.NewClientConfig(url, append([]rpc.ConfigOpt{rpc.WithRetryPolicy(rpc.NoRetry)}, opts...)...)
+	if err != nil {
+		return Endpoint{}, err
+	}
+	return Endpoint{
+		Name:      url,
+		Requester: rpc.NewClient(cfg).Requester(),
+	}, nil
+}
+
+// WebsocketEndpoint returns an endpoint backed by the websocket client c.
+// The pool does not manage the connection: c must be connected by the caller.
+func WebsocketEndpoint(name string, c *websocket.Client) Endpoint {
+	return Endpoint{
+		Name:      name,
+		Requester: c.Requester(),
+	}
+}
+
+// Health is the result of the last health check of an endpoint.
+type Health struct {
+	// Healthy reports whether the endpoint is synced, has a recent validated ledger
+	// and is not amendment blocked.
+	Healthy bool
+	// ServerState is the server_state reported by server_info, e.g. "full" or "syncing".
+	ServerState string
+	// LedgerAge is the age of the last validated ledger.
+	LedgerAge time.Duration
+	// LoadFactor is the load factor reported by server_info.
+	LoadFactor uint
+	// CheckedAt is when the check completed. It is zero if the endpoint was never checked.
+	CheckedAt time.Time
+	// Err is the error of the check, if the endpoint could not be reached.
+	Err error
+}
+
+// EndpointStatus is a snapshot of the state the pool keeps for an endpoint.
+type EndpointStatus struct {
+	Name   string
+	Health Health
+	// Failures is the number of consecutive failed requests.
+	Failures int
+	// PenalizedUntil is when the endpoint leaves the penalty box. It is in the past if the endpoint is not penalized.
+	PenalizedUntil time.Time
+}
+
+// endpoint is the mutable state of an Endpoint. All fields below mu are guarded by it.
+type endpoint struct {
+	Endpoint
+	index int
+
+	mu             sync.Mutex
+	health         Health
+	failures       int
+	penalizedUntil time.Time
+}
+
+func (e *endpoint) status() EndpointStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return EndpointStatus{
+		Name:           e.Name,
+		Health:         e.health,
+		Failures:       e.failures,
+		PenalizedUntil: e.penalizedUntil,
+	}
+}
+
+// succeeded clears the failure streak of the endpoint.
+func (e *endpoint) succeeded() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.failures = 0
+	e.penalizedUntil = time.Time{}
+}
+
+// failed puts the endpoint in the penalty box. The penalty doubles with every
+// consecutive failure, up to maxPenalty.
+func (e *endpoint) failed(now time.Time, penalty, maxPenalty time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.failures++
+	for i := 1; i < e.failures && penalty < maxPenalty; i++ {
+		penalty *= 2
+	}
+	if penalty > maxPenalty {
+		penalty = maxPenalty
+	}
+	e.penalizedUntil = now.Add(penalty)
+}
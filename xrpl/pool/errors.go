@@ -0,0 +1,37 @@
+package pool
+
+import (
+	"errors"
+
+	"github.com/Peersyst/xrpl-go/xrpl/autofill"
+)
+
+// Static errors
+var (
+	ErrNoEndpoints                            = errors.New("pool needs at least one endpoint")
+	ErrNilRequester                           = errors.New("endpoint requester must not be nil")
+	ErrAllEndpointsFailed                     = errors.New("all endpoints failed")
+	ErrCannotFundWalletWithoutClassicAddress  = errors.New("cannot fund wallet without classic address")
+	ErrMissingLastLedgerSequenceInTransaction = errors.New("missing LastLedgerSequence in transaction")
+	ErrMissingFaucetProvider                  = errors.New("pool has no faucet provider")
+
+	// Autofill errors, shared with the clients.
+	ErrSignerIsNotAnObject = autofill.ErrSignerIsNotAnObject
+	ErrSignerDataIsEmpty   = autofill.ErrSignerDataIsEmpty
+)
+
+// Dynamic errors
+
+// EndpointError is the error a request failed with on a given endpoint.
+type EndpointError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *EndpointError) Error() string {
+	return e.Endpoint + ": " + e.Err.Error()
+}
+
+func (e *EndpointError) Unwrap() error {
+	return e.Err
+}
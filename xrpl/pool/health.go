@@ -0,0 +1,105 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	server "github.com/Peersyst/xrpl-go/xrpl/queries/server"
+	servertypes "github.com/Peersyst/xrpl-go/xrpl/queries/server/types"
+)
+
+// healthyStates are the server states in which a server is synced with the network.
+var healthyStates = map[string]bool{
+	"full":       true,
+	"proposing":  true,
+	"validating": true,
+}
+
+// CheckHealth queries server_info on every endpoint, in parallel, and updates their health.
+// Endpoints that can't be reached are put in the penalty box. Requests trigger the checks
+// in the background once the health check interval has passed, and are routed in the
+// order of the endpoints until the first one is done: call CheckHealth after NewPool to
+// route the first requests by health too.
+func (p *Pool) CheckHealth(ctx context.Context) {
+	p.checkHealth(ctx)
+}
+
+// refreshHealth starts a health check in the background if the last one is older than the
+// health check interval and none is running. Requests never wait for it.
+func (p *Pool) refreshHealth(ctx context.Context) {
+	p.healthMu.Lock()
+	defer p.healthMu.Unlock()
+
+	if p.checking || (!p.checkedAt.IsZero() && p.now().Sub(p.checkedAt) < p.cfg.healthCheckInterval) {
+		return
+	}
+	p.checking = true
+	go func() {
+		// The check outlives the request that triggered it.
+		p.checkHealth(context.WithoutCancel(ctx))
+		p.healthMu.Lock()
+		p.checking = false
+		p.healthMu.Unlock()
+	}()
+}
+
+func (p *Pool) checkHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, e := range p.endpoints {
+		wg.Add(1)
+		go func(e *endpoint) {
+			defer wg.Done()
+			p.checkEndpoint(ctx, e)
+		}(e)
+	}
+	wg.Wait()
+
+	if ctx.Err() == nil {
+		p.healthMu.Lock()
+		p.checkedAt = p.now()
+		p.healthMu.Unlock()
+	}
+}
+
+func (p *Pool) checkEndpoint(ctx context.Context, e *endpoint) {
+	res, err := xrpl.Query[server.InfoResponse](ctx, e.Requester, &server.InfoRequest{})
+	now := p.now()
+	if err != nil {
+		// The endpoint isn't to blame if the caller gave up.
+		if ctx.Err() != nil {
+			return
+		}
+		e.mu.Lock()
+		e.health = Health{CheckedAt: now, Err: err}
+		e.mu.Unlock()
+		e.failed(now, p.cfg.penaltyDuration, p.cfg.maxPenaltyDuration)
+		return
+	}
+
+	h := p.assess(&res.Info)
+	h.CheckedAt = now
+
+	e.mu.Lock()
+	e.health = h
+	e.mu.Unlock()
+
+	if h.Healthy {
+		e.succeeded()
+	}
+}
+
+// assess scores the server_info of an endpoint.
+func (p *Pool) assess(info *servertypes.Info) Health {
+	h := Health{
+		ServerState: info.ServerState,
+		LedgerAge:   time.Duration(info.ValidatedLedger.Age) * time.Second,
+		LoadFactor:  info.LoadFactor,
+	}
+	h.Healthy = healthyStates[info.ServerState] &&
+		info.ValidatedLedger.Seq != 0 &&
+		h.LedgerAge <= p.cfg.maxLedgerAge &&
+		!info.AmendmentBlocked
+	return h
+}
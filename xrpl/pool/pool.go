@@ -0,0 +1,241 @@
+// Package pool provides a client that spreads requests over several XRPL servers.
+//
+// The pool scores every endpoint with server_info (server state, validated ledger age
+// and load factor) and sends each request to the best one. Transport errors and
+// server errors such as noNetwork or tooBusy make it fail over to the next endpoint,
+// while the failing one is put in a penalty box for a while.
+package pool
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/binary-codec/definitions"
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/queries"
+	"github.com/Peersyst/xrpl-go/xrpl/rpc"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
+)
+
+var _ xrpl.Client = (*Pool)(nil)
+
+// failoverCodes are the server error codes that mean another server may succeed.
+var failoverCodes = map[string]bool{
+	"noNetwork":        true,
+	"noCurrent":        true,
+	"noClosed":         true,
+	"notSynced":        true,
+	"tooBusy":          true,
+	"slowDown":         true,
+	"amendmentBlocked": true,
+	"failedToForward":  true,
+}
+
+// Pool is a client that routes every request to the healthiest of its endpoints and
+// fails over to the others when it can't be served. It is safe for concurrent use.
+type Pool struct {
+	// Typed queries and iterators, routed like any other request.
+	queries.Methods
+
+	cfg       *Config
+	endpoints []*endpoint
+
+	// healthMu guards the time of the last health check, and whether one is running.
+	healthMu  sync.Mutex
+	checkedAt time.Time
+	checking  bool
+
+	now func() time.Time
+
+	definitions xrpl.DefinitionsCache
+
+	NetworkID uint32
+}
+
+// NewPool creates a pool that routes requests over endpoints, in the given order of
+// preference when they are equally healthy.
+func NewPool(endpoints []Endpoint, opts ...ConfigOpt) (*Pool, error) {
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	p := &Pool{
+		cfg: newConfig(opts...),
+		now: time.Now,
+	}
+	p.Methods = queries.NewMethods(p)
+	for i, e := range endpoints {
+		if e.Requester == nil {
+			return nil, ErrNilRequester
+		}
+		p.endpoints = append(p.endpoints, &endpoint{Endpoint: e, index: i})
+	}
+	return p, nil
+}
+
+// Requester returns the pool itself, which routes every request.
+func (p *Pool) Requester() xrpl.Requester {
+	return p
+}
+
+// Definitions returns the binary codec definitions of the server, fetched with the
+// server_definitions method. They are cached, and only fetched again when the server
+// reports a new hash.
+func (p *Pool) Definitions(ctx context.Context) (*definitions.Definitions, error) {
+	return p.definitions.Get(ctx, p.Requester())
+}
+
+// Codec returns a codec that encodes and decodes with the definitions of the server,
+// see Definitions.
+func (p *Pool) Codec(ctx context.Context) (*binarycodec.Codec, error) {
+	return p.definitions.Codec(ctx, p.Requester())
+}
+
+// Request sends a request to the healthiest endpoint and fails over to the next ones if needed.
+func (p *Pool) Request(req xrpl.Request) (xrpl.Response, error) {
+	return p.RequestContext(context.Background(), req)
+}
+
+// RequestContext is like Request but uses ctx to cancel the request, or to bound its
+// duration. The health checks it may trigger run in the background, see CheckHealth.
+func (p *Pool) RequestContext(ctx context.Context, req xrpl.Request) (xrpl.Response, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.cfg.healthCheckInterval > 0 {
+		p.refreshHealth(ctx)
+	}
+
+	var errs []error
+	for _, e := range p.candidates() {
+		res, err := e.Requester.RequestContext(ctx, req)
+		if err == nil {
+			e.succeeded()
+			return res, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		if !shouldFailover(err) {
+			// The server answered, the request itself failed.
+			e.succeeded()
+			return nil, err
+		}
+		if !replayable(req, err) {
+			// The server may have applied the request, another one would hide its result.
+			e.failed(p.now(), p.cfg.penaltyDuration, p.cfg.maxPenaltyDuration)
+			return nil, err
+		}
+
+		e.failed(p.now(), p.cfg.penaltyDuration, p.cfg.maxPenaltyDuration)
+		errs = append(errs, &EndpointError{Endpoint: e.Name, Err: err})
+	}
+
+	return nil, fmt.Errorf("%w: %w", ErrAllEndpointsFailed, errors.Join(errs...))
+}
+
+// Status returns a snapshot of the state of every endpoint, in the order they were given.
+func (p *Pool) Status() []EndpointStatus {
+	statuses := make([]EndpointStatus, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		statuses = append(statuses, e.status())
+	}
+	return statuses
+}
+
+// candidates returns the endpoints in the order they should be tried: healthy ones first,
+// by load factor and then validated ledger age. Penalized endpoints come last, as a last resort.
+func (p *Pool) candidates() []*endpoint {
+	type candidate struct {
+		e *endpoint
+		s EndpointStatus
+	}
+
+	now := p.now()
+	cs := make([]candidate, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		cs = append(cs, candidate{e: e, s: e.status()})
+	}
+
+	slices.SortStableFunc(cs, func(a, b candidate) int {
+		aPenalized, bPenalized := now.Before(a.s.PenalizedUntil), now.Before(b.s.PenalizedUntil)
+		if aPenalized != bPenalized {
+			return boolOrder(aPenalized)
+		}
+		if aPenalized {
+			return a.s.PenalizedUntil.Compare(b.s.PenalizedUntil)
+		}
+		if a.s.Health.Healthy != b.s.Health.Healthy {
+			return boolOrder(!a.s.Health.Healthy)
+		}
+		if c := cmp.Compare(a.s.Health.LoadFactor, b.s.Health.LoadFactor); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.s.Health.LedgerAge, b.s.Health.LedgerAge)
+	})
+
+	endpoints := make([]*endpoint, 0, len(cs))
+	for _, c := range cs {
+		endpoints = append(endpoints, c.e)
+	}
+	return endpoints
+}
+
+// boolOrder sorts a after b when lastIfTrue is set.
+func boolOrder(lastIfTrue bool) int {
+	if lastIfTrue {
+		return 1
+	}
+	return -1
+}
+
+// shouldFailover reports whether err means the request may succeed on another endpoint:
+// server errors with a code in failoverCodes, HTTP server errors, and network errors or
+// timeouts. Errors raised by the client before sending, such as ErrAdminRequest or
+// validation errors, would fail on every endpoint and are returned as they are.
+func shouldFailover(err error) bool {
+	if code := xrpl.ErrorCode(err); code != "" {
+		return failoverCodes[code]
+	}
+	var rpcErr *rpc.ClientError
+	if errors.As(err, &rpcErr) {
+		return rpcErr.StatusCode == http.StatusTooManyRequests || rpcErr.StatusCode >= http.StatusInternalServerError
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, rpc.ErrEmptyResponse) ||
+		errors.Is(err, websocket.ErrNotConnected) ||
+		errors.Is(err, websocket.ErrNotConnectedToServer) ||
+		errors.Is(err, websocket.ErrConnectionClosed) ||
+		errors.Is(err, websocket.ErrRequestTimedOut)
+}
+
+// replayable reports whether req can be sent to another endpoint after failing with err,
+// see rpc.Replayable. Non-idempotent requests such as submit only fail over when
+// the failure shows the server did not process them: dial errors, requests the websocket
+// client could not send, slowDown and tooBusy errors, and HTTP 429 and 503 responses.
+func replayable(req xrpl.Request, err error) bool {
+	if errors.Is(err, websocket.ErrNotConnected) || errors.Is(err, websocket.ErrNotConnectedToServer) {
+		return true
+	}
+	a := rpc.Attempt{Method: req.Method(), ErrorCode: xrpl.ErrorCode(err), Err: err}
+	var rpcErr *rpc.ClientError
+	if errors.As(err, &rpcErr) {
+		a.StatusCode = rpcErr.StatusCode
+	}
+	return rpc.Replayable(req, a)
+}
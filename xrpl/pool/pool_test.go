@@ -0,0 +1,463 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	account "github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	servertypes "github.com/Peersyst/xrpl-go/xrpl/queries/server/types"
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/utility"
+	"github.com/Peersyst/xrpl-go/xrpl/rpc"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket"
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/testify/require"
+)
+
+type mockResponse map[string]any
+
+func (r mockResponse) GetResult(v any) error {
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{TagName: "json", Result: &v, DecodeHook: mapstructure.TextUnmarshallerHookFunc()})
+	if err != nil {
+		return err
+	}
+	return dec.Decode(map[string]any(r))
+}
+
+// mockServer answers server_info with info and every other request with handle.
+// server_info waits for infoBlock to be closed, if set.
+type mockServer struct {
+	mu        sync.Mutex
+	info      map[string]any
+	infoBlock chan struct{}
+	handle    func(req xrpl.Request) (xrpl.Response, error)
+	calls     map[string]int
+}
+
+func (s *mockServer) RequestContext(ctx context.Context, req xrpl.Request) (xrpl.Response, error) {
+	s.mu.Lock()
+	if s.calls == nil {
+		s.calls = make(map[string]int)
+	}
+	s.calls[req.Method()]++
+	s.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if req.Method() == "server_info" {
+		if s.infoBlock != nil {
+			<-s.infoBlock
+		}
+		return mockResponse{"info": s.info}, nil
+	}
+	if s.handle == nil {
+		return mockResponse{}, nil
+	}
+	return s.handle(req)
+}
+
+func (s *mockServer) count(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+func healthyInfo(loadFactor uint) map[string]any {
+	return map[string]any{
+		"server_state": "full",
+		"load_factor":  loadFactor,
+		"validated_ledger": map[string]any{
+			"age": 2,
+			"seq": 100,
+		},
+	}
+}
+
+func failWith(err error) func(xrpl.Request) (xrpl.Response, error) {
+	return func(xrpl.Request) (xrpl.Response, error) {
+		return nil, err
+	}
+}
+
+func newTestPool(t *testing.T, servers ...*mockServer) *Pool {
+	endpoints := make([]Endpoint, 0, len(servers))
+	for i, s := range servers {
+		endpoints = append(endpoints, Endpoint{Name: string(rune('a' + i)), Requester: s})
+	}
+	p, err := NewPool(endpoints, WithHealthCheckInterval(time.Minute))
+	require.NoError(t, err)
+	p.CheckHealth(context.Background())
+	return p
+}
+
+func TestNewPool(t *testing.T) {
+	_, err := NewPool(nil)
+	require.ErrorIs(t, err, ErrNoEndpoints)
+
+	_, err = NewPool([]Endpoint{{Name: "a"}})
+	require.ErrorIs(t, err, ErrNilRequester)
+}
+
+func TestPool_RoutesToHealthiestEndpoint(t *testing.T) {
+	syncing := &mockServer{info: map[string]any{"server_state": "syncing"}}
+	busy := &mockServer{info: healthyInfo(10)}
+	best := &mockServer{info: healthyInfo(1)}
+	p := newTestPool(t, syncing, busy, best)
+
+	_, err := p.Ping(&utility.PingRequest{})
+	require.NoError(t, err)
+
+	require.Equal(t, 0, syncing.count("ping"))
+	require.Equal(t, 0, busy.count("ping"))
+	require.Equal(t, 1, best.count("ping"))
+
+	// Health is cached for the health check interval.
+	_, err = p.Ping(&utility.PingRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 1, best.count("server_info"))
+}
+
+func TestPool_HealthCheckInBackground(t *testing.T) {
+	s := &mockServer{info: healthyInfo(1)}
+	p := newTestPool(t, s)
+
+	now := time.Now()
+	p.now = func() time.Time { return now }
+	s.infoBlock = make(chan struct{})
+	now = now.Add(time.Minute)
+
+	// The stale health is checked again without holding up requests.
+	for range 3 {
+		_, err := p.Ping(&utility.PingRequest{})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, s.count("ping"))
+	close(s.infoBlock)
+	require.Eventually(t, func() bool {
+		p.healthMu.Lock()
+		defer p.healthMu.Unlock()
+		return !p.checking && p.checkedAt.Equal(now)
+	}, time.Second, time.Millisecond)
+	require.Equal(t, 2, s.count("server_info"))
+}
+
+func connRefused() error {
+	return &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+}
+
+func TestPool_Failover(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantFailover bool
+	}{
+		{
+			name:         "transport error",
+			err:          connRefused(),
+			wantFailover: true,
+		},
+		{
+			name:         "connection closed",
+			err:          websocket.ErrConnectionClosed,
+			wantFailover: true,
+		},
+		{
+			name:         "noNetwork over JSON-RPC",
+			err:          &rpc.ClientError{ErrorString: "noNetwork", Code: "noNetwork"},
+			wantFailover: true,
+		},
+		{
+			name:         "HTTP 503",
+			err:          &rpc.ClientError{ErrorString: "Server is overloaded, rate limit exceeded", StatusCode: 503},
+			wantFailover: true,
+		},
+		{
+			name:         "tooBusy over websocket",
+			err:          &websocket.ErrorWebsocketClientXrplResponse{Type: "tooBusy"},
+			wantFailover: true,
+		},
+		{
+			name:         "request error",
+			err:          &rpc.ClientError{ErrorString: "actNotFound", Code: "actNotFound"},
+			wantFailover: false,
+		},
+		{
+			name:         "admin request refused by the client",
+			err:          xrpl.ErrAdminRequest,
+			wantFailover: false,
+		},
+		{
+			name:         "Clio-only request refused by the client",
+			err:          &xrpl.ClioOnlyError{Method: "nft_info"},
+			wantFailover: false,
+		},
+		{
+			name:         "local error",
+			err:          errors.New("invalid request"),
+			wantFailover: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := &mockServer{info: healthyInfo(1), handle: failWith(tt.err)}
+			second := &mockServer{info: healthyInfo(2)}
+			p := newTestPool(t, first, second)
+
+			_, err := p.Ping(&utility.PingRequest{})
+			require.Equal(t, 1, first.count("ping"))
+
+			if !tt.wantFailover {
+				require.ErrorIs(t, err, tt.err)
+				require.Equal(t, 0, second.count("ping"))
+				require.Zero(t, p.Status()[0].Failures)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, 1, second.count("ping"))
+			require.Equal(t, 1, p.Status()[0].Failures)
+			require.True(t, p.Status()[0].PenalizedUntil.After(time.Now()))
+		})
+	}
+}
+
+func TestPool_NonIdempotentFailover(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantFailover bool
+	}{
+		{
+			name:         "timeout",
+			err:          websocket.ErrRequestTimedOut,
+			wantFailover: false,
+		},
+		{
+			name:         "connection closed",
+			err:          websocket.ErrConnectionClosed,
+			wantFailover: false,
+		},
+		{
+			name:         "read error",
+			err:          &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")},
+			wantFailover: false,
+		},
+		{
+			name:         "noNetwork",
+			err:          &rpc.ClientError{ErrorString: "noNetwork", Code: "noNetwork"},
+			wantFailover: false,
+		},
+		{
+			name:         "dial error",
+			err:          connRefused(),
+			wantFailover: true,
+		},
+		{
+			name:         "websocket not connected",
+			err:          websocket.ErrNotConnectedToServer,
+			wantFailover: true,
+		},
+		{
+			name:         "tooBusy",
+			err:          &websocket.ErrorWebsocketClientXrplResponse{Type: "tooBusy"},
+			wantFailover: true,
+		},
+		{
+			name:         "HTTP 503",
+			err:          &rpc.ClientError{ErrorString: "Server is overloaded, rate limit exceeded", StatusCode: 503},
+			wantFailover: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := &mockServer{info: healthyInfo(1), handle: failWith(tt.err)}
+			second := &mockServer{info: healthyInfo(2)}
+			p := newTestPool(t, first, second)
+
+			_, err := p.Request(&requests.SubmitRequest{TxBlob: "1200"})
+			require.Equal(t, 1, first.count("submit"))
+			require.Equal(t, 1, p.Status()[0].Failures)
+
+			if !tt.wantFailover {
+				// The first server may have applied the transaction, so it is not replayed.
+				require.ErrorIs(t, err, tt.err)
+				require.Equal(t, 0, second.count("submit"))
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, 1, second.count("submit"))
+		})
+	}
+}
+
+func TestPool_PenaltyBox(t *testing.T) {
+	flaky := &mockServer{info: healthyInfo(1), handle: failWith(connRefused())}
+	backup := &mockServer{info: healthyInfo(5)}
+	p := newTestPool(t, flaky, backup)
+
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	_, err := p.Ping(&utility.PingRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 1, flaky.count("ping"))
+
+	// While penalized, the flaky endpoint is skipped even though it is the healthiest.
+	_, err = p.Ping(&utility.PingRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 1, flaky.count("ping"))
+	require.Equal(t, 2, backup.count("ping"))
+
+	// Once the penalty expires it is tried again.
+	now = now.Add(DefaultPenaltyDuration)
+	_, err = p.Ping(&utility.PingRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 2, flaky.count("ping"))
+	require.Equal(t, 2, p.Status()[0].Failures)
+}
+
+func TestPool_AllEndpointsFailed(t *testing.T) {
+	down := connRefused()
+	p := newTestPool(t,
+		&mockServer{info: healthyInfo(1), handle: failWith(down)},
+		&mockServer{info: healthyInfo(1), handle: failWith(&rpc.ClientError{ErrorString: "tooBusy", Code: "tooBusy"})},
+	)
+
+	_, err := p.Ping(&utility.PingRequest{})
+	require.ErrorIs(t, err, ErrAllEndpointsFailed)
+	require.ErrorIs(t, err, down)
+
+	var endpointErr *EndpointError
+	require.ErrorAs(t, err, &endpointErr)
+	require.Equal(t, "a", endpointErr.Endpoint)
+}
+
+func TestPool_CanceledContextDoesNotPenalize(t *testing.T) {
+	s := &mockServer{info: healthyInfo(1)}
+	p := newTestPool(t, s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := p.PingContext(ctx, &utility.PingRequest{})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Zero(t, p.Status()[0].Failures)
+}
+
+func TestPool_TypedQuery(t *testing.T) {
+	s := &mockServer{
+		info: healthyInfo(1),
+		handle: func(req xrpl.Request) (xrpl.Response, error) {
+			return mockResponse{
+				"account_data": map[string]any{
+					"Account":  "rN7n7otQDd6FczFgLdSqtcsAUxDkw6fzRH",
+					"Balance":  "1000000",
+					"Sequence": 7,
+				},
+			}, nil
+		},
+	}
+	p := newTestPool(t, s)
+
+	res, err := p.GetAccountInfo(&account.InfoRequest{Account: "rN7n7otQDd6FczFgLdSqtcsAUxDkw6fzRH"})
+	require.NoError(t, err)
+	require.Equal(t, uint32(7), res.AccountData.Sequence)
+
+	balance, err := p.GetXrpBalance("rN7n7otQDd6FczFgLdSqtcsAUxDkw6fzRH")
+	require.NoError(t, err)
+	require.Equal(t, "1", balance)
+}
+
+func TestPool_assess(t *testing.T) {
+	p := &Pool{cfg: newConfig()}
+
+	tests := []struct {
+		name    string
+		info    servertypes.Info
+		healthy bool
+	}{
+		{
+			name: "full and recent",
+			info: servertypes.Info{
+				ServerState:     "full",
+				ValidatedLedger: servertypes.ClosedLedger{Age: 3, Seq: 10},
+			},
+			healthy: true,
+		},
+		{
+			name: "syncing",
+			info: servertypes.Info{
+				ServerState:     "syncing",
+				ValidatedLedger: servertypes.ClosedLedger{Age: 3, Seq: 10},
+			},
+		},
+		{
+			name: "stale validated ledger",
+			info: servertypes.Info{
+				ServerState:     "proposing",
+				ValidatedLedger: servertypes.ClosedLedger{Age: 120, Seq: 10},
+			},
+		},
+		{
+			name: "no validated ledger",
+			info: servertypes.Info{ServerState: "full"},
+		},
+		{
+			name: "amendment blocked",
+			info: servertypes.Info{
+				ServerState:      "full",
+				AmendmentBlocked: true,
+				ValidatedLedger:  servertypes.ClosedLedger{Age: 3, Seq: 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.healthy, p.assess(&tt.info).Healthy)
+		})
+	}
+}
+
+func TestEndpoint_failed(t *testing.T) {
+	e := &endpoint{}
+	now := time.Now()
+
+	for _, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		e.failed(now, time.Second, 5*time.Second)
+		require.Equal(t, now.Add(want), e.penalizedUntil)
+	}
+
+	e.succeeded()
+	require.Zero(t, e.failures)
+	require.True(t, e.penalizedUntil.IsZero())
+}
+
+func TestRPCEndpoint_NoRetry(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	e, err := RPCEndpoint(srv.URL)
+	require.NoError(t, err)
+
+	// The pool fails over instead of waiting for the endpoint to retry.
+	_, err = e.Requester.RequestContext(context.Background(), &utility.PingRequest{})
+	require.Error(t, err)
+	require.EqualValues(t, 1, hits.Load())
+}
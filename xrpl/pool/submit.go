@@ -0,0 +1,153 @@
+package pool
+
+import (
+	"context"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/autofill"
+	"github.com/Peersyst/xrpl-go/xrpl/common"
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	"github.com/Peersyst/xrpl-go/xrpl/submission"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+)
+
+// autofiller returns the autofill engine configured with the current pool settings.
+// Its queries are routed like any other request.
+func (p *Pool) autofiller() *autofill.Engine {
+	return autofill.NewEngine(p, autofill.Config{
+		NetworkID:   p.NetworkID,
+		MaxFeeXRP:   p.cfg.maxFeeXRP,
+		FeeCushion:  p.cfg.feeCushion,
+		FeeStrategy: p.cfg.feeStrategy,
+		FeeReporter: p.cfg.feeReporter,
+		Sequences:   p.cfg.sequences,
+	})
+}
+
+// SubmitTxBlob sends a pre-signed transaction blob to the server.
+// It decodes the blob to confirm that it contains either a signature
+// or a signing public key, and then submits it using a submission request.
+// The failHard flag determines how strictly errors are handled.
+func (p *Pool) SubmitTxBlob(txBlob string, failHard bool) (*requests.SubmitResponse, error) {
+	return p.SubmitTxBlobContext(context.Background(), txBlob, failHard)
+}
+
+// SubmitTxBlobContext is like SubmitTxBlob but uses ctx to cancel the request or bound its duration.
+func (p *Pool) SubmitTxBlobContext(ctx context.Context, txBlob string, failHard bool) (*requests.SubmitResponse, error) {
+	return p.autofiller().SubmitTxBlob(ctx, txBlob, failHard)
+}
+
+// SubmitTxBlobAndWait sends a pre-signed transaction blob to the server,
+// decodes it to retrieve the required LastLedgerSequence, submits the blob,
+// and then waits until the transaction is confirmed in a ledger. It returns
+// the transaction response if the submission is successful.
+func (p *Pool) SubmitTxBlobAndWait(txBlob string, failHard bool) (*requests.TxResponse, error) {
+	return p.SubmitTxBlobAndWaitContext(context.Background(), txBlob, failHard)
+}
+
+// SubmitTxBlobAndWaitContext is like SubmitTxBlobAndWait but uses ctx to cancel the
+// submission and the wait for ledger confirmation.
+func (p *Pool) SubmitTxBlobAndWaitContext(ctx context.Context, txBlob string, failHard bool) (*requests.TxResponse, error) {
+	tx, err := binarycodec.Decode(txBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := tx["LastLedgerSequence"].(uint32); !ok {
+		return nil, ErrMissingLastLedgerSequenceInTransaction
+	}
+
+	rec, err := submission.NewManager(p, submission.Config{
+		PollInterval: p.cfg.retryDelay,
+		MaxChecks:    p.cfg.maxRetries,
+		FailHard:     failHard,
+	}).SubmitAndWait(ctx, txBlob)
+	if err != nil {
+		return nil, err
+	}
+	if err := rec.Result.Err(); err != nil {
+		return nil, err
+	}
+	return rec.Result.Tx, nil
+}
+
+// SubmitTx signs the transaction (if necessary) and submits it to the server
+// via a submission request. It applies the provided submit options to decide whether
+// to autofill missing fields and enforce failHard mode during submission.
+func (p *Pool) SubmitTx(tx transaction.FlatTransaction, opts *xrpl.SubmitOptions) (*requests.SubmitResponse, error) {
+	return p.SubmitTxContext(context.Background(), tx, opts)
+}
+
+// SubmitTxContext is like SubmitTx but uses ctx to cancel autofill and submission.
+func (p *Pool) SubmitTxContext(ctx context.Context, tx transaction.FlatTransaction, opts *xrpl.SubmitOptions) (*requests.SubmitResponse, error) {
+	return p.autofiller().SubmitTx(ctx, tx, opts)
+}
+
+// SubmitTxAndWait prepares a transaction by ensuring it is fully signed,
+// submits it to the server, and waits for ledger confirmation.
+// It validates that the transaction's EngineResult is successful before returning
+// the transaction response.
+func (p *Pool) SubmitTxAndWait(tx transaction.FlatTransaction, opts *xrpl.SubmitOptions) (*requests.TxResponse, error) {
+	return p.SubmitTxAndWaitContext(context.Background(), tx, opts)
+}
+
+// SubmitTxAndWaitContext is like SubmitTxAndWait but uses ctx to cancel autofill,
+// submission and the wait for ledger confirmation.
+func (p *Pool) SubmitTxAndWaitContext(ctx context.Context, tx transaction.FlatTransaction, opts *xrpl.SubmitOptions) (*requests.TxResponse, error) {
+	return p.autofiller().SubmitTxAndWait(ctx, tx, opts, p.SubmitTxBlobAndWaitContext)
+}
+
+func (p *Pool) SubmitMultisigned(txBlob string, failHard bool) (*requests.SubmitMultisignedResponse, error) {
+	return p.SubmitMultisignedContext(context.Background(), txBlob, failHard)
+}
+
+// SubmitMultisignedContext is like SubmitMultisigned but uses ctx to cancel the request or bound its duration.
+func (p *Pool) SubmitMultisignedContext(ctx context.Context, txBlob string, failHard bool) (*requests.SubmitMultisignedResponse, error) {
+	return p.autofiller().SubmitMultisigned(ctx, txBlob, failHard)
+}
+
+// Autofill fills in the missing fields in a transaction.
+func (p *Pool) Autofill(tx *transaction.FlatTransaction) error {
+	return p.AutofillContext(context.Background(), tx)
+}
+
+// AutofillContext is like Autofill but uses ctx to cancel the queries it issues.
+func (p *Pool) AutofillContext(ctx context.Context, tx *transaction.FlatTransaction) error {
+	return p.autofiller().Autofill(ctx, tx)
+}
+
+// AutofillMultisigned fills in the missing fields in a multisigned transaction.
+// This function is used to fill in the missing fields in a multisigned transaction.
+// It fills in the missing fields in the transaction and calculates the fee per number of signers.
+func (p *Pool) AutofillMultisigned(tx *transaction.FlatTransaction, nSigners uint64) error {
+	return p.AutofillMultisignedContext(context.Background(), tx, nSigners)
+}
+
+// AutofillMultisignedContext is like AutofillMultisigned but uses ctx to cancel the queries it issues.
+func (p *Pool) AutofillMultisignedContext(ctx context.Context, tx *transaction.FlatTransaction, nSigners uint64) error {
+	return p.autofiller().AutofillMultisigned(ctx, tx, nSigners)
+}
+
+// FaucetProvider returns the faucet provider of the pool.
+func (p *Pool) FaucetProvider() common.FaucetProvider {
+	return p.cfg.faucetProvider
+}
+
+// FundWallet funds a wallet with the pool's faucet provider.
+func (p *Pool) FundWallet(wallet *wallet.Wallet) error {
+	if wallet.ClassicAddress == "" {
+		return ErrCannotFundWalletWithoutClassicAddress
+	}
+	if p.cfg.faucetProvider == nil {
+		return ErrMissingFaucetProvider
+	}
+
+	err := p.cfg.faucetProvider.FundWallet(wallet.ClassicAddress)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
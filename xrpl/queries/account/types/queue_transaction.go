@@ -8,4 +8,5 @@ type QueueTransaction struct {
 	FeeLevel      types.XRPCurrencyAmount `json:"fee_level,omitempty"`
 	MaxSpendDrops types.XRPCurrencyAmount `json:"max_spend_drops,omitempty"`
 	Seq           int                     `json:"seq,omitempty"`
+	Ticket        int                     `json:"ticket,omitempty"`
 }
@@ -0,0 +1,52 @@
+package admin
+
+import (
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+// Special values of CanDeleteRequest.CanDelete.
+const (
+	CanDeleteNever  = "never"
+	CanDeleteAlways = "always"
+	CanDeleteNow    = "now"
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The can_delete method sets or returns the latest ledger the server may delete
+// with online deletion in advisory mode. CanDelete is a ledger index, a ledger hash
+// or one of CanDeleteNever, CanDeleteAlways and CanDeleteNow. If empty, the
+// current value is returned.
+type CanDeleteRequest struct {
+	common.BaseRequest
+	CanDelete string `json:"can_delete,omitempty"`
+}
+
+func (*CanDeleteRequest) Method() string {
+	return "can_delete"
+}
+
+func (*CanDeleteRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (*CanDeleteRequest) Validate() error {
+	return nil
+}
+
+// AdminOnly reports that can_delete is an admin method.
+func (*CanDeleteRequest) AdminOnly() bool {
+	return true
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the can_delete method.
+type CanDeleteResponse struct {
+	CanDelete common.LedgerIndex `json:"can_delete"`
+}
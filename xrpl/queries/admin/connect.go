@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"errors"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+var (
+	ErrNoConnectIP = errors.New("no ip specified")
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The connect method forces the server to connect to a specific peer. Port
+// defaults to 6561 if not set.
+type ConnectRequest struct {
+	common.BaseRequest
+	IP   string `json:"ip"`
+	Port uint16 `json:"port,omitempty"`
+}
+
+func (*ConnectRequest) Method() string {
+	return "connect"
+}
+
+func (*ConnectRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (r *ConnectRequest) Validate() error {
+	if r.IP == "" {
+		return ErrNoConnectIP
+	}
+	return nil
+}
+
+// AdminOnly reports that connect is an admin method.
+func (*ConnectRequest) AdminOnly() bool {
+	return true
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the connect method.
+type ConnectResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,43 @@
+package admin
+
+import (
+	admintypes "github.com/Peersyst/xrpl-go/xrpl/queries/admin/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The consensus_info method returns the state of the consensus process of the
+// server, for debugging.
+type ConsensusInfoRequest struct {
+	common.BaseRequest
+}
+
+func (*ConsensusInfoRequest) Method() string {
+	return "consensus_info"
+}
+
+func (*ConsensusInfoRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (*ConsensusInfoRequest) Validate() error {
+	return nil
+}
+
+// AdminOnly reports that consensus_info is an admin method.
+func (*ConsensusInfoRequest) AdminOnly() bool {
+	return true
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the consensus_info method.
+type ConsensusInfoResponse struct {
+	Info admintypes.ConsensusInfo `json:"info"`
+}
@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"testing"
+
+	admintypes "github.com/Peersyst/xrpl-go/xrpl/queries/admin/types"
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+)
+
+func TestConsensusInfoResponse(t *testing.T) {
+	s := ConsensusInfoResponse{
+		Info: admintypes.ConsensusInfo{
+			Consensus:         "no",
+			ConvergePercent:   0,
+			CurrentMS:         2004,
+			LedgerSeq:         13701086,
+			Phase:             "establish",
+			PreviousMSeconds:  2002,
+			PreviousProposers: 5,
+			Proposers:         5,
+			Proposing:         false,
+			Synched:           true,
+			Validating:        false,
+		},
+	}
+
+	j := `{
+	"info": {
+		"consensus": "no",
+		"current_ms": 2004,
+		"ledger_seq": 13701086,
+		"phase": "establish",
+		"previous_mseconds": 2002,
+		"previous_proposers": 5,
+		"proposers": 5,
+		"proposing": false,
+		"synched": true,
+		"validating": false
+	}
+}`
+
+	if err := testutil.SerializeAndDeserialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,54 @@
+package admin
+
+import (
+	"errors"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/server"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+var (
+	ErrNoFeature = errors.New("no feature specified")
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The feature method, with the vetoed parameter, sets how the server votes on an
+// amendment: Vetoed true votes against it, false in favor. Feature is the name or
+// the ID of the amendment.
+type FeatureVoteRequest struct {
+	common.BaseRequest
+	Feature string `json:"feature"`
+	Vetoed  bool   `json:"vetoed"`
+}
+
+func (*FeatureVoteRequest) Method() string {
+	return "feature"
+}
+
+func (*FeatureVoteRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (r *FeatureVoteRequest) Validate() error {
+	if r.Feature == "" {
+		return ErrNoFeature
+	}
+	return nil
+}
+
+// AdminOnly reports that voting on amendments is admin only.
+func (*FeatureVoteRequest) AdminOnly() bool {
+	return true
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the feature method: the status of the amendment,
+// keyed by its ID.
+type FeatureVoteResponse = server.FeatureResponse
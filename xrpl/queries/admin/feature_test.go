@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureVoteRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  FeatureVoteRequest
+		json string
+	}{
+		{
+			name: "veto",
+			req:  FeatureVoteRequest{Feature: "MultiSignReserve", Vetoed: true},
+			json: `{
+	"feature": "MultiSignReserve",
+	"vetoed": true
+}`,
+		},
+		{
+			name: "vote",
+			req:  FeatureVoteRequest{Feature: "MultiSignReserve"},
+			json: `{
+	"feature": "MultiSignReserve",
+	"vetoed": false
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testutil.Serialize(t, tt.req, tt.json); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	require.ErrorIs(t, (&FeatureVoteRequest{Vetoed: true}).Validate(), ErrNoFeature)
+}
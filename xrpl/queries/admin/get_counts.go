@@ -0,0 +1,44 @@
+package admin
+
+import (
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The get_counts method returns health information about the server, such as the
+// number of objects of each kind it holds in memory. MinCount hides the kinds with
+// fewer objects.
+type GetCountsRequest struct {
+	common.BaseRequest
+	MinCount uint32 `json:"min_count,omitempty"`
+}
+
+func (*GetCountsRequest) Method() string {
+	return "get_counts"
+}
+
+func (*GetCountsRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (*GetCountsRequest) Validate() error {
+	return nil
+}
+
+// AdminOnly reports that get_counts is an admin method.
+func (*GetCountsRequest) AdminOnly() bool {
+	return true
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the get_counts method. Its fields depend on the
+// server version and configuration, such as the object counts keyed by type,
+// "uptime" or the database sizes ("dbKBTotal", ...).
+type GetCountsResponse map[string]any
@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+)
+
+func TestGetCountsRequest(t *testing.T) {
+	s := GetCountsRequest{
+		MinCount: 100,
+	}
+
+	j := `{
+	"min_count": 100
+}`
+
+	if err := testutil.Serialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetCountsResponse(t *testing.T) {
+	s := GetCountsResponse{
+		"AL_hit_rate": float64(48.36725616455078),
+		"Ledger":      float64(46),
+		"dbKBTotal":   float64(1436),
+		"uptime":      "3 hours, 50 minutes, 27 seconds",
+	}
+
+	j := `{
+	"AL_hit_rate": 48.36725616455078,
+	"Ledger": 46,
+	"dbKBTotal": 1436,
+	"uptime": "3 hours, 50 minutes, 27 seconds"
+}`
+
+	if err := testutil.SerializeAndDeserialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
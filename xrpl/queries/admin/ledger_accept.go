@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The ledger_accept method forces the server to close the current working ledger
+// and move to the next ledger number. It is only available in stand-alone mode,
+// where no ledger is closed otherwise.
+type LedgerAcceptRequest struct {
+	common.BaseRequest
+}
+
+func (*LedgerAcceptRequest) Method() string {
+	return "ledger_accept"
+}
+
+func (*LedgerAcceptRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (*LedgerAcceptRequest) Validate() error {
+	return nil
+}
+
+// AdminOnly reports that ledger_accept is an admin method.
+func (*LedgerAcceptRequest) AdminOnly() bool {
+	return true
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the ledger_accept method.
+type LedgerAcceptResponse struct {
+	LedgerCurrentIndex common.LedgerIndex `json:"ledger_current_index"`
+}
@@ -0,0 +1,31 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedgerAcceptRequest(t *testing.T) {
+	s := LedgerAcceptRequest{}
+
+	if err := testutil.Serialize(t, s, `{}`); err != nil {
+		t.Error(err)
+	}
+	require.True(t, s.AdminOnly())
+}
+
+func TestLedgerAcceptResponse(t *testing.T) {
+	s := LedgerAcceptResponse{
+		LedgerCurrentIndex: 6643240,
+	}
+
+	j := `{
+	"ledger_current_index": 6643240
+}`
+
+	if err := testutil.SerializeAndDeserialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"errors"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+var (
+	ErrInvalidCleanerRange = errors.New("min_ledger must not be greater than max_ledger")
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The ledger_cleaner method controls the ledger cleaner, which checks and repairs
+// the ledgers of the server database. Ledger checks a single ledger, MinLedger and
+// MaxLedger a range of them.
+type LedgerCleanerRequest struct {
+	common.BaseRequest
+	Ledger     common.LedgerIndex `json:"ledger,omitempty"`
+	MaxLedger  common.LedgerIndex `json:"max_ledger,omitempty"`
+	MinLedger  common.LedgerIndex `json:"min_ledger,omitempty"`
+	Full       bool               `json:"full,omitempty"`
+	FixTxns    bool               `json:"fix_txns,omitempty"`
+	CheckNodes bool               `json:"check_nodes,omitempty"`
+	Stop       bool               `json:"stop,omitempty"`
+}
+
+func (*LedgerCleanerRequest) Method() string {
+	return "ledger_cleaner"
+}
+
+func (*LedgerCleanerRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (r *LedgerCleanerRequest) Validate() error {
+	if r.MinLedger != 0 && r.MaxLedger != 0 && r.MinLedger > r.MaxLedger {
+		return ErrInvalidCleanerRange
+	}
+	return nil
+}
+
+// AdminOnly reports that ledger_cleaner is an admin method.
+func (*LedgerCleanerRequest) AdminOnly() bool {
+	return true
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the ledger_cleaner method.
+type LedgerCleanerResponse struct {
+	Message string `json:"message"`
+}
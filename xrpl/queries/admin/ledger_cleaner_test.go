@@ -0,0 +1,42 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedgerCleanerRequest(t *testing.T) {
+	s := LedgerCleanerRequest{
+		MaxLedger:  13000,
+		MinLedger:  12000,
+		CheckNodes: true,
+	}
+
+	j := `{
+	"max_ledger": 13000,
+	"min_ledger": 12000,
+	"check_nodes": true
+}`
+
+	if err := testutil.Serialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+	require.NoError(t, s.Validate())
+	require.ErrorIs(t, (&LedgerCleanerRequest{MinLedger: 13000, MaxLedger: 12000}).Validate(), ErrInvalidCleanerRange)
+}
+
+func TestLedgerCleanerResponse(t *testing.T) {
+	s := LedgerCleanerResponse{
+		Message: "Cleaner configured",
+	}
+
+	j := `{
+	"message": "Cleaner configured"
+}`
+
+	if err := testutil.SerializeAndDeserialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"errors"
+
+	admintypes "github.com/Peersyst/xrpl-go/xrpl/queries/admin/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	ledgertypes "github.com/Peersyst/xrpl-go/xrpl/queries/ledger/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+var (
+	ErrNoLedgerRequested      = errors.New("either the ledger index or the ledger hash must be specified")
+	ErrLedgerIndexAndHashBoth = errors.New("the ledger index and the ledger hash cannot be both specified")
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The ledger_request method tells the server to fetch a ledger from its peers, by
+// index or by hash, if it doesn't have it already.
+type LedgerRequestRequest struct {
+	common.BaseRequest
+	LedgerHash  common.LedgerHash  `json:"ledger_hash,omitempty"`
+	LedgerIndex common.LedgerIndex `json:"ledger_index,omitempty"`
+}
+
+func (*LedgerRequestRequest) Method() string {
+	return "ledger_request"
+}
+
+func (*LedgerRequestRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (r *LedgerRequestRequest) Validate() error {
+	if r.LedgerHash == "" && r.LedgerIndex == 0 {
+		return ErrNoLedgerRequested
+	}
+	if r.LedgerHash != "" && r.LedgerIndex != 0 {
+		return ErrLedgerIndexAndHashBoth
+	}
+	return nil
+}
+
+// AdminOnly reports that ledger_request is an admin method.
+func (*LedgerRequestRequest) AdminOnly() bool {
+	return true
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the ledger_request method. Ledger is the header of the
+// ledger if the server has it, and Acquiring the progress of its download otherwise.
+type LedgerRequestResponse struct {
+	Acquiring   *admintypes.LedgerAcquisition `json:"acquiring,omitempty"`
+	Ledger      *ledgertypes.BaseLedger       `json:"ledger,omitempty"`
+	LedgerIndex common.LedgerIndex            `json:"ledger_index,omitempty"`
+}
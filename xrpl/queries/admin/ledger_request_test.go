@@ -0,0 +1,113 @@
+package admin
+
+import (
+	"testing"
+
+	admintypes "github.com/Peersyst/xrpl-go/xrpl/queries/admin/types"
+	ledgertypes "github.com/Peersyst/xrpl-go/xrpl/queries/ledger/types"
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedgerRequestRequest(t *testing.T) {
+	s := LedgerRequestRequest{
+		LedgerIndex: 13800000,
+	}
+
+	j := `{
+	"ledger_index": 13800000
+}`
+
+	if err := testutil.Serialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLedgerRequestRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  LedgerRequestRequest
+		err  error
+	}{
+		{name: "pass - index", req: LedgerRequestRequest{LedgerIndex: 13800000}},
+		{name: "pass - hash", req: LedgerRequestRequest{LedgerHash: "763F8F4E5A0F8E4B9F2C6AD1F5C4B6E0E7E0C5A2C1D0B2A39485766554433221"}},
+		{name: "fail - nothing", err: ErrNoLedgerRequested},
+		{
+			name: "fail - index and hash",
+			req:  LedgerRequestRequest{LedgerIndex: 1, LedgerHash: "763F8F4E5A0F8E4B9F2C6AD1F5C4B6E0E7E0C5A2C1D0B2A39485766554433221"},
+			err:  ErrLedgerIndexAndHashBoth,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.req.Validate(), tt.err)
+		})
+	}
+}
+
+func TestLedgerRequestResponse(t *testing.T) {
+	s := LedgerRequestResponse{
+		Ledger: &ledgertypes.BaseLedger{
+			AccountHash:         "4C1F2DA7F9A4E6D1AEF0A1F3B1C89E6F9A9B5F2E0C8D7A6B5C4D3E2F1A0B9C8D",
+			CloseFlags:          0,
+			CloseTime:           486000000,
+			CloseTimeHuman:      "2015-May-26 23:20:00.000000000 UTC",
+			CloseTimeResolution: 10,
+			Closed:              true,
+			LedgerHash:          "763F8F4E5A0F8E4B9F2C6AD1F5C4B6E0E7E0C5A2C1D0B2A39485766554433221",
+			LedgerIndex:         13800000,
+			ParentCloseTime:     485999990,
+			ParentHash:          "F8A2F4A8B7F5C6D5E4A3B2C1D0E9F8A7B6C5D4E3F2A1B0C9D8E7F6A5B4C3D2E1",
+			TotalCoins:          99999999973871,
+			TransactionHash:     "9B7C0A1D2E3F4A5B6C7D8E9F0A1B2C3D4E5F6A7B8C9D0E1F2A3B4C5D6E7F8A9B",
+		},
+		LedgerIndex: 13800000,
+	}
+
+	j := `{
+	"ledger": {
+		"account_hash": "4C1F2DA7F9A4E6D1AEF0A1F3B1C89E6F9A9B5F2E0C8D7A6B5C4D3E2F1A0B9C8D",
+		"close_flags": 0,
+		"close_time": 486000000,
+		"close_time_human": "2015-May-26 23:20:00.000000000 UTC",
+		"close_time_resolution": 10,
+		"closed": true,
+		"ledger_hash": "763F8F4E5A0F8E4B9F2C6AD1F5C4B6E0E7E0C5A2C1D0B2A39485766554433221",
+		"ledger_index": 13800000,
+		"parent_close_time": 485999990,
+		"parent_hash": "F8A2F4A8B7F5C6D5E4A3B2C1D0E9F8A7B6C5D4E3F2A1B0C9D8E7F6A5B4C3D2E1",
+		"total_coins": "99999999973871",
+		"transaction_hash": "9B7C0A1D2E3F4A5B6C7D8E9F0A1B2C3D4E5F6A7B8C9D0E1F2A3B4C5D6E7F8A9B"
+	},
+	"ledger_index": 13800000
+}`
+
+	if err := testutil.SerializeAndDeserialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLedgerRequestResponse_Acquiring(t *testing.T) {
+	s := LedgerRequestResponse{
+		Acquiring: &admintypes.LedgerAcquisition{
+			Hash:       "763F8F4E5A0F8E4B9F2C6AD1F5C4B6E0E7E0C5A2C1D0B2A39485766554433221",
+			HaveHeader: true,
+			Peers:      2,
+			Timeouts:   1,
+		},
+	}
+
+	j := `{
+	"acquiring": {
+		"hash": "763F8F4E5A0F8E4B9F2C6AD1F5C4B6E0E7E0C5A2C1D0B2A39485766554433221",
+		"have_header": true,
+		"peers": 2,
+		"timeouts": 1
+	}
+}`
+
+	if err := testutil.SerializeAndDeserialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
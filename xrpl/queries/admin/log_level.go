@@ -0,0 +1,66 @@
+package admin
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+var (
+	ErrInvalidSeverity     = errors.New("severity must be one of trace, debug, info, warning, error or fatal")
+	ErrPartitionNoSeverity = errors.New("a partition requires a severity")
+)
+
+// severities are the log levels accepted by the log_level method.
+var severities = []string{"trace", "debug", "info", "warning", "error", "fatal"}
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The log_level method changes the log verbosity of the server, or returns the
+// current one when no Severity is set. Partition restricts the change to one part
+// of the server, the default is all of them ("base").
+type LogLevelRequest struct {
+	common.BaseRequest
+	Severity  string `json:"severity,omitempty"`
+	Partition string `json:"partition,omitempty"`
+}
+
+func (*LogLevelRequest) Method() string {
+	return "log_level"
+}
+
+func (*LogLevelRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (r *LogLevelRequest) Validate() error {
+	if r.Severity == "" {
+		if r.Partition != "" {
+			return ErrPartitionNoSeverity
+		}
+		return nil
+	}
+	if !slices.Contains(severities, r.Severity) {
+		return ErrInvalidSeverity
+	}
+	return nil
+}
+
+// AdminOnly reports that log_level is an admin method.
+func (*LogLevelRequest) AdminOnly() bool {
+	return true
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the log_level method. Levels, keyed by partition, is
+// only returned when no Severity was set.
+type LogLevelResponse struct {
+	Levels map[string]string `json:"levels,omitempty"`
+}
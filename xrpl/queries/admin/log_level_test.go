@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLevelRequest(t *testing.T) {
+	s := LogLevelRequest{
+		Severity:  "debug",
+		Partition: "PathRequest",
+	}
+
+	j := `{
+	"severity": "debug",
+	"partition": "PathRequest"
+}`
+
+	if err := testutil.Serialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLogLevelRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  LogLevelRequest
+		err  error
+	}{
+		{name: "pass - get levels", req: LogLevelRequest{}},
+		{name: "pass - all partitions", req: LogLevelRequest{Severity: "warning"}},
+		{name: "pass - one partition", req: LogLevelRequest{Severity: "trace", Partition: "Peer"}},
+		{name: "fail - severity", req: LogLevelRequest{Severity: "verbose"}, err: ErrInvalidSeverity},
+		{name: "fail - partition without severity", req: LogLevelRequest{Partition: "Peer"}, err: ErrPartitionNoSeverity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.req.Validate(), tt.err)
+		})
+	}
+}
+
+func TestLogLevelResponse(t *testing.T) {
+	s := LogLevelResponse{
+		Levels: map[string]string{
+			"base":   "Warning",
+			"Ledger": "Info",
+		},
+	}
+
+	j := `{
+	"levels": {
+		"Ledger": "Info",
+		"base": "Warning"
+	}
+}`
+
+	if err := testutil.SerializeAndDeserialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
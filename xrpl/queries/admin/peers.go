@@ -0,0 +1,45 @@
+package admin
+
+import (
+	admintypes "github.com/Peersyst/xrpl-go/xrpl/queries/admin/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The peers method returns the servers connected to this one, and the members of
+// its cluster, if any.
+type PeersRequest struct {
+	common.BaseRequest
+}
+
+func (*PeersRequest) Method() string {
+	return "peers"
+}
+
+func (*PeersRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (*PeersRequest) Validate() error {
+	return nil
+}
+
+// AdminOnly reports that peers is an admin method.
+func (*PeersRequest) AdminOnly() bool {
+	return true
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the peers method. Cluster is keyed by the public key
+// of the cluster members.
+type PeersResponse struct {
+	Cluster map[string]admintypes.ClusterNode `json:"cluster,omitempty"`
+	Peers   []admintypes.Peer                 `json:"peers"`
+}
@@ -0,0 +1,66 @@
+package admin
+
+import (
+	"testing"
+
+	admintypes "github.com/Peersyst/xrpl-go/xrpl/queries/admin/types"
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+)
+
+func TestPeersResponse(t *testing.T) {
+	s := PeersResponse{
+		Cluster: map[string]admintypes.ClusterNode{
+			"n9KorY8QtTdRx7TVDpwnG9NvyxsDwHUKUEeDLY3AkiGncVaSXZi5": {Tag: "euro", Fee: 256, Age: 1},
+		},
+		Peers: []admintypes.Peer{
+			{
+				Address:         "64.78.81.112:51235",
+				CompleteLedgers: "32570 - 87466043",
+				Latency:         48,
+				Ledger:          "B25AA7B11A8B6A57D13B563DAC4B8D6C3D8B0E8D3C0E2D1B2C3D4E5F60718293",
+				Load:            22,
+				Metrics: admintypes.PeerMetrics{
+					AvgBpsRecv:     "5413",
+					AvgBpsSent:     "7268",
+					TotalBytesRecv: "17419440",
+					TotalBytesSent: "25395016",
+				},
+				PublicKey: "n9L3eR8uQHA5k4rHDbYQnwMn7GHs5HKYUnhoT79TdTxqYRkrZQsW",
+				Uptime:    3342,
+				Version:   "rippled-2.2.0",
+			},
+		},
+	}
+
+	j := `{
+	"cluster": {
+		"n9KorY8QtTdRx7TVDpwnG9NvyxsDwHUKUEeDLY3AkiGncVaSXZi5": {
+			"tag": "euro",
+			"fee": 256,
+			"age": 1
+		}
+	},
+	"peers": [
+		{
+			"address": "64.78.81.112:51235",
+			"complete_ledgers": "32570 - 87466043",
+			"latency": 48,
+			"ledger": "B25AA7B11A8B6A57D13B563DAC4B8D6C3D8B0E8D3C0E2D1B2C3D4E5F60718293",
+			"load": 22,
+			"metrics": {
+				"avg_bps_recv": "5413",
+				"avg_bps_sent": "7268",
+				"total_bytes_recv": "17419440",
+				"total_bytes_sent": "25395016"
+			},
+			"public_key": "n9L3eR8uQHA5k4rHDbYQnwMn7GHs5HKYUnhoT79TdTxqYRkrZQsW",
+			"uptime": 3342,
+			"version": "rippled-2.2.0"
+		}
+	]
+}`
+
+	if err := testutil.SerializeAndDeserialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The stop method gracefully shuts the server down.
+type StopRequest struct {
+	common.BaseRequest
+}
+
+func (*StopRequest) Method() string {
+	return "stop"
+}
+
+func (*StopRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (*StopRequest) Validate() error {
+	return nil
+}
+
+// AdminOnly reports that stop is an admin method.
+func (*StopRequest) AdminOnly() bool {
+	return true
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the stop method.
+type StopResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,21 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+)
+
+func TestStopResponse(t *testing.T) {
+	s := StopResponse{
+		Message: "ripple server stopping",
+	}
+
+	j := `{
+	"message": "ripple server stopping"
+}`
+
+	if err := testutil.SerializeAndDeserialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
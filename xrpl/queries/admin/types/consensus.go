@@ -0,0 +1,24 @@
+package types
+
+// ConsensusInfo is the state of the consensus process of a server. Its fields vary
+// with the phase of the process, OurPosition and PeerPositions are returned as is.
+type ConsensusInfo struct {
+	Acquired          map[string]any `json:"acquired,omitempty"`
+	CloseGranularity  uint32         `json:"close_granularity,omitempty"`
+	CloseTimes        map[string]any `json:"close_times,omitempty"`
+	Consensus         string         `json:"consensus,omitempty"`
+	ConvergePercent   uint32         `json:"converge_percent,omitempty"`
+	CurrentMS         uint32         `json:"current_ms,omitempty"`
+	Disputes          map[string]any `json:"disputes,omitempty"`
+	HaveTimeConsensus bool           `json:"have_time_consensus,omitempty"`
+	LedgerSeq         uint32         `json:"ledger_seq,omitempty"`
+	OurPosition       map[string]any `json:"our_position,omitempty"`
+	PeerPositions     map[string]any `json:"peer_positions,omitempty"`
+	Phase             string         `json:"phase,omitempty"`
+	PreviousMSeconds  uint32         `json:"previous_mseconds,omitempty"`
+	PreviousProposers uint32         `json:"previous_proposers,omitempty"`
+	Proposers         uint32         `json:"proposers,omitempty"`
+	Proposing         bool           `json:"proposing"`
+	Synched           bool           `json:"synched,omitempty"`
+	Validating        bool           `json:"validating"`
+}
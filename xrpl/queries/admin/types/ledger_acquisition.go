@@ -0,0 +1,14 @@
+package types
+
+// LedgerAcquisition is the progress of the download of a ledger requested with the
+// ledger_request method.
+type LedgerAcquisition struct {
+	Hash                    string   `json:"hash,omitempty"`
+	HaveHeader              bool     `json:"have_header"`
+	HaveState               bool     `json:"have_state,omitempty"`
+	HaveTransactions        bool     `json:"have_transactions,omitempty"`
+	NeededStateHashes       []string `json:"needed_state_hashes,omitempty"`
+	NeededTransactionHashes []string `json:"needed_transaction_hashes,omitempty"`
+	Peers                   uint32   `json:"peers"`
+	Timeouts                uint32   `json:"timeouts"`
+}
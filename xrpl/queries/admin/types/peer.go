@@ -0,0 +1,35 @@
+package types
+
+// Peer is a server connected to the one answering the peers method.
+type Peer struct {
+	Address         string      `json:"address"`
+	Cluster         bool        `json:"cluster,omitempty"`
+	Name            string      `json:"name,omitempty"`
+	CompleteLedgers string      `json:"complete_ledgers,omitempty"`
+	Inbound         bool        `json:"inbound,omitempty"`
+	Latency         uint32      `json:"latency,omitempty"`
+	Ledger          string      `json:"ledger,omitempty"`
+	Load            uint32      `json:"load,omitempty"`
+	Metrics         PeerMetrics `json:"metrics,omitempty"`
+	Protocol        string      `json:"protocol,omitempty"`
+	PublicKey       string      `json:"public_key,omitempty"`
+	Sanity          string      `json:"sanity,omitempty"`
+	Status          string      `json:"status,omitempty"`
+	Uptime          uint32      `json:"uptime"`
+	Version         string      `json:"version,omitempty"`
+}
+
+// PeerMetrics is the traffic of a peer, in bytes.
+type PeerMetrics struct {
+	AvgBpsRecv     string `json:"avg_bps_recv"`
+	AvgBpsSent     string `json:"avg_bps_sent"`
+	TotalBytesRecv string `json:"total_bytes_recv"`
+	TotalBytesSent string `json:"total_bytes_sent"`
+}
+
+// ClusterNode is a member of the cluster of the server answering the peers method.
+type ClusterNode struct {
+	Tag string `json:"tag,omitempty"`
+	Fee uint32 `json:"fee,omitempty"`
+	Age uint32 `json:"age,omitempty"`
+}
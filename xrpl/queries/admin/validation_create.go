@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The validation_create method generates the keys a rippled server can use to sign
+// validations, from a random seed or the given secret.
+type ValidationCreateRequest struct {
+	common.BaseRequest
+	Secret string `json:"secret,omitempty"`
+}
+
+func (*ValidationCreateRequest) Method() string {
+	return "validation_create"
+}
+
+func (*ValidationCreateRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (*ValidationCreateRequest) Validate() error {
+	return nil
+}
+
+// AdminOnly reports that validation_create is an admin method.
+func (*ValidationCreateRequest) AdminOnly() bool {
+	return true
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the validation_create method.
+type ValidationCreateResponse struct {
+	ValidationKey       string `json:"validation_key"`
+	ValidationPublicKey string `json:"validation_public_key"`
+	ValidationSeed      string `json:"validation_seed"`
+}
@@ -0,0 +1,39 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+)
+
+func TestValidationCreateRequest(t *testing.T) {
+	s := ValidationCreateRequest{
+		Secret: "BAWL MAN JADE MOON DOVE GEM SON NOW HAD ADEN GLOW TIRE",
+	}
+
+	j := `{
+	"secret": "BAWL MAN JADE MOON DOVE GEM SON NOW HAD ADEN GLOW TIRE"
+}`
+
+	if err := testutil.Serialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidationCreateResponse(t *testing.T) {
+	s := ValidationCreateResponse{
+		ValidationKey:       "BAWL MAN JADE MOON DOVE GEM SON NOW HAD ADEN GLOW TIRE",
+		ValidationPublicKey: "n9Mxf6qD4J55XeLSCEpqaePW4GjoCR5U1ZeGZGJUCNe3bQa4yQbG",
+		ValidationSeed:      "ssZkdwURFMBXenJPbrpE14b6noJSu",
+	}
+
+	j := `{
+	"validation_key": "BAWL MAN JADE MOON DOVE GEM SON NOW HAD ADEN GLOW TIRE",
+	"validation_public_key": "n9Mxf6qD4J55XeLSCEpqaePW4GjoCR5U1ZeGZGJUCNe3bQa4yQbG",
+	"validation_seed": "ssZkdwURFMBXenJPbrpE14b6noJSu"
+}`
+
+	if err := testutil.SerializeAndDeserialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
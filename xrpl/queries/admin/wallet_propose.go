@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"errors"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+var (
+	ErrMultipleWalletSeeds = errors.New("only one of passphrase, seed and seed_hex can be specified")
+	ErrInvalidKeyType      = errors.New("key type must be secp256k1 or ed25519")
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The wallet_propose method generates a key pair and XRP Ledger address, from a
+// random seed or the given passphrase or seed. The keys are only generated, no
+// account is created.
+type WalletProposeRequest struct {
+	common.BaseRequest
+	KeyType    string `json:"key_type,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+	Seed       string `json:"seed,omitempty"`
+	SeedHex    string `json:"seed_hex,omitempty"`
+}
+
+func (*WalletProposeRequest) Method() string {
+	return "wallet_propose"
+}
+
+func (*WalletProposeRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (r *WalletProposeRequest) Validate() error {
+	seeds := 0
+	for _, s := range []string{r.Passphrase, r.Seed, r.SeedHex} {
+		if s != "" {
+			seeds++
+		}
+	}
+	if seeds > 1 {
+		return ErrMultipleWalletSeeds
+	}
+	if r.KeyType != "" && r.KeyType != "secp256k1" && r.KeyType != "ed25519" {
+		return ErrInvalidKeyType
+	}
+	return nil
+}
+
+// AdminOnly reports that wallet_propose is an admin method.
+func (*WalletProposeRequest) AdminOnly() bool {
+	return true
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the wallet_propose method.
+type WalletProposeResponse struct {
+	AccountID     string `json:"account_id"`
+	KeyType       string `json:"key_type"`
+	MasterKey     string `json:"master_key"`
+	MasterSeed    string `json:"master_seed"`
+	MasterSeedHex string `json:"master_seed_hex"`
+	PublicKey     string `json:"public_key"`
+	PublicKeyHex  string `json:"public_key_hex"`
+	Warning       string `json:"warning,omitempty"`
+}
@@ -0,0 +1,75 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletProposeRequest(t *testing.T) {
+	s := WalletProposeRequest{
+		KeyType:    "secp256k1",
+		Passphrase: "masterpassphrase",
+	}
+
+	j := `{
+	"key_type": "secp256k1",
+	"passphrase": "masterpassphrase"
+}`
+
+	if err := testutil.Serialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWalletProposeRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  WalletProposeRequest
+		err  error
+	}{
+		{name: "pass - random", req: WalletProposeRequest{}},
+		{name: "pass - seed", req: WalletProposeRequest{KeyType: "ed25519", Seed: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"}},
+		{
+			name: "fail - passphrase and seed",
+			req:  WalletProposeRequest{Passphrase: "masterpassphrase", Seed: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"},
+			err:  ErrMultipleWalletSeeds,
+		},
+		{name: "fail - key type", req: WalletProposeRequest{KeyType: "rsa"}, err: ErrInvalidKeyType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.req.Validate(), tt.err)
+		})
+	}
+}
+
+func TestWalletProposeResponse(t *testing.T) {
+	s := WalletProposeResponse{
+		AccountID:     "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+		KeyType:       "secp256k1",
+		MasterKey:     "I IRE BOND BOW TRIO LAID SEAT GOAL HEN IBIS IBIS DARE",
+		MasterSeed:    "snoPBrXtMeMyMHUVTgbuqAfg1SUTb",
+		MasterSeedHex: "DEDCE9CE67B451D852FD4E846FCDE31C",
+		PublicKey:     "aBQG8RQAzjs1eTKFEAQXr2gS4utcDiEC9wmi7pfUPTi27VCahwgw",
+		PublicKeyHex:  "0330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020",
+		Warning:       "This wallet was generated using a user-supplied passphrase that has low entropy and is vulnerable to brute-force attacks.",
+	}
+
+	j := `{
+	"account_id": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+	"key_type": "secp256k1",
+	"master_key": "I IRE BOND BOW TRIO LAID SEAT GOAL HEN IBIS IBIS DARE",
+	"master_seed": "snoPBrXtMeMyMHUVTgbuqAfg1SUTb",
+	"master_seed_hex": "DEDCE9CE67B451D852FD4E846FCDE31C",
+	"public_key": "aBQG8RQAzjs1eTKFEAQXr2gS4utcDiEC9wmi7pfUPTi27VCahwgw",
+	"public_key_hex": "0330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020",
+	"warning": "This wallet was generated using a user-supplied passphrase that has low entropy and is vulnerable to brute-force attacks."
+}`
+
+	if err := testutil.SerializeAndDeserialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,77 @@
+package amm
+
+import (
+	"errors"
+
+	"github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	ammtypes "github.com/Peersyst/xrpl-go/xrpl/queries/amm/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+var (
+	ErrNoAMMSpecified      = errors.New("either the asset pair or the AMM account must be specified")
+	ErrAssetPairAndAMM     = errors.New("the asset pair and the AMM account cannot be both specified")
+	ErrIncompleteAssetPair = errors.New("both assets of the pair must be specified")
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The `amm_info` method retrieves information about an Automated Market Maker
+// (AMM) instance, identified either by the pair of assets in its pool or by
+// the address of its AMM account.
+type InfoRequest struct {
+	common.BaseRequest
+	// One of the assets of the AMM's pool. For XRP, set only the currency "XRP".
+	Asset *ledger.Asset `json:"asset,omitempty"`
+	// The other asset of the AMM's pool.
+	Asset2 *ledger.Asset `json:"asset2,omitempty"`
+	// The address of the AMM's special AccountRoot.
+	AMMAccount types.Address `json:"amm_account,omitempty"`
+	// Show only the LP tokens held by this liquidity provider.
+	Account     types.Address          `json:"account,omitempty"`
+	LedgerHash  common.LedgerHash      `json:"ledger_hash,omitempty"`
+	LedgerIndex common.LedgerSpecifier `json:"ledger_index,omitempty"`
+}
+
+func (*InfoRequest) Method() string {
+	return "amm_info"
+}
+
+func (*InfoRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+// Validate checks that the AMM is identified either by its asset pair or by its account.
+func (r *InfoRequest) Validate() error {
+	pair := r.Asset != nil || r.Asset2 != nil
+	switch {
+	case pair && r.AMMAccount != "":
+		return ErrAssetPairAndAMM
+	case pair && (r.Asset == nil || r.Asset2 == nil):
+		return ErrIncompleteAssetPair
+	case !pair && r.AMMAccount == "":
+		return ErrNoAMMSpecified
+	}
+	return nil
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the amm_info method.
+type InfoResponse struct {
+	// The snapshot of the AMM's pool.
+	AMM ammtypes.AMM `json:"amm"`
+	// The ledger index of the current in-progress ledger, which was used to
+	// retrieve this information.
+	LedgerCurrentIndex common.LedgerIndex `json:"ledger_current_index,omitempty"`
+	// The ledger index of the ledger version used to retrieve this information.
+	LedgerIndex common.LedgerIndex `json:"ledger_index,omitempty"`
+	// If true, the information comes from a validated ledger version.
+	Validated bool `json:"validated"`
+}
@@ -0,0 +1,109 @@
+package amm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	ammtypes "github.com/Peersyst/xrpl-go/xrpl/queries/amm/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfoRequest(t *testing.T) {
+	s := InfoRequest{
+		Asset:       &ledger.Asset{Currency: "XRP"},
+		Asset2:      &ledger.Asset{Currency: "TST", Issuer: "rP9jPyP5kyvFRb6ZiRghAGw5u8SGAmU4bd"},
+		LedgerIndex: common.Validated,
+	}
+	j := `{
+	"asset": {
+		"currency": "XRP"
+	},
+	"asset2": {
+		"currency": "TST",
+		"issuer": "rP9jPyP5kyvFRb6ZiRghAGw5u8SGAmU4bd"
+	},
+	"ledger_index": "validated"
+}`
+	if err := testutil.Serialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInfoRequest_Validate(t *testing.T) {
+	xrp := &ledger.Asset{Currency: "XRP"}
+	tst := &ledger.Asset{Currency: "TST", Issuer: "rP9jPyP5kyvFRb6ZiRghAGw5u8SGAmU4bd"}
+
+	tests := []struct {
+		name string
+		req  InfoRequest
+		err  error
+	}{
+		{name: "pass - asset pair", req: InfoRequest{Asset: xrp, Asset2: tst}},
+		{name: "pass - AMM account", req: InfoRequest{AMMAccount: "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM"}},
+		{name: "fail - nothing", err: ErrNoAMMSpecified},
+		{name: "fail - single asset", req: InfoRequest{Asset: xrp}, err: ErrIncompleteAssetPair},
+		{
+			name: "fail - asset pair and AMM account",
+			req:  InfoRequest{Asset: xrp, Asset2: tst, AMMAccount: "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM"},
+			err:  ErrAssetPairAndAMM,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.req.Validate(), tt.err)
+		})
+	}
+}
+
+func TestInfoResponse_UnmarshalJSON(t *testing.T) {
+	j := `{
+		"amm": {
+			"account": "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM",
+			"amount": "296890496",
+			"amount2": {"currency": "TST", "issuer": "rP9jPyP5kyvFRb6ZiRghAGw5u8SGAmU4bd", "value": "25.81656470648473"},
+			"asset2_frozen": true,
+			"auction_slot": {
+				"account": "rJVUeRqDFNs2xqA7ncVE6ZoAhPUoaJJSQm",
+				"auth_accounts": [{"account": "r3f2WpQMsAd8k4Zoijv2PZ8dDLw6JGhPtd"}],
+				"discounted_fee": 60,
+				"expiration": "2023-07-04T10:45:51+0000",
+				"price": {"currency": "039C99CD9AB0B70B32ECDA51EAAE471625608EA2", "issuer": "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM", "value": "0"},
+				"time_interval": 1
+			},
+			"lp_token": {"currency": "039C99CD9AB0B70B32ECDA51EAAE471625608EA2", "issuer": "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM", "value": "87533.41976112682"},
+			"trading_fee": 600,
+			"vote_slots": [{"account": "rJVUeRqDFNs2xqA7ncVE6ZoAhPUoaJJSQm", "trading_fee": 600, "vote_weight": 100000}]
+		},
+		"ledger_current_index": 316745,
+		"validated": false
+	}`
+
+	var res InfoResponse
+	require.NoError(t, json.Unmarshal([]byte(j), &res))
+
+	require.Equal(t, InfoResponse{
+		AMM: ammtypes.AMM{
+			Account:      "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM",
+			Amount:       types.XRPCurrencyAmount(296890496),
+			Amount2:      types.IssuedCurrencyAmount{Currency: "TST", Issuer: "rP9jPyP5kyvFRb6ZiRghAGw5u8SGAmU4bd", Value: "25.81656470648473"},
+			Asset2Frozen: true,
+			AuctionSlot: &ammtypes.AuctionSlot{
+				Account:       "rJVUeRqDFNs2xqA7ncVE6ZoAhPUoaJJSQm",
+				AuthAccounts:  []ammtypes.AuthAccount{{Account: "r3f2WpQMsAd8k4Zoijv2PZ8dDLw6JGhPtd"}},
+				DiscountedFee: 60,
+				Expiration:    "2023-07-04T10:45:51+0000",
+				Price:         types.IssuedCurrencyAmount{Currency: "039C99CD9AB0B70B32ECDA51EAAE471625608EA2", Issuer: "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM", Value: "0"},
+				TimeInterval:  1,
+			},
+			LPToken:    types.IssuedCurrencyAmount{Currency: "039C99CD9AB0B70B32ECDA51EAAE471625608EA2", Issuer: "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM", Value: "87533.41976112682"},
+			TradingFee: 600,
+			VoteSlots:  []ammtypes.VoteSlot{{Account: "rJVUeRqDFNs2xqA7ncVE6ZoAhPUoaJJSQm", TradingFee: 600, VoteWeight: 100000}},
+		},
+		LedgerCurrentIndex: 316745,
+	}, res)
+}
@@ -0,0 +1,107 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+// Layouts of the auction slot expiration returned by rippled.
+var expirationLayouts = []string{
+	"2006-01-02T15:04:05-0700",
+	time.RFC3339,
+	"2006-Jan-02 15:04:05.000000000 MST",
+}
+
+var (
+	ErrInvalidExpiration = errors.New("invalid auction slot expiration")
+)
+
+// AMM is a snapshot of an AMM pool, as returned by the amm_info method.
+type AMM struct {
+	// The address of the AMM account.
+	Account types.Address `json:"account"`
+	// The amount of the first asset in the pool.
+	Amount types.CurrencyAmount `json:"amount"`
+	// The amount of the second asset in the pool.
+	Amount2 types.CurrencyAmount `json:"amount2"`
+	// Whether the first asset is frozen. Omitted for XRP.
+	AssetFrozen bool `json:"asset_frozen,omitempty"`
+	// Whether the second asset is frozen. Omitted for XRP.
+	Asset2Frozen bool `json:"asset2_frozen,omitempty"`
+	// The current auction slot, if any.
+	AuctionSlot *AuctionSlot `json:"auction_slot,omitempty"`
+	// The total outstanding balance of liquidity provider tokens, or the balance held by
+	// the account of the request if it specified one.
+	LPToken types.IssuedCurrencyAmount `json:"lp_token"`
+	// The trading fee of the pool, in units of 1/100,000.
+	TradingFee uint16 `json:"trading_fee"`
+	// The current votes for the trading fee of the pool.
+	VoteSlots []VoteSlot `json:"vote_slots,omitempty"`
+}
+
+// UnmarshalJSON decodes the pool amounts into their XRP, issued or MPT currency amount.
+func (a *AMM) UnmarshalJSON(data []byte) error {
+	type alias AMM
+	var h struct {
+		alias
+		Amount  json.RawMessage `json:"amount"`
+		Amount2 json.RawMessage `json:"amount2"`
+	}
+	if err := json.Unmarshal(data, &h); err != nil {
+		return err
+	}
+	*a = AMM(h.alias)
+
+	var err error
+	if a.Amount, err = types.UnmarshalCurrencyAmount(h.Amount); err != nil {
+		return err
+	}
+	if a.Amount2, err = types.UnmarshalCurrencyAmount(h.Amount2); err != nil {
+		return err
+	}
+	return nil
+}
+
+// AuctionSlot is the auction slot of an AMM pool, whose holder trades at a discounted fee.
+type AuctionSlot struct {
+	// The current holder of the auction slot.
+	Account types.Address `json:"account"`
+	// Additional accounts that trade at the discounted fee.
+	AuthAccounts []AuthAccount `json:"auth_accounts,omitempty"`
+	// The trading fee of the holder and the authorized accounts, in units of 1/100,000.
+	DiscountedFee uint32 `json:"discounted_fee"`
+	// The time the auction slot expires, as an ISO 8601 timestamp.
+	Expiration string `json:"expiration"`
+	// The amount the holder paid for the auction slot, in LP tokens.
+	Price types.IssuedCurrencyAmount `json:"price"`
+	// The current 72-minute interval of the 24-hour auction slot, from 0 to 19.
+	TimeInterval uint32 `json:"time_interval"`
+}
+
+// ExpirationTime parses the expiration of the auction slot.
+func (s *AuctionSlot) ExpirationTime() (time.Time, error) {
+	for _, layout := range expirationLayouts {
+		if t, err := time.Parse(layout, s.Expiration); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, ErrInvalidExpiration
+}
+
+// AuthAccount is an account authorized to trade at the discounted fee of an auction slot.
+type AuthAccount struct {
+	Account types.Address `json:"account"`
+}
+
+// VoteSlot is a vote for the trading fee of an AMM pool.
+type VoteSlot struct {
+	// The account that cast the vote.
+	Account types.Address `json:"account"`
+	// The trading fee the account voted for, in units of 1/100,000.
+	TradingFee uint16 `json:"trading_fee"`
+	// The weight of the vote, proportional to the LP tokens held by the account, in units of 1/100,000.
+	VoteWeight uint32 `json:"vote_weight"`
+}
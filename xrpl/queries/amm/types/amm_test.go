@@ -0,0 +1,27 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuctionSlot_ExpirationTime(t *testing.T) {
+	want := time.Date(2023, 7, 4, 10, 45, 51, 0, time.UTC)
+
+	for _, expiration := range []string{
+		"2023-07-04T10:45:51+0000",
+		"2023-07-04T10:45:51Z",
+		"2023-Jul-04 10:45:51.000000000 UTC",
+	} {
+		t.Run(expiration, func(t *testing.T) {
+			got, err := (&AuctionSlot{Expiration: expiration}).ExpirationTime()
+			require.NoError(t, err)
+			require.True(t, want.Equal(got))
+		})
+	}
+
+	_, err := (&AuctionSlot{Expiration: "soon"}).ExpirationTime()
+	require.ErrorIs(t, err, ErrInvalidExpiration)
+}
@@ -0,0 +1,55 @@
+package clio
+
+import (
+	cliotypes "github.com/Peersyst/xrpl-go/xrpl/queries/clio/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The ledger method of Clio retrieves information about a ledger version. Unlike
+// rippled, Clio can also return the ledger entries the ledger version created,
+// modified or deleted, with Diff.
+type LedgerRequest struct {
+	common.BaseRequest
+	LedgerHash   common.LedgerHash      `json:"ledger_hash,omitempty"`
+	LedgerIndex  common.LedgerSpecifier `json:"ledger_index,omitempty"`
+	Transactions bool                   `json:"transactions,omitempty"`
+	Expand       bool                   `json:"expand,omitempty"`
+	OwnerFunds   bool                   `json:"owner_funds,omitempty"`
+	Binary       bool                   `json:"binary,omitempty"`
+	Diff         bool                   `json:"diff,omitempty"`
+}
+
+func (*LedgerRequest) Method() string {
+	return "ledger"
+}
+
+func (*LedgerRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (*LedgerRequest) Validate() error {
+	return nil
+}
+
+// ClioOnly reports that the request is only served by Clio servers, which is the case
+// when it asks for the ledger diff.
+func (r *LedgerRequest) ClioOnly() bool {
+	return r.Diff
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the ledger method of Clio.
+type LedgerResponse struct {
+	Ledger      cliotypes.Ledger   `json:"ledger"`
+	LedgerHash  common.LedgerHash  `json:"ledger_hash"`
+	LedgerIndex common.LedgerIndex `json:"ledger_index"`
+	Validated   bool               `json:"validated,omitempty"`
+}
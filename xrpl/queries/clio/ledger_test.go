@@ -0,0 +1,35 @@
+package clio
+
+import (
+	"testing"
+
+	cliotypes "github.com/Peersyst/xrpl-go/xrpl/queries/clio/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedgerRequest(t *testing.T) {
+	s := LedgerRequest{
+		LedgerIndex: common.Validated,
+		Diff:        true,
+	}
+
+	j := `{
+	"ledger_index": "validated",
+	"diff": true
+}`
+
+	if err := testutil.Serialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+	require.True(t, s.ClioOnly())
+	require.False(t, (&LedgerRequest{}).ClioOnly())
+}
+
+func TestLedgerDiff_Deleted(t *testing.T) {
+	require.True(t, cliotypes.LedgerDiff{ObjectID: "AB", Object: ""}.Deleted())
+	require.True(t, cliotypes.LedgerDiff{ObjectID: "AB"}.Deleted())
+	require.False(t, cliotypes.LedgerDiff{ObjectID: "AB", Object: "1100612200000000"}.Deleted())
+	require.False(t, cliotypes.LedgerDiff{ObjectID: "AB", Object: map[string]any{"LedgerEntryType": "AccountRoot"}}.Deleted())
+}
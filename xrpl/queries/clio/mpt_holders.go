@@ -0,0 +1,62 @@
+package clio
+
+import (
+	"errors"
+
+	cliotypes "github.com/Peersyst/xrpl-go/xrpl/queries/clio/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+var (
+	ErrNoMPTIssuanceID = errors.New("no MPTIssuanceID defined")
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The mpt_holders method returns the accounts holding a given MPT issuance,
+// with their balances.
+type MPTHoldersRequest struct {
+	common.BaseRequest
+	MPTIssuanceID string                 `json:"mpt_issuance_id"`
+	LedgerHash    common.LedgerHash      `json:"ledger_hash,omitempty"`
+	LedgerIndex   common.LedgerSpecifier `json:"ledger_index,omitempty"`
+	Marker        any                    `json:"marker,omitempty"`
+	Limit         int                    `json:"limit,omitempty"`
+}
+
+func (*MPTHoldersRequest) Method() string {
+	return "mpt_holders"
+}
+
+func (*MPTHoldersRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (r *MPTHoldersRequest) Validate() error {
+	if r.MPTIssuanceID == "" {
+		return ErrNoMPTIssuanceID
+	}
+	return nil
+}
+
+// ClioOnly reports that mpt_holders is only served by Clio servers.
+func (*MPTHoldersRequest) ClioOnly() bool {
+	return true
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the mpt_holders method.
+type MPTHoldersResponse struct {
+	MPTIssuanceID string                `json:"mpt_issuance_id"`
+	MPTokens      []cliotypes.MPTHolder `json:"mptokens"`
+	Limit         int                   `json:"limit,omitempty"`
+	Marker        any                   `json:"marker,omitempty"`
+	LedgerIndex   common.LedgerIndex    `json:"ledger_index"`
+	Validated     bool                  `json:"validated"`
+}
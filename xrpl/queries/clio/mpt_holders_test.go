@@ -0,0 +1,69 @@
+package clio
+
+import (
+	"testing"
+
+	cliotypes "github.com/Peersyst/xrpl-go/xrpl/queries/clio/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMPTHoldersRequest(t *testing.T) {
+	s := MPTHoldersRequest{
+		MPTIssuanceID: "000004C463C52827307480341125DA0577DEFC38405B0E3E",
+		LedgerIndex:   common.Validated,
+		Limit:         10,
+	}
+
+	j := `{
+	"mpt_issuance_id": "000004C463C52827307480341125DA0577DEFC38405B0E3E",
+	"ledger_index": "validated",
+	"limit": 10
+}`
+
+	if err := testutil.Serialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMPTHoldersRequest_Validate(t *testing.T) {
+	require.NoError(t, (&MPTHoldersRequest{MPTIssuanceID: "000004C463C52827307480341125DA0577DEFC38405B0E3E"}).Validate())
+	require.ErrorIs(t, (&MPTHoldersRequest{}).Validate(), ErrNoMPTIssuanceID)
+}
+
+func TestMPTHoldersResponse(t *testing.T) {
+	s := MPTHoldersResponse{
+		MPTIssuanceID: "000004C463C52827307480341125DA0577DEFC38405B0E3E",
+		MPTokens: []cliotypes.MPTHolder{
+			{
+				Account:      "rfyWeZWZbPS5bLAbxqZGkJCsYEtt8ByFzY",
+				Flags:        0,
+				MPTAmount:    "20",
+				MPTokenIndex: "36D91DEE5EFE4A93119A8B84C944A528F2B444329F3846E49FE921040DE17E65",
+			},
+		},
+		Limit:       50,
+		LedgerIndex: 83,
+		Validated:   true,
+	}
+
+	j := `{
+	"mpt_issuance_id": "000004C463C52827307480341125DA0577DEFC38405B0E3E",
+	"mptokens": [
+		{
+			"account": "rfyWeZWZbPS5bLAbxqZGkJCsYEtt8ByFzY",
+			"flags": 0,
+			"mpt_amount": "20",
+			"mptoken_index": "36D91DEE5EFE4A93119A8B84C944A528F2B444329F3846E49FE921040DE17E65"
+		}
+	],
+	"limit": 50,
+	"ledger_index": 83,
+	"validated": true
+}`
+
+	if err := testutil.SerializeAndDeserialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
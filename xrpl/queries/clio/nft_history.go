@@ -31,11 +31,18 @@ func (*NFTHistoryRequest) APIVersion() int {
 	return version.RippledAPIV2
 }
 
-// TODO: Implement V2
-func (*NFTHistoryRequest) Validate() error {
+func (r *NFTHistoryRequest) Validate() error {
+	if r.NFTokenID == "" {
+		return ErrNoNFTokenID
+	}
 	return nil
 }
 
+// ClioOnly reports that nft_history is only served by Clio servers.
+func (*NFTHistoryRequest) ClioOnly() bool {
+	return true
+}
+
 // ############################################################################
 // Response
 // ############################################################################
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNFTHistoryRequest(t *testing.T) {
@@ -31,3 +32,8 @@ func TestNFTHistoryRequest(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestNFTHistoryRequest_Validate(t *testing.T) {
+	require.NoError(t, (&NFTHistoryRequest{NFTokenID: "00080000B4F4AFC5FBCBD76873F18006173D2193467D3EE70000099B00000000"}).Validate())
+	require.ErrorIs(t, (&NFTHistoryRequest{}).Validate(), ErrNoNFTokenID)
+}
@@ -1,11 +1,17 @@
 package clio
 
 import (
+	"errors"
+
 	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
 	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
 	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
 )
 
+var (
+	ErrNoNFTokenID = errors.New("no NFTokenID defined")
+)
+
 // ############################################################################
 // Request
 // ############################################################################
@@ -26,10 +32,18 @@ func (*NFTInfoRequest) APIVersion() int {
 	return version.RippledAPIV2
 }
 
-func (*NFTInfoRequest) Validate() error {
+func (r *NFTInfoRequest) Validate() error {
+	if r.NFTokenID == "" {
+		return ErrNoNFTokenID
+	}
 	return nil
 }
 
+// ClioOnly reports that nft_info is only served by Clio servers.
+func (*NFTInfoRequest) ClioOnly() bool {
+	return true
+}
+
 // ############################################################################
 // Response
 // ############################################################################
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
 	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNFTInfoRequest(t *testing.T) {
@@ -49,3 +50,8 @@ func TestNFTInfoResponse(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestNFTInfoRequest_Validate(t *testing.T) {
+	require.NoError(t, (&NFTInfoRequest{NFTokenID: "00080000B4F4AFC5FBCBD76873F18006173D2193467D3EE70000099B00000000"}).Validate())
+	require.ErrorIs(t, (&NFTInfoRequest{}).Validate(), ErrNoNFTokenID)
+}
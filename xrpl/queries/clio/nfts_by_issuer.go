@@ -1,12 +1,18 @@
 package clio
 
 import (
+	"errors"
+
 	cliotypes "github.com/Peersyst/xrpl-go/xrpl/queries/clio/types"
 	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
 	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
 	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
 )
 
+var (
+	ErrNoIssuer = errors.New("no Issuer defined")
+)
+
 // ############################################################################
 // Request
 // ############################################################################
@@ -29,11 +35,18 @@ func (*NFTsByIssuerRequest) APIVersion() int {
 	return version.RippledAPIV2
 }
 
-// TODO: Implement V2
-func (*NFTsByIssuerRequest) Validate() error {
+func (r *NFTsByIssuerRequest) Validate() error {
+	if r.Issuer == "" {
+		return ErrNoIssuer
+	}
 	return nil
 }
 
+// ClioOnly reports that nfts_by_issuer is only served by Clio servers.
+func (*NFTsByIssuerRequest) ClioOnly() bool {
+	return true
+}
+
 // ############################################################################
 // Response
 // ############################################################################
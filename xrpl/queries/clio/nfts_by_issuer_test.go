@@ -5,6 +5,7 @@ import (
 
 	cliotypes "github.com/Peersyst/xrpl-go/xrpl/queries/clio/types"
 	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNFTsByIssuerRequest(t *testing.T) {
@@ -74,3 +75,8 @@ func TestNFTsByIssuerResponse(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestNFTsByIssuerRequest_Validate(t *testing.T) {
+	require.NoError(t, (&NFTsByIssuerRequest{Issuer: "rHVokeuSnjPjz718qdb47bGXBBHNMP3KDQ"}).Validate())
+	require.ErrorIs(t, (&NFTsByIssuerRequest{}).Validate(), ErrNoIssuer)
+}
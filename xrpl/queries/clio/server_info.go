@@ -0,0 +1,46 @@
+package clio
+
+import (
+	cliotypes "github.com/Peersyst/xrpl-go/xrpl/queries/clio/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The server_info method of Clio retrieves the status of a Clio server: its
+// version, ledger cache, ETL sources and, with Counters, its request counters.
+// Sent to rippled, it returns the rippled status, without the Clio fields.
+type ServerInfoRequest struct {
+	common.BaseRequest
+	Counters bool `json:"counters,omitempty"`
+}
+
+func (*ServerInfoRequest) Method() string {
+	return "server_info"
+}
+
+func (*ServerInfoRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (*ServerInfoRequest) Validate() error {
+	return nil
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the server_info method of Clio.
+type ServerInfoResponse struct {
+	Info      cliotypes.ServerInfo `json:"info"`
+	Validated bool                 `json:"validated,omitempty"`
+}
+
+// IsClio reports whether the response comes from a Clio server.
+func (r *ServerInfoResponse) IsClio() bool {
+	return r.Info.ClioVersion != ""
+}
@@ -0,0 +1,44 @@
+package clio
+
+import (
+	"testing"
+
+	cliotypes "github.com/Peersyst/xrpl-go/xrpl/queries/clio/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerInfoResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		info       cliotypes.ServerInfo
+		clio       bool
+		forwarding bool
+	}{
+		{name: "rippled", info: cliotypes.ServerInfo{CompleteLedgers: "1-100"}},
+		{
+			name: "clio without sources",
+			info: cliotypes.ServerInfo{ClioVersion: "2.3.0", ETL: &cliotypes.ETL{}},
+			clio: true,
+		},
+		{
+			name: "clio forwarding",
+			info: cliotypes.ServerInfo{
+				ClioVersion: "2.3.0",
+				ETL: &cliotypes.ETL{ETLSources: []cliotypes.ETLSource{
+					{IP: "10.0.0.1", IsConnected: "false"},
+					{IP: "10.0.0.2", IsConnected: "true"},
+				}},
+			},
+			clio:       true,
+			forwarding: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := ServerInfoResponse{Info: tt.info}
+			require.Equal(t, tt.clio, res.IsClio())
+			require.Equal(t, tt.forwarding, res.Info.Forwarding())
+		})
+	}
+}
@@ -23,4 +23,19 @@ type Ledger struct {
 	TotalCoins          types.XRPCurrencyAmount       `json:"total_coins"`
 	TransactionHash     string                        `json:"transaction_hash"`
 	Transactions        []transaction.FlatTransaction `json:"transactions,omitempty"`
+	Diff                []LedgerDiff                  `json:"diff,omitempty"`
+}
+
+// LedgerDiff is a ledger entry created, modified or deleted by a ledger version, as
+// returned by the ledger method of Clio when asked for the diff.
+type LedgerDiff struct {
+	ObjectID string `json:"object_id"`
+	// Object is the entry after the ledger version, as a JSON object, or as a hex string
+	// with binary set. It is empty if the entry was deleted.
+	Object any `json:"object"`
+}
+
+// Deleted reports whether the ledger version deleted the entry.
+func (d LedgerDiff) Deleted() bool {
+	return d.Object == nil || d.Object == ""
 }
@@ -0,0 +1,12 @@
+package types
+
+import "github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+
+// MPTHolder is an account holding an MPT, as returned by the mpt_holders method.
+type MPTHolder struct {
+	Account      types.Address `json:"account"`
+	Flags        uint32        `json:"flags"`
+	MPTAmount    string        `json:"mpt_amount"`
+	LockedAmount string        `json:"locked_amount,omitempty"`
+	MPTokenIndex string        `json:"mptoken_index"`
+}
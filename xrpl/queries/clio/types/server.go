@@ -2,6 +2,36 @@ package types
 
 import "github.com/Peersyst/xrpl-go/xrpl/transaction/types"
 
+// ServerInfo is the status of a Clio server, as returned by its server_info method.
+type ServerInfo struct {
+	CompleteLedgers  string      `json:"complete_ledgers"`
+	Counters         *Counters   `json:"counters,omitempty"`
+	LoadFactor       int         `json:"load_factor"`
+	ClioVersion      string      `json:"clio_version"`
+	LibXRPLVersion   string      `json:"libxrpl_version,omitempty"`
+	RippledVersion   string      `json:"rippled_version,omitempty"`
+	ValidationQuorum int         `json:"validation_quorum"`
+	NetworkID        uint32      `json:"network_id,omitempty"`
+	AmendmentBlocked bool        `json:"amendment_blocked,omitempty"`
+	ValidatedLedger  *LedgerInfo `json:"validated_ledger,omitempty"`
+	Cache            *Cache      `json:"cache,omitempty"`
+	ETL              *ETL        `json:"etl,omitempty"`
+}
+
+// Forwarding reports whether the server is connected to at least one rippled ETL
+// source, to which it forwards the requests it cannot answer itself.
+func (i *ServerInfo) Forwarding() bool {
+	if i.ETL == nil {
+		return false
+	}
+	for _, src := range i.ETL.ETLSources {
+		if src.IsConnected == "true" {
+			return true
+		}
+	}
+	return false
+}
+
 type Counters struct {
 	RPC           map[string]RPC `json:"rpc"`
 	Subscriptions Subscriptions  `json:"subscriptions"`
@@ -36,6 +36,11 @@ func (*NFTHistoryRequest) Validate() error {
 	return nil
 }
 
+// ClioOnly reports that nft_history is only served by Clio servers.
+func (*NFTHistoryRequest) ClioOnly() bool {
+	return true
+}
+
 // ############################################################################
 // Response
 // ############################################################################
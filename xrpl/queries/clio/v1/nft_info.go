@@ -31,6 +31,11 @@ func (*NFTInfoRequest) Validate() error {
 	return nil
 }
 
+// ClioOnly reports that nft_info is only served by Clio servers.
+func (*NFTInfoRequest) ClioOnly() bool {
+	return true
+}
+
 // ############################################################################
 // Response
 // ############################################################################
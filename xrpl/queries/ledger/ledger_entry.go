@@ -0,0 +1,153 @@
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	ledgertypes "github.com/Peersyst/xrpl-go/xrpl/queries/ledger/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+var (
+	ErrNoEntrySelector        = errors.New("no ledger entry selector specified")
+	ErrMultipleEntrySelectors = errors.New("only one ledger entry selector can be specified")
+	ErrNoBridgeAccount        = errors.New("bridge selector requires a bridge account")
+	ErrNoEntryNode            = errors.New("ledger entry response has no node")
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The ledger_entry method returns a single ledger entry from the XRP Ledger in
+// its raw format. Exactly one selector must be set: either the ID of the entry
+// in Index, or the fields identifying an entry of a given type.
+type EntryRequest struct {
+	common.BaseRequest
+	LedgerHash  common.LedgerHash      `json:"ledger_hash,omitempty"`
+	LedgerIndex common.LedgerSpecifier `json:"ledger_index,omitempty"`
+	// If true, the entry is returned hex-encoded in the NodeBinary field of the response.
+	Binary bool `json:"binary,omitempty"`
+
+	Index                           types.Hash256                                        `json:"index,omitempty"`
+	AccountRoot                     types.Address                                        `json:"account_root,omitempty"`
+	AMM                             *ledgertypes.AMMSelector                             `json:"amm,omitempty"`
+	Bridge                          *ledgertypes.XChainBridge                            `json:"bridge,omitempty"`
+	BridgeAccount                   types.Address                                        `json:"bridge_account,omitempty"`
+	Check                           types.Hash256                                        `json:"check,omitempty"`
+	Credential                      *ledgertypes.CredentialSelector                      `json:"credential,omitempty"`
+	DepositPreauth                  *ledgertypes.DepositPreauthSelector                  `json:"deposit_preauth,omitempty"`
+	DID                             types.Address                                        `json:"did,omitempty"`
+	Directory                       *ledgertypes.DirectorySelector                       `json:"directory,omitempty"`
+	Escrow                          *ledgertypes.EscrowSelector                          `json:"escrow,omitempty"`
+	MPTIssuance                     string                                               `json:"mpt_issuance,omitempty"`
+	MPToken                         *ledgertypes.MPTokenSelector                         `json:"mptoken,omitempty"`
+	NFTPage                         types.Hash256                                        `json:"nft_page,omitempty"`
+	Offer                           *ledgertypes.OfferSelector                           `json:"offer,omitempty"`
+	Oracle                          *ledgertypes.OracleSelector                          `json:"oracle,omitempty"`
+	PaymentChannel                  types.Hash256                                        `json:"payment_channel,omitempty"`
+	PermissionedDomain              *ledgertypes.PermissionedDomainSelector              `json:"permissioned_domain,omitempty"`
+	RippleState                     *ledgertypes.RippleStateSelector                     `json:"ripple_state,omitempty"`
+	Ticket                          *ledgertypes.TicketSelector                          `json:"ticket,omitempty"`
+	XChainOwnedClaimID              *ledgertypes.XChainOwnedClaimIDSelector              `json:"xchain_owned_claim_id,omitempty"`
+	XChainOwnedCreateAccountClaimID *ledgertypes.XChainOwnedCreateAccountClaimIDSelector `json:"xchain_owned_create_account_claim_id,omitempty"`
+}
+
+func (*EntryRequest) Method() string {
+	return "ledger_entry"
+}
+
+func (*EntryRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+// Validate checks that exactly one selector is set.
+func (r *EntryRequest) Validate() error {
+	if r.Bridge != nil && r.BridgeAccount == "" {
+		return ErrNoBridgeAccount
+	}
+
+	selectors := 0
+	for _, set := range []bool{
+		r.Index != "",
+		r.AccountRoot != "",
+		r.AMM != nil,
+		r.Bridge != nil,
+		r.Check != "",
+		r.Credential != nil,
+		r.DepositPreauth != nil,
+		r.DID != "",
+		r.Directory != nil,
+		r.Escrow != nil,
+		r.MPTIssuance != "",
+		r.MPToken != nil,
+		r.NFTPage != "",
+		r.Offer != nil,
+		r.Oracle != nil,
+		r.PaymentChannel != "",
+		r.PermissionedDomain != nil,
+		r.RippleState != nil,
+		r.Ticket != nil,
+		r.XChainOwnedClaimID != nil,
+		r.XChainOwnedCreateAccountClaimID != nil,
+	} {
+		if set {
+			selectors++
+		}
+	}
+
+	switch {
+	case selectors == 0:
+		return ErrNoEntrySelector
+	case selectors > 1:
+		return ErrMultipleEntrySelectors
+	}
+	return nil
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the ledger_entry method. Node holds the entry
+// in JSON, or NodeBinary hex-encoded if the request set Binary.
+type EntryResponse struct {
+	Index              string                  `json:"index"`
+	LedgerHash         common.LedgerHash       `json:"ledger_hash,omitempty"`
+	LedgerIndex        common.LedgerIndex      `json:"ledger_index,omitempty"`
+	LedgerCurrentIndex common.LedgerIndex      `json:"ledger_current_index,omitempty"`
+	Node               ledger.FlatLedgerObject `json:"node,omitempty"`
+	NodeBinary         string                  `json:"node_binary,omitempty"`
+	Validated          bool                    `json:"validated"`
+}
+
+// Object decodes the entry into its typed ledger object, e.g. a *ledger.AccountRoot.
+// Binary entries are decoded first.
+func (r *EntryResponse) Object() (ledger.Object, error) {
+	node := map[string]any(r.Node)
+	if r.NodeBinary != "" {
+		decoded, err := binarycodec.Decode(r.NodeBinary)
+		if err != nil {
+			return nil, err
+		}
+		node = decoded
+	}
+	if node == nil {
+		return nil, ErrNoEntryNode
+	}
+
+	// Binary entries don't carry their ID.
+	if _, ok := node["index"]; !ok && r.Index != "" {
+		node["index"] = r.Index
+	}
+
+	b, err := json.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	return ledger.UnmarshalLedgerObject(b)
+}
@@ -0,0 +1,228 @@
+package ledger
+
+import (
+	"testing"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	ledgertypes "github.com/Peersyst/xrpl-go/xrpl/queries/ledger/types"
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedgerEntryRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      EntryRequest
+		expected string
+	}{
+		{
+			name: "index",
+			req: EntryRequest{
+				Index:       "13F1A95D7AAB7108D5CE7EEAF504B2894B8C674E6D68499076441C4837282BF8",
+				LedgerIndex: common.Validated,
+				Binary:      true,
+			},
+			expected: `{
+	"ledger_index": "validated",
+	"binary": true,
+	"index": "13F1A95D7AAB7108D5CE7EEAF504B2894B8C674E6D68499076441C4837282BF8"
+}`,
+		},
+		{
+			name: "amm",
+			req: EntryRequest{
+				AMM: &ledgertypes.AMMSelector{
+					Asset:  ledger.Asset{Currency: "XRP"},
+					Asset2: ledger.Asset{Currency: "TST", Issuer: "rP9jPyP5kyvFRb6ZiRghAGw5u8SGAmU4bd"},
+				},
+			},
+			expected: `{
+	"amm": {
+		"asset": {
+			"currency": "XRP"
+		},
+		"asset2": {
+			"currency": "TST",
+			"issuer": "rP9jPyP5kyvFRb6ZiRghAGw5u8SGAmU4bd"
+		}
+	}
+}`,
+		},
+		{
+			name: "deposit_preauth with credentials",
+			req: EntryRequest{
+				DepositPreauth: &ledgertypes.DepositPreauthSelector{
+					Owner: "rsUiUMpnrgxQp24dJYZDhmV4bE3aBtQyt8",
+					AuthorizedCredentials: []ledgertypes.AuthorizedCredential{
+						{Issuer: "ra5nK24KXen9AHvsdFTKHSANinZseWnPcX", CredentialType: "6D795F63726564656E7469616C"},
+					},
+				},
+			},
+			expected: `{
+	"deposit_preauth": {
+		"owner": "rsUiUMpnrgxQp24dJYZDhmV4bE3aBtQyt8",
+		"authorized_credentials": [
+			{
+				"issuer": "ra5nK24KXen9AHvsdFTKHSANinZseWnPcX",
+				"credential_type": "6D795F63726564656E7469616C"
+			}
+		]
+	}
+}`,
+		},
+		{
+			name: "ripple_state",
+			req: EntryRequest{
+				RippleState: &ledgertypes.RippleStateSelector{
+					Accounts: [2]types.Address{"rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn", "rrrrrrrrrrrrrrrrrrrrBZbvji"},
+					Currency: "USD",
+				},
+			},
+			expected: `{
+	"ripple_state": {
+		"accounts": [
+			"rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn",
+			"rrrrrrrrrrrrrrrrrrrrBZbvji"
+		],
+		"currency": "USD"
+	}
+}`,
+		},
+		{
+			name: "mptoken",
+			req: EntryRequest{
+				MPToken: &ledgertypes.MPTokenSelector{
+					MPTIssuanceID: "000004C463C52827307480341125DA0577DEFC38405B0E3E",
+					Account:       "rajgkBmMxmz161r8bWYH7CQAFZP5bA9oSG",
+				},
+			},
+			expected: `{
+	"mptoken": {
+		"mpt_issuance_id": "000004C463C52827307480341125DA0577DEFC38405B0E3E",
+		"account": "rajgkBmMxmz161r8bWYH7CQAFZP5bA9oSG"
+	}
+}`,
+		},
+		{
+			name: "xchain_owned_claim_id",
+			req: EntryRequest{
+				XChainOwnedClaimID: &ledgertypes.XChainOwnedClaimIDSelector{
+					XChainBridge: ledgertypes.XChainBridge{
+						IssuingChainDoor:  "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+						IssuingChainIssue: ledger.Asset{Currency: "XRP"},
+						LockingChainDoor:  "rMAXACCrp3Y8PpswXcg3bKggHX76V3F8M4",
+						LockingChainIssue: ledger.Asset{Currency: "XRP"},
+					},
+					XChainOwnedClaimID: 4,
+				},
+			},
+			expected: `{
+	"xchain_owned_claim_id": {
+		"IssuingChainDoor": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+		"IssuingChainIssue": {
+			"currency": "XRP"
+		},
+		"LockingChainDoor": "rMAXACCrp3Y8PpswXcg3bKggHX76V3F8M4",
+		"LockingChainIssue": {
+			"currency": "XRP"
+		},
+		"xchain_owned_claim_id": 4
+	}
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testutil.Serialize(t, tt.req, tt.expected); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestLedgerEntryRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  EntryRequest
+		err  error
+	}{
+		{
+			name: "pass - single selector",
+			req:  EntryRequest{Offer: &ledgertypes.OfferSelector{Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn", Seq: 5}},
+		},
+		{
+			name: "pass - bridge with bridge account",
+			req:  EntryRequest{Bridge: &ledgertypes.XChainBridge{}, BridgeAccount: "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"},
+		},
+		{
+			name: "fail - no selector",
+			req:  EntryRequest{LedgerIndex: common.Validated},
+			err:  ErrNoEntrySelector,
+		},
+		{
+			name: "fail - several selectors",
+			req:  EntryRequest{AccountRoot: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn", Check: "49647F0D748DC3FE26BDACBC57F251AADEFFF391403EC9BF87C97F67E9977FB0"},
+			err:  ErrMultipleEntrySelectors,
+		},
+		{
+			name: "fail - bridge without bridge account",
+			req:  EntryRequest{Bridge: &ledgertypes.XChainBridge{}},
+			err:  ErrNoBridgeAccount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.req.Validate(), tt.err)
+		})
+	}
+}
+
+func TestLedgerEntryResponse_Object(t *testing.T) {
+	accountRoot := map[string]any{
+		"LedgerEntryType":   "AccountRoot",
+		"Account":           "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn",
+		"Balance":           "1000000",
+		"Flags":             uint32(0),
+		"OwnerCount":        uint32(0),
+		"PreviousTxnID":     "E3FE6EA3D48F0C2B639448020EA4F03D4F4F8FFDB243A852A0F59177921B4879",
+		"PreviousTxnLgrSeq": uint32(6),
+		"Sequence":          uint32(5),
+	}
+	blob, err := binarycodec.Encode(accountRoot)
+	require.NoError(t, err)
+
+	index := "13F1A95D7AAB7108D5CE7EEAF504B2894B8C674E6D68499076441C4837282BF8"
+
+	t.Run("json", func(t *testing.T) {
+		res := EntryResponse{Index: index, Node: ledger.FlatLedgerObject(accountRoot)}
+		obj, err := res.Object()
+		require.NoError(t, err)
+
+		root, ok := obj.(*ledger.AccountRoot)
+		require.True(t, ok)
+		require.Equal(t, types.Address("rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn"), root.Account)
+		require.Equal(t, uint32(5), root.Sequence)
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		res := EntryResponse{Index: index, NodeBinary: blob}
+		obj, err := res.Object()
+		require.NoError(t, err)
+
+		root, ok := obj.(*ledger.AccountRoot)
+		require.True(t, ok)
+		require.Equal(t, types.Hash256(index), root.Index)
+		require.Equal(t, types.XRPCurrencyAmount(1000000), root.Balance)
+		require.Equal(t, uint32(6), root.PreviousTxnLgrSeq)
+	})
+
+	t.Run("no node", func(t *testing.T) {
+		_, err := (&EntryResponse{Index: index}).Object()
+		require.ErrorIs(t, err, ErrNoEntryNode)
+	})
+}
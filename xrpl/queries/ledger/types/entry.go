@@ -0,0 +1,110 @@
+package types
+
+import (
+	"github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+// The selectors below identify a ledger entry by the fields it is keyed on. Every entry can
+// also be retrieved by its ID, with the index field of the ledger_entry request.
+
+// AMMSelector identifies an AMM entry by the two assets in its pool.
+type AMMSelector struct {
+	Asset  ledger.Asset `json:"asset"`
+	Asset2 ledger.Asset `json:"asset2"`
+}
+
+// CredentialSelector identifies a Credential entry.
+type CredentialSelector struct {
+	Subject        types.Address        `json:"subject"`
+	Issuer         types.Address        `json:"issuer"`
+	CredentialType types.CredentialType `json:"credential_type"`
+}
+
+// AuthorizedCredential is a credential accepted by a credential-based DepositPreauth entry.
+type AuthorizedCredential struct {
+	Issuer         types.Address        `json:"issuer"`
+	CredentialType types.CredentialType `json:"credential_type"`
+}
+
+// DepositPreauthSelector identifies a DepositPreauth entry, which preauthorizes either an
+// account (Authorized) or a set of credentials (AuthorizedCredentials).
+type DepositPreauthSelector struct {
+	Owner                 types.Address          `json:"owner"`
+	Authorized            types.Address          `json:"authorized,omitempty"`
+	AuthorizedCredentials []AuthorizedCredential `json:"authorized_credentials,omitempty"`
+}
+
+// DirectorySelector identifies a DirectoryNode entry by the owner of the directory, or by
+// the ID of its first page, and the index of the page.
+type DirectorySelector struct {
+	Owner    types.Address `json:"owner,omitempty"`
+	DirRoot  types.Hash256 `json:"dir_root,omitempty"`
+	SubIndex uint64        `json:"sub_index,omitempty"`
+}
+
+// EscrowSelector identifies an Escrow entry by its owner and the sequence number of the
+// transaction that created it.
+type EscrowSelector struct {
+	Owner types.Address `json:"owner"`
+	Seq   uint32        `json:"seq"`
+}
+
+// MPTokenSelector identifies the MPToken entry of an account for an MPT issuance.
+type MPTokenSelector struct {
+	MPTIssuanceID string        `json:"mpt_issuance_id"`
+	Account       types.Address `json:"account"`
+}
+
+// OfferSelector identifies an Offer entry by its owner and the sequence number of the
+// transaction that created it.
+type OfferSelector struct {
+	Account types.Address `json:"account"`
+	Seq     uint32        `json:"seq"`
+}
+
+// OracleSelector identifies an Oracle entry by its owner and document ID.
+type OracleSelector struct {
+	Account          types.Address `json:"account"`
+	OracleDocumentID uint32        `json:"oracle_document_id"`
+}
+
+// PermissionedDomainSelector identifies a PermissionedDomain entry by its owner and the
+// sequence number of the transaction that created it.
+type PermissionedDomainSelector struct {
+	Account types.Address `json:"account"`
+	Seq     uint32        `json:"seq"`
+}
+
+// RippleStateSelector identifies the trust line between two accounts for a currency.
+type RippleStateSelector struct {
+	Accounts [2]types.Address `json:"accounts"`
+	Currency string           `json:"currency"`
+}
+
+// TicketSelector identifies a Ticket entry by its owner and the sequence number it sets aside.
+type TicketSelector struct {
+	Account   types.Address `json:"account"`
+	TicketSeq uint32        `json:"ticket_seq"`
+}
+
+// XChainBridge identifies a cross-chain bridge by its door accounts and assets.
+type XChainBridge struct {
+	IssuingChainDoor  types.Address
+	IssuingChainIssue ledger.Asset
+	LockingChainDoor  types.Address
+	LockingChainIssue ledger.Asset
+}
+
+// XChainOwnedClaimIDSelector identifies an XChainOwnedClaimID entry by its bridge and claim ID.
+type XChainOwnedClaimIDSelector struct {
+	XChainBridge
+	XChainOwnedClaimID uint64 `json:"xchain_owned_claim_id"`
+}
+
+// XChainOwnedCreateAccountClaimIDSelector identifies an XChainOwnedCreateAccountClaimID
+// entry by its bridge and account claim ID.
+type XChainOwnedCreateAccountClaimIDSelector struct {
+	XChainBridge
+	XChainOwnedCreateAccountClaimID uint64 `json:"xchain_owned_create_account_claim_id"`
+}
@@ -0,0 +1,21 @@
+// Package queries implements the typed query and pagination methods of the clients on
+// top of an xrpl.Requester. The rpc, websocket and pool clients embed Methods, so that
+// every method is written once whatever the transport.
+package queries
+
+import "github.com/Peersyst/xrpl-go/xrpl"
+
+// Methods sends typed queries through a Requester and decodes their results.
+type Methods struct {
+	r xrpl.Requester
+}
+
+// NewMethods returns the methods sending their requests through r.
+func NewMethods(r xrpl.Requester) Methods {
+	return Methods{r: r}
+}
+
+var (
+	_ xrpl.Querier   = Methods{}
+	_ xrpl.Paginator = Methods{}
+)
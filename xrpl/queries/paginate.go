@@ -0,0 +1,83 @@
+package queries
+
+import (
+	"context"
+	"iter"
+
+	ledger "github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	"github.com/Peersyst/xrpl-go/xrpl/paginate"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	accounttypes "github.com/Peersyst/xrpl-go/xrpl/queries/account/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/clio"
+	cliotypes "github.com/Peersyst/xrpl-go/xrpl/queries/clio/types"
+	ledgerqueries "github.com/Peersyst/xrpl-go/xrpl/queries/ledger"
+	ledgertypes "github.com/Peersyst/xrpl-go/xrpl/queries/ledger/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/path"
+	pathtypes "github.com/Peersyst/xrpl-go/xrpl/queries/path/types"
+)
+
+// AccountTransactionsAll iterates over the transactions of an account, following the markers of account_tx.
+// The iteration stops at the first error, which is yielded, or when ctx is done.
+func (m Methods) AccountTransactionsAll(ctx context.Context, req *account.TransactionsRequest) iter.Seq2[account.Transaction, error] {
+	return paginate.AccountTransactions(ctx, m.r, req)
+}
+
+// AccountLinesAll iterates over the trust lines of an account, following the markers of account_lines.
+// The iteration stops at the first error, which is yielded, or when ctx is done.
+func (m Methods) AccountLinesAll(ctx context.Context, req *account.LinesRequest) iter.Seq2[accounttypes.TrustLine, error] {
+	return paginate.AccountLines(ctx, m.r, req)
+}
+
+// AccountObjectsAll iterates over the ledger objects owned by an account, following the markers of account_objects.
+// The iteration stops at the first error, which is yielded, or when ctx is done.
+func (m Methods) AccountObjectsAll(ctx context.Context, req *account.ObjectsRequest) iter.Seq2[ledger.FlatLedgerObject, error] {
+	return paginate.AccountObjects(ctx, m.r, req)
+}
+
+// AccountOffersAll iterates over the offers of an account, following the markers of account_offers.
+// The iteration stops at the first error, which is yielded, or when ctx is done.
+func (m Methods) AccountOffersAll(ctx context.Context, req *account.OffersRequest) iter.Seq2[accounttypes.OfferResult, error] {
+	return paginate.AccountOffers(ctx, m.r, req)
+}
+
+// AccountNFTsAll iterates over the NFTs owned by an account, following the markers of account_nfts.
+// The iteration stops at the first error, which is yielded, or when ctx is done.
+func (m Methods) AccountNFTsAll(ctx context.Context, req *account.NFTsRequest) iter.Seq2[accounttypes.NFT, error] {
+	return paginate.AccountNFTs(ctx, m.r, req)
+}
+
+// AccountChannelsAll iterates over the payment channels of an account, following the markers of account_channels.
+// The iteration stops at the first error, which is yielded, or when ctx is done.
+func (m Methods) AccountChannelsAll(ctx context.Context, req *account.ChannelsRequest) iter.Seq2[accounttypes.ChannelResult, error] {
+	return paginate.AccountChannels(ctx, m.r, req)
+}
+
+// LedgerDataAll iterates over the state entries of a ledger, following the markers of ledger_data.
+// The iteration stops at the first error, which is yielded, or when ctx is done.
+func (m Methods) LedgerDataAll(ctx context.Context, req *ledgerqueries.DataRequest) iter.Seq2[ledgertypes.State, error] {
+	return paginate.LedgerData(ctx, m.r, req)
+}
+
+// BookOffersAll iterates over the offers of an order book, following the markers of book_offers.
+// The iteration stops at the first error, which is yielded, or when ctx is done.
+func (m Methods) BookOffersAll(ctx context.Context, req *path.BookOffersRequest) iter.Seq2[pathtypes.BookOffer, error] {
+	return paginate.BookOffers(ctx, m.r, req)
+}
+
+// NFTsByIssuerAll iterates over the NFTs issued by an account, following the markers of the Clio nfts_by_issuer method.
+// The iteration stops at the first error, which is yielded, or when ctx is done.
+func (m Methods) NFTsByIssuerAll(ctx context.Context, req *clio.NFTsByIssuerRequest) iter.Seq2[cliotypes.NFToken, error] {
+	return paginate.NFTsByIssuer(ctx, m.r, req)
+}
+
+// NFTHistoryAll iterates over the transactions that involved an NFToken, following the markers of the Clio nft_history method.
+// The iteration stops at the first error, which is yielded, or when ctx is done.
+func (m Methods) NFTHistoryAll(ctx context.Context, req *clio.NFTHistoryRequest) iter.Seq2[clio.NFTHistoryTransactions, error] {
+	return paginate.NFTHistory(ctx, m.r, req)
+}
+
+// MPTHoldersAll iterates over the holders of an MPT issuance, following the markers of the Clio mpt_holders method.
+// The iteration stops at the first error, which is yielded, or when ctx is done.
+func (m Methods) MPTHoldersAll(ctx context.Context, req *clio.MPTHoldersRequest) iter.Seq2[cliotypes.MPTHolder, error] {
+	return paginate.MPTHolders(ctx, m.r, req)
+}
@@ -0,0 +1,556 @@
+package queries
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+
+	"github.com/Peersyst/xrpl-go/xrpl/currency"
+	account "github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/amm"
+	channel "github.com/Peersyst/xrpl-go/xrpl/queries/channel"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/clio"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	ledger "github.com/Peersyst/xrpl-go/xrpl/queries/ledger"
+	nft "github.com/Peersyst/xrpl-go/xrpl/queries/nft"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/oracle"
+	path "github.com/Peersyst/xrpl-go/xrpl/queries/path"
+	server "github.com/Peersyst/xrpl-go/xrpl/queries/server"
+	utility "github.com/Peersyst/xrpl-go/xrpl/queries/utility"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+// GetAccountInfo retrieves information about an account on the XRP Ledger.
+// It takes an AccountInfoRequest as input and returns an AccountInfoResponse,
+// along with the raw XRPL response and any error encountered.
+func (m Methods) GetAccountInfo(req *account.InfoRequest) (*account.InfoResponse, error) {
+	return m.GetAccountInfoContext(context.Background(), req)
+}
+
+// GetAccountInfoContext is like GetAccountInfo but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetAccountInfoContext(ctx context.Context, req *account.InfoRequest) (*account.InfoResponse, error) {
+	return xrpl.Query[account.InfoResponse](ctx, m.r, req)
+}
+
+// GetAccountChannels retrieves a list of payment channels associated with an account.
+// It takes an AccountChannelsRequest as input and returns an AccountChannelsResponse,
+// along with any error encountered.
+func (m Methods) GetAccountChannels(req *account.ChannelsRequest) (*account.ChannelsResponse, error) {
+	return m.GetAccountChannelsContext(context.Background(), req)
+}
+
+// GetAccountChannelsContext is like GetAccountChannels but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetAccountChannelsContext(ctx context.Context, req *account.ChannelsRequest) (*account.ChannelsResponse, error) {
+	return xrpl.Query[account.ChannelsResponse](ctx, m.r, req)
+}
+
+// GetAccountObjects retrieves a list of objects owned by an account on the XRP Ledger.
+// It takes an AccountObjectsRequest as input and returns an AccountObjectsResponse,
+// along with any error encountered.
+func (m Methods) GetAccountObjects(req *account.ObjectsRequest) (*account.ObjectsResponse, error) {
+	return m.GetAccountObjectsContext(context.Background(), req)
+}
+
+// GetAccountObjectsContext is like GetAccountObjects but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetAccountObjectsContext(ctx context.Context, req *account.ObjectsRequest) (*account.ObjectsResponse, error) {
+	return xrpl.Query[account.ObjectsResponse](ctx, m.r, req)
+}
+
+// GetAccountLines retrieves the lines associated with an account on the XRP Ledger.
+// It takes an AccountLinesRequest as input and returns an AccountLinesResponse,
+// along with any error encountered.
+func (m Methods) GetAccountLines(req *account.LinesRequest) (*account.LinesResponse, error) {
+	return m.GetAccountLinesContext(context.Background(), req)
+}
+
+// GetAccountLinesContext is like GetAccountLines but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetAccountLinesContext(ctx context.Context, req *account.LinesRequest) (*account.LinesResponse, error) {
+	return xrpl.Query[account.LinesResponse](ctx, m.r, req)
+}
+
+// GetXrpBalance retrieves the XRP balance of a given account address.
+// It returns the balance as a string in XRP (not drops) and any error encountered.
+func (m Methods) GetXrpBalance(address types.Address) (string, error) {
+	return m.GetXrpBalanceContext(context.Background(), address)
+}
+
+// GetXrpBalanceContext is like GetXrpBalance but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetXrpBalanceContext(ctx context.Context, address types.Address) (string, error) {
+	res, err := m.GetAccountInfoContext(ctx, &account.InfoRequest{
+		Account: address,
+	})
+	if err != nil {
+		return "", err
+	}
+	xrpBalance, err := currency.DropsToXrp(res.AccountData.Balance.String())
+	if err != nil {
+		return "", err
+	}
+	return xrpBalance, nil
+}
+
+// GetAccountNFTs retrieves a list of NFTs owned by an account on the XRP Ledger.
+// It takes an AccountNFTsRequest as input and returns an AccountNFTsResponse,
+// along with any error encountered.
+func (m Methods) GetAccountNFTs(req *account.NFTsRequest) (*account.NFTsResponse, error) {
+	return m.GetAccountNFTsContext(context.Background(), req)
+}
+
+// GetAccountNFTsContext is like GetAccountNFTs but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetAccountNFTsContext(ctx context.Context, req *account.NFTsRequest) (*account.NFTsResponse, error) {
+	return xrpl.Query[account.NFTsResponse](ctx, m.r, req)
+}
+
+// GetAccountCurrencies retrieves a list of currencies that an account can send or receive.
+// It takes an AccountCurrenciesRequest as input and returns an AccountCurrenciesResponse,
+// along with any error encountered.
+func (m Methods) GetAccountCurrencies(req *account.CurrenciesRequest) (*account.CurrenciesResponse, error) {
+	return m.GetAccountCurrenciesContext(context.Background(), req)
+}
+
+// GetAccountCurrenciesContext is like GetAccountCurrencies but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetAccountCurrenciesContext(ctx context.Context, req *account.CurrenciesRequest) (*account.CurrenciesResponse, error) {
+	return xrpl.Query[account.CurrenciesResponse](ctx, m.r, req)
+}
+
+// GetAccountOffers retrieves a list of offers made by an account that are currently active
+// in the XRP Ledger's decentralized exchange.
+// It takes an AccountOffersRequest as input and returns an AccountOffersResponse,
+// along with any error encountered.
+func (m Methods) GetAccountOffers(req *account.OffersRequest) (*account.OffersResponse, error) {
+	return m.GetAccountOffersContext(context.Background(), req)
+}
+
+// GetAccountOffersContext is like GetAccountOffers but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetAccountOffersContext(ctx context.Context, req *account.OffersRequest) (*account.OffersResponse, error) {
+	return xrpl.Query[account.OffersResponse](ctx, m.r, req)
+}
+
+// GetAccountTransactions retrieves a list of transactions that involved a specific account.
+// It takes an AccountTransactionsRequest as input and returns an AccountTransactionsResponse,
+// along with any error encountered.
+func (m Methods) GetAccountTransactions(req *account.TransactionsRequest) (*account.TransactionsResponse, error) {
+	return m.GetAccountTransactionsContext(context.Background(), req)
+}
+
+// GetAccountTransactionsContext is like GetAccountTransactions but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetAccountTransactionsContext(ctx context.Context, req *account.TransactionsRequest) (*account.TransactionsResponse, error) {
+	return xrpl.Query[account.TransactionsResponse](ctx, m.r, req)
+}
+
+// GetGatewayBalances retrieves the gateway balances for an account.
+// It takes a GatewayBalancesRequest as input and returns a GatewayBalancesResponse,
+// along with any error encountered.
+func (m Methods) GetGatewayBalances(req *account.GatewayBalancesRequest) (*account.GatewayBalancesResponse, error) {
+	return m.GetGatewayBalancesContext(context.Background(), req)
+}
+
+// GetGatewayBalancesContext is like GetGatewayBalances but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetGatewayBalancesContext(ctx context.Context, req *account.GatewayBalancesRequest) (*account.GatewayBalancesResponse, error) {
+	return xrpl.Query[account.GatewayBalancesResponse](ctx, m.r, req)
+}
+
+// Channel queries
+
+// GetChannelVerify verifies the signature of a payment channel claim.
+// It takes a ChannelVerifyRequest as input and returns a ChannelVerifyResponse,
+// along with any error encountered.
+func (m Methods) GetChannelVerify(req *channel.VerifyRequest) (*channel.VerifyResponse, error) {
+	return m.GetChannelVerifyContext(context.Background(), req)
+}
+
+// GetChannelVerifyContext is like GetChannelVerify but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetChannelVerifyContext(ctx context.Context, req *channel.VerifyRequest) (*channel.VerifyResponse, error) {
+	return xrpl.Query[channel.VerifyResponse](ctx, m.r, req)
+}
+
+// Ledger queries
+
+// GetLedgerIndex returns the index of the most recently validated ledger.
+// It returns the ledger index as a LedgerIndex type and any error encountered.
+func (m Methods) GetLedgerIndex() (common.LedgerIndex, error) {
+	return m.GetLedgerIndexContext(context.Background())
+}
+
+// GetLedgerIndexContext is like GetLedgerIndex but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetLedgerIndexContext(ctx context.Context) (common.LedgerIndex, error) {
+	res, err := xrpl.Query[ledger.Response](ctx, m.r, &ledger.Request{
+		LedgerIndex: common.LedgerTitle("validated"),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return res.LedgerIndex, nil
+}
+
+// GetClosedLedger retrieves information about the last closed ledger.
+// It returns a ClosedResponse containing the ledger information and any error encountered.
+func (m Methods) GetClosedLedger() (*ledger.ClosedResponse, error) {
+	return m.GetClosedLedgerContext(context.Background())
+}
+
+// GetClosedLedgerContext is like GetClosedLedger but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetClosedLedgerContext(ctx context.Context) (*ledger.ClosedResponse, error) {
+	return xrpl.Query[ledger.ClosedResponse](ctx, m.r, &ledger.ClosedRequest{})
+}
+
+// GetCurrentLedger retrieves information about the current working ledger.
+// It returns a CurrentResponse containing the ledger information and any error encountered.
+func (m Methods) GetCurrentLedger() (*ledger.CurrentResponse, error) {
+	return m.GetCurrentLedgerContext(context.Background())
+}
+
+// GetCurrentLedgerContext is like GetCurrentLedger but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetCurrentLedgerContext(ctx context.Context) (*ledger.CurrentResponse, error) {
+	return xrpl.Query[ledger.CurrentResponse](ctx, m.r, &ledger.CurrentRequest{})
+}
+
+// GetLedgerData retrieves contents of a ledger.
+// It takes a DataRequest as input and returns a DataResponse containing the ledger data,
+// along with any error encountered.
+func (m Methods) GetLedgerData(req *ledger.DataRequest) (*ledger.DataResponse, error) {
+	return m.GetLedgerDataContext(context.Background(), req)
+}
+
+// GetLedgerDataContext is like GetLedgerData but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetLedgerDataContext(ctx context.Context, req *ledger.DataRequest) (*ledger.DataResponse, error) {
+	return xrpl.Query[ledger.DataResponse](ctx, m.r, req)
+}
+
+// GetLedgerEntry retrieves a single ledger entry, selected by its ID or by the fields
+// it is keyed on. The typed entry is available with the Object method of the response.
+func (m Methods) GetLedgerEntry(req *ledger.EntryRequest) (*ledger.EntryResponse, error) {
+	return m.GetLedgerEntryContext(context.Background(), req)
+}
+
+// GetLedgerEntryContext is like GetLedgerEntry but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetLedgerEntryContext(ctx context.Context, req *ledger.EntryRequest) (*ledger.EntryResponse, error) {
+	return xrpl.Query[ledger.EntryResponse](ctx, m.r, req)
+}
+
+// GetAMMInfo retrieves a snapshot of an AMM pool, identified by its asset pair or by its
+// AMM account: its balances, LP token, trading fee, vote slots and auction slot.
+func (m Methods) GetAMMInfo(req *amm.InfoRequest) (*amm.InfoResponse, error) {
+	return m.GetAMMInfoContext(context.Background(), req)
+}
+
+// GetAMMInfoContext is like GetAMMInfo but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetAMMInfoContext(ctx context.Context, req *amm.InfoRequest) (*amm.InfoResponse, error) {
+	res, err := m.r.RequestContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// The pool amounts are decoded as currency amounts by their JSON decoder.
+	var result map[string]any
+	err = res.GetResult(&result)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var ar amm.InfoResponse
+	err = json.Unmarshal(b, &ar)
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
+
+// GetLedger retrieves information about a specific ledger version.
+// It takes a Request as input and returns a Response containing the ledger information,
+// along with any error encountered.
+func (m Methods) GetLedger(req *ledger.Request) (*ledger.Response, error) {
+	return m.GetLedgerContext(context.Background(), req)
+}
+
+// GetLedgerContext is like GetLedger but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetLedgerContext(ctx context.Context, req *ledger.Request) (*ledger.Response, error) {
+	return xrpl.Query[ledger.Response](ctx, m.r, req)
+}
+
+// NFT queries
+
+// GetNFTBuyOffers retrieves all buy offers for a specific NFT.
+// It takes an NFTokenBuyOffersRequest as input and returns an NFTokenBuyOffersResponse,
+// along with any error encountered.
+func (m Methods) GetNFTBuyOffers(req *nft.NFTokenBuyOffersRequest) (*nft.NFTokenBuyOffersResponse, error) {
+	return m.GetNFTBuyOffersContext(context.Background(), req)
+}
+
+// GetNFTBuyOffersContext is like GetNFTBuyOffers but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetNFTBuyOffersContext(ctx context.Context, req *nft.NFTokenBuyOffersRequest) (*nft.NFTokenBuyOffersResponse, error) {
+	return xrpl.Query[nft.NFTokenBuyOffersResponse](ctx, m.r, req)
+}
+
+// GetNFTSellOffers retrieves all sell offers for a specific NFT.
+// It takes an NFTokenSellOffersRequest as input and returns an NFTokenSellOffersResponse,
+// along with any error encountered.
+func (m Methods) GetNFTSellOffers(req *nft.NFTokenSellOffersRequest) (*nft.NFTokenSellOffersResponse, error) {
+	return m.GetNFTSellOffersContext(context.Background(), req)
+}
+
+// GetNFTSellOffersContext is like GetNFTSellOffers but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetNFTSellOffersContext(ctx context.Context, req *nft.NFTokenSellOffersRequest) (*nft.NFTokenSellOffersResponse, error) {
+	return xrpl.Query[nft.NFTokenSellOffersResponse](ctx, m.r, req)
+}
+
+// Clio queries
+
+// GetNFTInfo retrieves information about an NFToken, including burned ones. It is only
+// served by Clio servers.
+func (m Methods) GetNFTInfo(req *clio.NFTInfoRequest) (*clio.NFTInfoResponse, error) {
+	return m.GetNFTInfoContext(context.Background(), req)
+}
+
+// GetNFTInfoContext is like GetNFTInfo but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetNFTInfoContext(ctx context.Context, req *clio.NFTInfoRequest) (*clio.NFTInfoResponse, error) {
+	return xrpl.Query[clio.NFTInfoResponse](ctx, m.r, req)
+}
+
+// GetNFTHistory retrieves the transactions that involved an NFToken. It is only served
+// by Clio servers. See NFTHistoryAll to follow the markers of the response.
+func (m Methods) GetNFTHistory(req *clio.NFTHistoryRequest) (*clio.NFTHistoryResponse, error) {
+	return m.GetNFTHistoryContext(context.Background(), req)
+}
+
+// GetNFTHistoryContext is like GetNFTHistory but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetNFTHistoryContext(ctx context.Context, req *clio.NFTHistoryRequest) (*clio.NFTHistoryResponse, error) {
+	return xrpl.Query[clio.NFTHistoryResponse](ctx, m.r, req)
+}
+
+// GetNFTsByIssuer retrieves a page of the NFTokens issued by an account. It is only
+// served by Clio servers. See NFTsByIssuerAll to follow the markers of the response.
+func (m Methods) GetNFTsByIssuer(req *clio.NFTsByIssuerRequest) (*clio.NFTsByIssuerResponse, error) {
+	return m.GetNFTsByIssuerContext(context.Background(), req)
+}
+
+// GetNFTsByIssuerContext is like GetNFTsByIssuer but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetNFTsByIssuerContext(ctx context.Context, req *clio.NFTsByIssuerRequest) (*clio.NFTsByIssuerResponse, error) {
+	return xrpl.Query[clio.NFTsByIssuerResponse](ctx, m.r, req)
+}
+
+// GetMPTHolders retrieves a page of the accounts holding an MPT issuance. It is only
+// served by Clio servers. See MPTHoldersAll to follow the markers of the response.
+func (m Methods) GetMPTHolders(req *clio.MPTHoldersRequest) (*clio.MPTHoldersResponse, error) {
+	return m.GetMPTHoldersContext(context.Background(), req)
+}
+
+// GetMPTHoldersContext is like GetMPTHolders but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetMPTHoldersContext(ctx context.Context, req *clio.MPTHoldersRequest) (*clio.MPTHoldersResponse, error) {
+	return xrpl.Query[clio.MPTHoldersResponse](ctx, m.r, req)
+}
+
+// GetClioLedger retrieves a ledger version from a Clio server, with the ledger entries it
+// created, modified or deleted if the request sets Diff. Requests with Diff are only
+// served by Clio servers.
+func (m Methods) GetClioLedger(req *clio.LedgerRequest) (*clio.LedgerResponse, error) {
+	return m.GetClioLedgerContext(context.Background(), req)
+}
+
+// GetClioLedgerContext is like GetClioLedger but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetClioLedgerContext(ctx context.Context, req *clio.LedgerRequest) (*clio.LedgerResponse, error) {
+	return xrpl.Query[clio.LedgerResponse](ctx, m.r, req)
+}
+
+// Path queries
+
+// GetBookOffers retrieves a list of offers between two currencies.
+// It takes a BookOffersRequest as input and returns a BookOffersResponse,
+// along with any error encountered.
+func (m Methods) GetBookOffers(req *path.BookOffersRequest) (*path.BookOffersResponse, error) {
+	return m.GetBookOffersContext(context.Background(), req)
+}
+
+// GetBookOffersContext is like GetBookOffers but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetBookOffersContext(ctx context.Context, req *path.BookOffersRequest) (*path.BookOffersResponse, error) {
+	return xrpl.Query[path.BookOffersResponse](ctx, m.r, req)
+}
+
+// GetDepositAuthorized checks whether one account is authorized to send payments directly to another.
+// It takes a DepositAuthorizedRequest as input and returns a DepositAuthorizedResponse,
+// along with any error encountered.
+func (m Methods) GetDepositAuthorized(req *path.DepositAuthorizedRequest) (*path.DepositAuthorizedResponse, error) {
+	return m.GetDepositAuthorizedContext(context.Background(), req)
+}
+
+// GetDepositAuthorizedContext is like GetDepositAuthorized but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetDepositAuthorizedContext(ctx context.Context, req *path.DepositAuthorizedRequest) (*path.DepositAuthorizedResponse, error) {
+	return xrpl.Query[path.DepositAuthorizedResponse](ctx, m.r, req)
+}
+
+// FindPathCreate creates a path finding request that will be monitored until it expires or is closed.
+// It takes a FindCreateRequest as input and returns a FindResponse,
+// along with any error encountered.
+func (m Methods) FindPathCreate(req *path.FindCreateRequest) (*path.FindResponse, error) {
+	return m.FindPathCreateContext(context.Background(), req)
+}
+
+// FindPathCreateContext is like FindPathCreate but uses ctx to cancel the request or bound its duration.
+func (m Methods) FindPathCreateContext(ctx context.Context, req *path.FindCreateRequest) (*path.FindResponse, error) {
+	return xrpl.Query[path.FindResponse](ctx, m.r, req)
+}
+
+// FindPathClose closes an existing path finding request.
+// It takes a FindCloseRequest as input and returns a FindResponse,
+// along with any error encountered.
+func (m Methods) FindPathClose(req *path.FindCloseRequest) (*path.FindResponse, error) {
+	return m.FindPathCloseContext(context.Background(), req)
+}
+
+// FindPathCloseContext is like FindPathClose but uses ctx to cancel the request or bound its duration.
+func (m Methods) FindPathCloseContext(ctx context.Context, req *path.FindCloseRequest) (*path.FindResponse, error) {
+	return xrpl.Query[path.FindResponse](ctx, m.r, req)
+}
+
+// FindPathStatus checks the status of an existing path finding request.
+// It takes a FindStatusRequest as input and returns a FindResponse,
+// along with any error encountered.
+func (m Methods) FindPathStatus(req *path.FindStatusRequest) (*path.FindResponse, error) {
+	return m.FindPathStatusContext(context.Background(), req)
+}
+
+// FindPathStatusContext is like FindPathStatus but uses ctx to cancel the request or bound its duration.
+func (m Methods) FindPathStatusContext(ctx context.Context, req *path.FindStatusRequest) (*path.FindResponse, error) {
+	return xrpl.Query[path.FindResponse](ctx, m.r, req)
+}
+
+// GetRipplePathFind finds paths for a payment between two accounts.
+// It takes a RipplePathFindRequest as input and returns a RipplePathFindResponse,
+// along with any error encountered.
+func (m Methods) GetRipplePathFind(req *path.RipplePathFindRequest) (*path.RipplePathFindResponse, error) {
+	return m.GetRipplePathFindContext(context.Background(), req)
+}
+
+// GetRipplePathFindContext is like GetRipplePathFind but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetRipplePathFindContext(ctx context.Context, req *path.RipplePathFindRequest) (*path.RipplePathFindResponse, error) {
+	return xrpl.Query[path.RipplePathFindResponse](ctx, m.r, req)
+}
+
+// Server queries
+
+// GetServerInfo retrieves information about the server.
+// It takes a ServerInfoRequest as input and returns a ServerInfoResponse,
+// along with any error encountered.
+func (m Methods) GetServerInfo(req *server.InfoRequest) (*server.InfoResponse, error) {
+	return m.GetServerInfoContext(context.Background(), req)
+}
+
+// GetServerInfoContext is like GetServerInfo but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetServerInfoContext(ctx context.Context, req *server.InfoRequest) (*server.InfoResponse, error) {
+	return xrpl.Query[server.InfoResponse](ctx, m.r, req)
+}
+
+// GetAllFeatures retrieves information about all features supported by the server.
+// It takes a FeatureAllRequest as input and returns a FeatureAllResponse,
+// along with any error encountered.
+func (m Methods) GetAllFeatures(req *server.FeatureAllRequest) (*server.FeatureAllResponse, error) {
+	return m.GetAllFeaturesContext(context.Background(), req)
+}
+
+// GetAllFeaturesContext is like GetAllFeatures but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetAllFeaturesContext(ctx context.Context, req *server.FeatureAllRequest) (*server.FeatureAllResponse, error) {
+	return xrpl.Query[server.FeatureAllResponse](ctx, m.r, req)
+}
+
+// GetFeature retrieves information about a specific feature supported by the server.
+// It takes a FeatureOneRequest as input and returns a FeatureResponse,
+// along with any error encountered.
+func (m Methods) GetFeature(req *server.FeatureOneRequest) (*server.FeatureResponse, error) {
+	return m.GetFeatureContext(context.Background(), req)
+}
+
+// GetFeatureContext is like GetFeature but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetFeatureContext(ctx context.Context, req *server.FeatureOneRequest) (*server.FeatureResponse, error) {
+	return xrpl.Query[server.FeatureResponse](ctx, m.r, req)
+}
+
+// GetFee retrieves the current transaction fee settings from the server.
+// It takes a FeeRequest as input and returns a FeeResponse,
+// along with any error encountered.
+func (m Methods) GetFee(req *server.FeeRequest) (*server.FeeResponse, error) {
+	return m.GetFeeContext(context.Background(), req)
+}
+
+// GetFeeContext is like GetFee but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetFeeContext(ctx context.Context, req *server.FeeRequest) (*server.FeeResponse, error) {
+	return xrpl.Query[server.FeeResponse](ctx, m.r, req)
+}
+
+// GetManifest retrieves public information about a known validator.
+// It takes a ManifestRequest as input and returns a ManifestResponse,
+// along with any error encountered.
+func (m Methods) GetManifest(req *server.ManifestRequest) (*server.ManifestResponse, error) {
+	return m.GetManifestContext(context.Background(), req)
+}
+
+// GetManifestContext is like GetManifest but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetManifestContext(ctx context.Context, req *server.ManifestRequest) (*server.ManifestResponse, error) {
+	return xrpl.Query[server.ManifestResponse](ctx, m.r, req)
+}
+
+// GetServerState retrieves information about the current state of the server.
+// It takes a StateRequest as input and returns a StateResponse,
+// along with any error encountered.
+func (m Methods) GetServerState(req *server.StateRequest) (*server.StateResponse, error) {
+	return m.GetServerStateContext(context.Background(), req)
+}
+
+// GetServerStateContext is like GetServerState but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetServerStateContext(ctx context.Context, req *server.StateRequest) (*server.StateResponse, error) {
+	return xrpl.Query[server.StateResponse](ctx, m.r, req)
+}
+
+// GetServerDefinitions retrieves the definitions the server uses to encode and decode
+// the binary format. It takes a DefinitionsRequest as input and returns a DefinitionsResponse,
+// along with any error encountered. See Definitions to fetch them with hash caching.
+func (m Methods) GetServerDefinitions(req *server.DefinitionsRequest) (*server.DefinitionsResponse, error) {
+	return m.GetServerDefinitionsContext(context.Background(), req)
+}
+
+// GetServerDefinitionsContext is like GetServerDefinitions but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetServerDefinitionsContext(ctx context.Context, req *server.DefinitionsRequest) (*server.DefinitionsResponse, error) {
+	return xrpl.Query[server.DefinitionsResponse](ctx, m.r, req)
+}
+
+// Oracle queries
+
+// GetAggregatePrice retrieves the aggregate price of an asset.
+// It takes a GetAggregatePriceRequest as input and returns a GetAggregatePriceResponse,
+// along with any error encountered.
+func (m Methods) GetAggregatePrice(req *oracle.GetAggregatePriceRequest) (*oracle.GetAggregatePriceResponse, error) {
+	return m.GetAggregatePriceContext(context.Background(), req)
+}
+
+// GetAggregatePriceContext is like GetAggregatePrice but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetAggregatePriceContext(ctx context.Context, req *oracle.GetAggregatePriceRequest) (*oracle.GetAggregatePriceResponse, error) {
+	return xrpl.Query[oracle.GetAggregatePriceResponse](ctx, m.r, req)
+}
+
+// Utility queries
+
+// Ping tests the connection to the server.
+// It takes a PingRequest as input and returns a PingResponse,
+// along with any error encountered.
+func (m Methods) Ping(req *utility.PingRequest) (*utility.PingResponse, error) {
+	return m.PingContext(context.Background(), req)
+}
+
+// PingContext is like Ping but uses ctx to cancel the request or bound its duration.
+func (m Methods) PingContext(ctx context.Context, req *utility.PingRequest) (*utility.PingResponse, error) {
+	return xrpl.Query[utility.PingResponse](ctx, m.r, req)
+}
+
+// GetRandom provides a random number from the server.
+// It takes a RandomRequest as input and returns a RandomResponse,
+// along with any error encountered.
+func (m Methods) GetRandom(req *utility.RandomRequest) (*utility.RandomResponse, error) {
+	return m.GetRandomContext(context.Background(), req)
+}
+
+// GetRandomContext is like GetRandom but uses ctx to cancel the request or bound its duration.
+func (m Methods) GetRandomContext(ctx context.Context, req *utility.RandomRequest) (*utility.RandomResponse, error) {
+	return xrpl.Query[utility.RandomResponse](ctx, m.r, req)
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/Peersyst/xrpl-go/binary-codec/definitions"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+)
+
+var (
+	// ErrDefinitionsNotReturned is returned when building definitions from a response
+	// without them, which the server sends when the requested hash is still current.
+	ErrDefinitionsNotReturned = errors.New("server_definitions response has no definitions")
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The server_definitions method returns the definitions the server uses to encode
+// and decode transactions and ledger objects in the binary format: its fields,
+// transaction types, ledger entry types and transaction results.
+type DefinitionsRequest struct {
+	common.BaseRequest
+	// Hash of the definitions already known by the client. If it is still current,
+	// the server answers with the hash alone.
+	Hash string `json:"hash,omitempty"`
+}
+
+func (*DefinitionsRequest) Method() string {
+	return "server_definitions"
+}
+
+func (*DefinitionsRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (*DefinitionsRequest) Validate() error {
+	return nil
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the server_definitions method. Everything but the
+// Hash is omitted when the requested hash is still current.
+type DefinitionsResponse struct {
+	Hash               string           `json:"hash"`
+	Types              map[string]int32 `json:"TYPES,omitempty"`
+	LedgerEntryTypes   map[string]int32 `json:"LEDGER_ENTRY_TYPES,omitempty"`
+	Fields             []any            `json:"FIELDS,omitempty"`
+	TransactionResults map[string]int32 `json:"TRANSACTION_RESULTS,omitempty"`
+	TransactionTypes   map[string]int32 `json:"TRANSACTION_TYPES,omitempty"`
+}
+
+// HasDefinitions reports whether the response carries the definitions, rather than
+// only confirming the requested hash.
+func (r *DefinitionsResponse) HasDefinitions() bool {
+	return len(r.Fields) > 0
+}
+
+// Definitions builds the binary codec definitions of the response, to encode and
+// decode with binarycodec.NewCodec.
+func (r *DefinitionsResponse) Definitions() (*definitions.Definitions, error) {
+	if !r.HasDefinitions() {
+		return nil, ErrDefinitionsNotReturned
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return definitions.New(b)
+}
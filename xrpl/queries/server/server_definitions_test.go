@@ -0,0 +1,97 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/binary-codec/definitions"
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefinitionsRequest(t *testing.T) {
+	s := DefinitionsRequest{
+		Hash: "56ADA2A0E7A2F3CE34ABF7C9C2C2F8E0F5CD6A4A54F4D5CE7D4AEC0AD9C7C3AD",
+	}
+
+	j := `{
+	"hash": "56ADA2A0E7A2F3CE34ABF7C9C2C2F8E0F5CD6A4A54F4D5CE7D4AEC0AD9C7C3AD"
+}`
+
+	if err := testutil.Serialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDefinitionsResponse(t *testing.T) {
+	s := DefinitionsResponse{
+		Hash:             "56ADA2A0E7A2F3CE34ABF7C9C2C2F8E0F5CD6A4A54F4D5CE7D4AEC0AD9C7C3AD",
+		Types:            map[string]int32{"UInt16": 1},
+		LedgerEntryTypes: map[string]int32{"AccountRoot": 97},
+		Fields: []any{
+			[]any{"TransactionType", map[string]any{
+				"isSerialized":   true,
+				"isSigningField": true,
+				"isVLEncoded":    false,
+				"nth":            float64(2),
+				"type":           "UInt16",
+			}},
+		},
+		TransactionResults: map[string]int32{"tesSUCCESS": 0},
+		TransactionTypes:   map[string]int32{"Payment": 0},
+	}
+
+	j := `{
+	"hash": "56ADA2A0E7A2F3CE34ABF7C9C2C2F8E0F5CD6A4A54F4D5CE7D4AEC0AD9C7C3AD",
+	"TYPES": {
+		"UInt16": 1
+	},
+	"LEDGER_ENTRY_TYPES": {
+		"AccountRoot": 97
+	},
+	"FIELDS": [
+		[
+			"TransactionType",
+			{
+				"isSerialized": true,
+				"isSigningField": true,
+				"isVLEncoded": false,
+				"nth": 2,
+				"type": "UInt16"
+			}
+		]
+	],
+	"TRANSACTION_RESULTS": {
+		"tesSUCCESS": 0
+	},
+	"TRANSACTION_TYPES": {
+		"Payment": 0
+	}
+}`
+
+	if err := testutil.SerializeAndDeserialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+
+	defs, err := s.Definitions()
+	require.NoError(t, err)
+	require.Equal(t, s.Hash, defs.Hash)
+	require.Equal(t, &definitions.FieldHeader{TypeCode: 1, FieldCode: 2}, defs.Fields["TransactionType"].FieldHeader)
+}
+
+func TestDefinitionsResponse_Unchanged(t *testing.T) {
+	s := DefinitionsResponse{
+		Hash: "56ADA2A0E7A2F3CE34ABF7C9C2C2F8E0F5CD6A4A54F4D5CE7D4AEC0AD9C7C3AD",
+	}
+
+	j := `{
+	"hash": "56ADA2A0E7A2F3CE34ABF7C9C2C2F8E0F5CD6A4A54F4D5CE7D4AEC0AD9C7C3AD"
+}`
+
+	if err := testutil.SerializeAndDeserialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+
+	require.False(t, s.HasDefinitions())
+	_, err := s.Definitions()
+	require.ErrorIs(t, err, ErrDefinitionsNotReturned)
+}
@@ -0,0 +1,22 @@
+package types
+
+// The manifests stream sends manifestReceived messages whenever the server receives a
+// manifest, which validators use to rotate the keys they sign validations with.
+type ManifestStream struct {
+	// The value manifestReceived indicates this is from the manifests stream.
+	Type Type `json:"type"`
+	// The base58 encoded NodePublic master key of the validator.
+	MasterKey string `json:"master_key"`
+	// The signature of the manifest by the master key, as hexadecimal.
+	MasterSignature string `json:"master_signature"`
+	// The data of the manifest, encoded as base64.
+	Manifest string `json:"manifest"`
+	// The base58 encoded NodePublic ephemeral key the validator signs validations with.
+	SigningKey string `json:"signing_key"`
+	// The sequence number of the manifest, higher numbers overriding lower ones.
+	Seq uint32 `json:"seq"`
+	// The signature of the manifest by the ephemeral key, as hexadecimal.
+	Signature string `json:"signature"`
+	// (May be omitted) The domain the validator claims to be associated with.
+	Domain string `json:"domain,omitempty"`
+}
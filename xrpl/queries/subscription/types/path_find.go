@@ -0,0 +1,26 @@
+package types
+
+import (
+	pathtypes "github.com/Peersyst/xrpl-go/xrpl/queries/path/types"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+// After a path_find create request, the server keeps sending path_find messages with
+// updated payment paths, until the request is closed or another one is created.
+type PathFindStream struct {
+	// The value path_find indicates this is an update of a path_find request.
+	Type Type `json:"type"`
+	// The ID of the path_find create request this update belongs to.
+	ID int `json:"id"`
+	// The source account of the path_find request.
+	SourceAccount types.Address `json:"source_account"`
+	// The destination account of the path_find request.
+	DestinationAccount types.Address `json:"destination_account"`
+	// The amount the destination should receive, as in the path_find request.
+	DestinationAmount any `json:"destination_amount"`
+	// If false, this is the result of an incomplete search: a later message may hold
+	// better paths.
+	FullReply bool `json:"full_reply"`
+	// The suggested paths, each with the amount the source would send.
+	Alternatives []pathtypes.Alternative `json:"alternatives"`
+}
@@ -0,0 +1,30 @@
+package types
+
+// The server stream sends serverStatus messages whenever the status of the server, such
+// as its load, changes.
+type ServerStream struct {
+	// The value serverStatus indicates this is from the server stream.
+	Type Type `json:"type"`
+	// The minimum transaction cost of a reference transaction, in drops of XRP.
+	BaseFee uint64 `json:"base_fee"`
+	// The baseline amount of server load used in transaction cost calculations.
+	LoadBase uint `json:"load_base"`
+	// The load factor the server is currently enforcing. The ratio between it and
+	// load_base multiplies the transaction cost.
+	LoadFactor uint `json:"load_factor"`
+	// (May be omitted) The current multiplier to the transaction cost to get into the
+	// open ledger, in fee levels.
+	LoadFactorFeeEscalation uint `json:"load_factor_fee_escalation,omitempty"`
+	// (May be omitted) The current multiplier to the transaction cost to get into the
+	// queue, if the queue is full, in fee levels.
+	LoadFactorFeeQueue uint `json:"load_factor_fee_queue,omitempty"`
+	// (May be omitted) The transaction cost with no load scaling, in fee levels.
+	LoadFactorFeeReference uint `json:"load_factor_fee_reference,omitempty"`
+	// (May be omitted) The load factor the server is enforcing, not including the open
+	// ledger cost.
+	LoadFactorServer uint `json:"load_factor_server,omitempty"`
+	// The current status of the server, such as full or syncing.
+	ServerStatus string `json:"server_status"`
+	// (May be omitted) The network ID of the server.
+	NetworkID uint32 `json:"network_id,omitempty"`
+}
@@ -9,4 +9,8 @@ const (
 	PeerStatusStreamType  Type = "peerStatusChange"
 	OrderBookStreamType   Type = TransactionStreamType
 	ConsensusStreamType   Type = "consensusPhase"
+	BookChangesStreamType Type = "bookChanges"
+	ManifestStreamType    Type = "manifestReceived"
+	ServerStreamType      Type = "serverStatus"
+	PathFindStreamType    Type = "path_find"
 )
@@ -0,0 +1,111 @@
+package transactions
+
+import (
+	"errors"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+)
+
+var (
+	ErrNoTxJSON                   = errors.New("no TxJSON defined")
+	ErrNoSigningCredentials       = errors.New("one of secret, seed, seed_hex and passphrase must be specified")
+	ErrMultipleSigningCredentials = errors.New("only one of secret, seed, seed_hex and passphrase can be specified")
+	ErrKeyTypeWithSecret          = errors.New("key type cannot be specified with secret")
+	ErrInvalidSigningKeyType      = errors.New("key type must be secp256k1 or ed25519")
+)
+
+// SigningCredentials are the keys a server signs a transaction with, in the sign,
+// sign_for and submit methods. Exactly one of Secret, Seed, SeedHex and Passphrase
+// must be set. KeyType is required for ed25519 keys, and can only be set along with
+// Seed, SeedHex or Passphrase.
+//
+// Servers receive these keys in clear text: clients only send them to local or admin
+// endpoints.
+type SigningCredentials struct {
+	Secret     string `json:"secret,omitempty"`
+	Seed       string `json:"seed,omitempty"`
+	SeedHex    string `json:"seed_hex,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+	KeyType    string `json:"key_type,omitempty"`
+}
+
+// HasSecret reports whether the credentials carry any secret key material.
+func (c *SigningCredentials) HasSecret() bool {
+	return c.Secret != "" || c.Seed != "" || c.SeedHex != "" || c.Passphrase != ""
+}
+
+// Validate checks that exactly one form of the key is set, and that KeyType goes with it.
+func (c *SigningCredentials) Validate() error {
+	set := 0
+	for _, s := range []string{c.Secret, c.Seed, c.SeedHex, c.Passphrase} {
+		if s != "" {
+			set++
+		}
+	}
+	switch {
+	case set == 0:
+		return ErrNoSigningCredentials
+	case set > 1:
+		return ErrMultipleSigningCredentials
+	}
+	if c.KeyType == "" {
+		return nil
+	}
+	if c.Secret != "" {
+		return ErrKeyTypeWithSecret
+	}
+	if c.KeyType != "secp256k1" && c.KeyType != "ed25519" {
+		return ErrInvalidSigningKeyType
+	}
+	return nil
+}
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The sign method signs a transaction in JSON format with the given keys, and
+// returns it in JSON and binary formats. Unless Offline is set, the server fills
+// in the Sequence, Fee and LastLedgerSequence fields the transaction is missing.
+type SignRequest struct {
+	common.BaseRequest
+	TxJSON             transaction.FlatTransaction `json:"tx_json"`
+	SigningCredentials `json:",squash"`
+	Offline            bool   `json:"offline,omitempty"`
+	BuildPath          bool   `json:"build_path,omitempty"`
+	FeeMultMax         uint32 `json:"fee_mult_max,omitempty"`
+	FeeDivMax          uint32 `json:"fee_div_max,omitempty"`
+}
+
+func (*SignRequest) Method() string {
+	return "sign"
+}
+
+func (*SignRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (r *SignRequest) Validate() error {
+	if r.TxJSON == nil {
+		return ErrNoTxJSON
+	}
+	return r.SigningCredentials.Validate()
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the sign method.
+type SignResponse struct {
+	TxBlob string                      `json:"tx_blob"`
+	Tx     transaction.FlatTransaction `json:"tx_json"`
+}
+
+// Hash returns the hash of the signed transaction.
+func (r *SignResponse) Hash() string {
+	hash, _ := r.Tx["hash"].(string)
+	return hash
+}
@@ -0,0 +1,53 @@
+package transactions
+
+import (
+	"errors"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/version"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+var (
+	ErrNoSignForAccount = errors.New("no signing Account defined")
+)
+
+// ############################################################################
+// Request
+// ############################################################################
+
+// The sign_for method provides one signature of a multi-signed transaction: the
+// server signs it with the keys of Account and adds the signature to its Signers.
+type SignForRequest struct {
+	common.BaseRequest
+	Account            types.Address               `json:"account"`
+	TxJSON             transaction.FlatTransaction `json:"tx_json"`
+	SigningCredentials `json:",squash"`
+}
+
+func (*SignForRequest) Method() string {
+	return "sign_for"
+}
+
+func (*SignForRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (r *SignForRequest) Validate() error {
+	if r.Account == "" {
+		return ErrNoSignForAccount
+	}
+	if r.TxJSON == nil {
+		return ErrNoTxJSON
+	}
+	return r.SigningCredentials.Validate()
+}
+
+// ############################################################################
+// Response
+// ############################################################################
+
+// The expected response from the sign_for method: the transaction with the new
+// signature among its Signers.
+type SignForResponse = SignResponse
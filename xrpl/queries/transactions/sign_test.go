@@ -0,0 +1,127 @@
+package transactions
+
+import (
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/testutil"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignRequest(t *testing.T) {
+	s := SignRequest{
+		TxJSON: transaction.FlatTransaction{
+			"Account":         "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn",
+			"TransactionType": "AccountSet",
+		},
+		SigningCredentials: SigningCredentials{
+			Seed:    "sEdTM1uX8pu2do5XvTnutH6HsouMaM2",
+			KeyType: "ed25519",
+		},
+		Offline:    true,
+		FeeMultMax: 1000,
+	}
+
+	j := `{
+	"tx_json": {
+		"Account": "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn",
+		"TransactionType": "AccountSet"
+	},
+	"seed": "sEdTM1uX8pu2do5XvTnutH6HsouMaM2",
+	"key_type": "ed25519",
+	"offline": true,
+	"fee_mult_max": 1000
+}`
+
+	if err := testutil.Serialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSignRequest_Validate(t *testing.T) {
+	tx := transaction.FlatTransaction{"TransactionType": "AccountSet"}
+
+	tests := []struct {
+		name string
+		req  SignRequest
+		err  error
+	}{
+		{name: "pass - secret", req: SignRequest{TxJSON: tx, SigningCredentials: SigningCredentials{Secret: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"}}},
+		{name: "pass - passphrase", req: SignRequest{TxJSON: tx, SigningCredentials: SigningCredentials{Passphrase: "masterpassphrase", KeyType: "secp256k1"}}},
+		{name: "fail - no tx_json", req: SignRequest{SigningCredentials: SigningCredentials{Secret: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"}}, err: ErrNoTxJSON},
+		{name: "fail - no credentials", req: SignRequest{TxJSON: tx}, err: ErrNoSigningCredentials},
+		{
+			name: "fail - secret and seed",
+			req:  SignRequest{TxJSON: tx, SigningCredentials: SigningCredentials{Secret: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb", Seed: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"}},
+			err:  ErrMultipleSigningCredentials,
+		},
+		{
+			name: "fail - key type with secret",
+			req:  SignRequest{TxJSON: tx, SigningCredentials: SigningCredentials{Secret: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb", KeyType: "ed25519"}},
+			err:  ErrKeyTypeWithSecret,
+		},
+		{
+			name: "fail - invalid key type",
+			req:  SignRequest{TxJSON: tx, SigningCredentials: SigningCredentials{SeedHex: "DEDCE9CE67B451D852FD4E846FCDE31C", KeyType: "rsa"}},
+			err:  ErrInvalidSigningKeyType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.req.Validate(), tt.err)
+		})
+	}
+}
+
+func TestSignForRequest_Validate(t *testing.T) {
+	tx := transaction.FlatTransaction{"TransactionType": "AccountSet"}
+	creds := SigningCredentials{Secret: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"}
+
+	tests := []struct {
+		name string
+		req  SignForRequest
+		err  error
+	}{
+		{name: "pass", req: SignForRequest{Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn", TxJSON: tx, SigningCredentials: creds}},
+		{name: "fail - no account", req: SignForRequest{TxJSON: tx, SigningCredentials: creds}, err: ErrNoSignForAccount},
+		{name: "fail - no tx_json", req: SignForRequest{Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn", SigningCredentials: creds}, err: ErrNoTxJSON},
+		{name: "fail - no credentials", req: SignForRequest{Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn", TxJSON: tx}, err: ErrNoSigningCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.req.Validate(), tt.err)
+		})
+	}
+}
+
+func TestSignAndSubmitRequest(t *testing.T) {
+	s := SignAndSubmitRequest{
+		TxJSON: transaction.FlatTransaction{
+			"TransactionType": "AccountSet",
+		},
+		SigningCredentials: SigningCredentials{Secret: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"},
+		FailHard:           true,
+	}
+
+	j := `{
+	"tx_json": {
+		"TransactionType": "AccountSet"
+	},
+	"secret": "snoPBrXtMeMyMHUVTgbuqAfg1SUTb",
+	"fail_hard": true
+}`
+
+	if err := testutil.Serialize(t, s, j); err != nil {
+		t.Error(err)
+	}
+	require.ErrorIs(t, (&SignAndSubmitRequest{}).Validate(), ErrNoTxJSON)
+	require.True(t, s.HasSecret())
+}
+
+func TestSignResponse_Hash(t *testing.T) {
+	res := SignResponse{Tx: transaction.FlatTransaction{"hash": "ABCD"}}
+	require.Equal(t, "ABCD", res.Hash())
+	require.Empty(t, (&SignResponse{}).Hash())
+}
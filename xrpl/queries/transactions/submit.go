@@ -39,6 +39,34 @@ func (req *SubmitRequest) Validate() error {
 	return nil
 }
 
+// In sign-and-submit mode, the submit method signs a transaction in JSON format
+// with the given keys, like the sign method, then applies and sends it like
+// SubmitRequest does.
+type SignAndSubmitRequest struct {
+	common.BaseRequest
+	TxJSON             transaction.FlatTransaction `json:"tx_json"`
+	SigningCredentials `json:",squash"`
+	FailHard           bool   `json:"fail_hard,omitempty"`
+	BuildPath          bool   `json:"build_path,omitempty"`
+	FeeMultMax         uint32 `json:"fee_mult_max,omitempty"`
+	FeeDivMax          uint32 `json:"fee_div_max,omitempty"`
+}
+
+func (*SignAndSubmitRequest) Method() string {
+	return "submit"
+}
+
+func (*SignAndSubmitRequest) APIVersion() int {
+	return version.RippledAPIV2
+}
+
+func (r *SignAndSubmitRequest) Validate() error {
+	if r.TxJSON == nil {
+		return ErrNoTxJSON
+	}
+	return r.SigningCredentials.Validate()
+}
+
 // ############################################################################
 // Response
 // ############################################################################
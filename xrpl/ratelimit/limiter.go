@@ -0,0 +1,244 @@
+// Package ratelimit provides a client-side token-bucket rate limiter that adapts to the
+// load reported by rippled.
+//
+// A Limiter is shared by the requests of a client, or of several clients talking to the
+// same server. It slows down when responses carry a load warning or fail with slowDown
+// or tooBusy, and speeds back up to its configured rate once responses are clean again.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMinRateFraction is the lowest fraction of the configured rate the limiter
+	// backs off to.
+	DefaultMinRateFraction = 0.1
+	// DefaultDecrease is the factor the rate is multiplied by on a load warning.
+	DefaultDecrease = 0.5
+	// DefaultIncrease is the fraction of the configured rate recovered on every clean response.
+	DefaultIncrease = 0.05
+)
+
+// Signal is the load feedback carried by a response.
+type Signal int
+
+const (
+	// SignalOK is a response without any load warning.
+	SignalOK Signal = iota
+	// SignalLoadWarning is a response carrying the "load" warning: the server is close to
+	// throttling the client.
+	SignalLoadWarning
+	// SignalSlowDown is a response failing with the slowDown or tooBusy error code: the
+	// server is throttling the client.
+	SignalSlowDown
+)
+
+// SignalFor returns the signal of a response with the given warning and error code.
+func SignalFor(warning, errorCode string) Signal {
+	switch {
+	case errorCode == "slowDown" || errorCode == "tooBusy":
+		return SignalSlowDown
+	case warning == "load":
+		return SignalLoadWarning
+	default:
+		return SignalOK
+	}
+}
+
+// Stats is a snapshot of the budget of a Limiter.
+type Stats struct {
+	// BaseRate is the configured rate, in requests per second.
+	BaseRate float64
+	// Rate is the current rate, in requests per second. It is lower than BaseRate while
+	// the limiter is backing off.
+	Rate float64
+	// Burst is the maximum number of tokens.
+	Burst int
+	// Tokens is the number of requests that can be sent right away. It is negative when
+	// requests are waiting for a token.
+	Tokens float64
+
+	// Requests is the number of requests let through.
+	Requests uint64
+	// Waited is the total time requests waited for a token.
+	Waited time.Duration
+	// LoadWarnings is the number of responses with a load warning.
+	LoadWarnings uint64
+	// SlowDowns is the number of responses failing with slowDown or tooBusy.
+	SlowDowns uint64
+}
+
+// Limiter is an adaptive token-bucket rate limiter. It is safe for concurrent use.
+type Limiter struct {
+	mu sync.Mutex
+
+	baseRate float64
+	minRate  float64
+	rate     float64
+	burst    int
+	tokens   float64
+	last     time.Time
+
+	decrease float64
+	increase float64
+
+	stats Stats
+
+	now func() time.Time
+}
+
+// Option configures a Limiter.
+type Option func(l *Limiter)
+
+// WithMinRate sets the lowest rate, in requests per second, the limiter backs off to.
+// Default: DefaultMinRateFraction of the configured rate
+func WithMinRate(rate float64) Option {
+	return func(l *Limiter) {
+		l.minRate = rate
+	}
+}
+
+// WithDecrease sets the factor the rate is multiplied by on a load warning. A slowDown
+// or tooBusy error multiplies it twice.
+// Default: DefaultDecrease
+func WithDecrease(factor float64) Option {
+	return func(l *Limiter) {
+		l.decrease = factor
+	}
+}
+
+// WithIncrease sets the fraction of the configured rate recovered on every clean response.
+// Default: DefaultIncrease
+func WithIncrease(fraction float64) Option {
+	return func(l *Limiter) {
+		l.increase = fraction
+	}
+}
+
+// NewLimiter creates a limiter letting through rate requests per second on average,
+// and up to burst requests at once. The bucket starts full. A rate of zero or less
+// lets every request through.
+func NewLimiter(rate float64, burst int, opts ...Option) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	l := &Limiter{
+		baseRate: rate,
+		minRate:  rate * DefaultMinRateFraction,
+		rate:     rate,
+		burst:    burst,
+		tokens:   float64(burst),
+		decrease: DefaultDecrease,
+		increase: DefaultIncrease,
+		now:      time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	if l.minRate <= 0 {
+		l.minRate = rate * DefaultMinRateFraction
+	}
+	l.minRate = math.Min(l.minRate, l.baseRate)
+	l.last = l.now()
+	return l
+}
+
+// Wait blocks until a request can be sent, or until ctx is done, in which case it
+// returns the context error and gives the token back.
+func (l *Limiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	if l.baseRate <= 0 {
+		l.stats.Requests++
+		l.mu.Unlock()
+		return nil
+	}
+	l.refill()
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.stats.Requests++
+	l.stats.Waited += delay
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.stats.Requests--
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Observe adjusts the rate to the load signal of a response: it decreases on load
+// warnings and slowDown errors, and recovers slowly on clean responses.
+func (l *Limiter) Observe(s Signal) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+
+	switch s {
+	case SignalSlowDown:
+		l.stats.SlowDowns++
+		l.rate *= l.decrease * l.decrease
+		// The server is already throttling, don't send the saved up burst.
+		l.tokens = math.Min(l.tokens, 0)
+	case SignalLoadWarning:
+		l.stats.LoadWarnings++
+		l.rate *= l.decrease
+	default:
+		l.rate += l.baseRate * l.increase
+	}
+
+	l.rate = math.Max(l.minRate, math.Min(l.rate, l.baseRate))
+}
+
+// Stats returns a snapshot of the current budget.
+func (l *Limiter) Stats() Stats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+
+	s := l.stats
+	s.BaseRate = l.baseRate
+	s.Rate = l.rate
+	s.Burst = l.burst
+	s.Tokens = l.tokens
+	return s
+}
+
+// refill adds the tokens earned since the last refill at the current rate.
+// It must be called with mu held.
+func (l *Limiter) refill() {
+	now := l.now()
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+	if elapsed <= 0 {
+		return
+	}
+	l.tokens = math.Min(float64(l.burst), l.tokens+elapsed*l.rate)
+}
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(rate float64, burst int, opts ...Option) (*Limiter, *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLimiter(rate, burst, opts...)
+	l.now = func() time.Time { return now }
+	l.last = now
+	return l, &now
+}
+
+func TestSignalFor(t *testing.T) {
+	require.Equal(t, SignalOK, SignalFor("", ""))
+	require.Equal(t, SignalOK, SignalFor("", "actNotFound"))
+	require.Equal(t, SignalLoadWarning, SignalFor("load", ""))
+	require.Equal(t, SignalSlowDown, SignalFor("load", "slowDown"))
+	require.Equal(t, SignalSlowDown, SignalFor("", "tooBusy"))
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	l, now := newTestLimiter(10, 2)
+
+	// The burst goes through right away.
+	require.NoError(t, l.Wait(context.Background()))
+	require.NoError(t, l.Wait(context.Background()))
+	require.Equal(t, 0.0, l.Stats().Tokens)
+
+	// Tokens are earned back at the configured rate.
+	*now = now.Add(100 * time.Millisecond)
+	require.InDelta(t, 1.0, l.Stats().Tokens, 1e-9)
+	require.NoError(t, l.Wait(context.Background()))
+
+	// Without tokens, requests wait for the next one.
+	start := time.Now()
+	require.NoError(t, l.Wait(context.Background()))
+	require.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	stats := l.Stats()
+	require.Equal(t, uint64(4), stats.Requests)
+	require.Equal(t, 100*time.Millisecond, stats.Waited)
+}
+
+func TestLimiter_WaitCanceled(t *testing.T) {
+	l, _ := newTestLimiter(0.01, 1)
+	require.NoError(t, l.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+	require.Equal(t, uint64(1), l.Stats().Requests)
+	require.Equal(t, 0.0, l.Stats().Tokens)
+}
+
+func TestLimiter_Unlimited(t *testing.T) {
+	l, _ := newTestLimiter(0, 1)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, l.Wait(context.Background()))
+	}
+	require.Equal(t, uint64(10), l.Stats().Requests)
+}
+
+func TestLimiter_Observe(t *testing.T) {
+	l, _ := newTestLimiter(10, 5, WithMinRate(2))
+
+	l.Observe(SignalLoadWarning)
+	require.Equal(t, 5.0, l.Stats().Rate)
+
+	l.Observe(SignalSlowDown)
+	stats := l.Stats()
+	require.Equal(t, 2.0, stats.Rate, "rate is floored at the min rate")
+	require.Equal(t, 0.0, stats.Tokens, "the saved up burst is dropped")
+	require.Equal(t, uint64(1), stats.LoadWarnings)
+	require.Equal(t, uint64(1), stats.SlowDowns)
+
+	// Clean responses slowly bring the rate back to the configured one.
+	l.Observe(SignalOK)
+	require.InDelta(t, 2.5, l.Stats().Rate, 1e-9)
+	for i := 0; i < 100; i++ {
+		l.Observe(SignalOK)
+	}
+	require.Equal(t, 10.0, l.Stats().Rate)
+}
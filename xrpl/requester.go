@@ -0,0 +1,43 @@
+package xrpl
+
+import "context"
+
+// Request is the minimal contract every XRPL request fulfils, whatever the transport.
+type Request interface {
+	Method() string
+	Validate() error
+	APIVersion() int
+}
+
+// Response is the minimal contract of an XRPL response: its result can be decoded into a typed value.
+type Response interface {
+	GetResult(v any) error
+}
+
+// Requester sends a single request to an XRPL server and returns its response.
+// It is the only capability transport independent helpers, such as autofill, rely on.
+type Requester interface {
+	RequestContext(ctx context.Context, req Request) (Response, error)
+}
+
+// RequesterFunc adapts an ordinary function to the Requester interface.
+type RequesterFunc func(ctx context.Context, req Request) (Response, error)
+
+// RequestContext calls f(ctx, req).
+func (f RequesterFunc) RequestContext(ctx context.Context, req Request) (Response, error) {
+	return f(ctx, req)
+}
+
+// Query sends req through r and decodes the result into a new T.
+func Query[T any](ctx context.Context, r Requester, req Request) (*T, error) {
+	res, err := r.RequestContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var result T
+	err = res.GetResult(&result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/admin"
+	"github.com/Peersyst/xrpl-go/xrpl/rpc/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_LedgerAccept(t *testing.T) {
+	mc := &testutil.JSONRPCMockClient{}
+	mc.DoFunc = testutil.MockResponse(`{"result": {"ledger_current_index": 6643240, "status": "success"}}`, 200, mc)
+
+	cfg, err := NewClientConfig("http://localhost:5005/", WithHTTPClient(mc))
+	require.NoError(t, err)
+
+	res, err := NewClient(cfg).LedgerAccept()
+	require.NoError(t, err)
+	require.Equal(t, &admin.LedgerAcceptResponse{LedgerCurrentIndex: 6643240}, res)
+}
+
+func TestClient_AdminRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		opts    []ConfigOpt
+		allowed bool
+	}{
+		{name: "local URL", url: "http://127.0.0.1:5005/", allowed: true},
+		{name: "remote URL", url: "https://s.altnet.rippletest.net:51234/", allowed: false},
+		{name: "remote admin URL", url: "http://10.0.0.5:5005/", opts: []ConfigOpt{WithAdmin(true)}, allowed: true},
+		{name: "local non-admin URL", url: "http://localhost:5005/", opts: []ConfigOpt{WithAdmin(false)}, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := &testutil.JSONRPCMockClient{}
+			mc.DoFunc = func(req *http.Request) (*http.Response, error) {
+				mc.RequestCount++
+				return testutil.MockResponse(`{"result": {"message": "ripple server stopping"}}`, 200, mc)(req)
+			}
+
+			cfg, err := NewClientConfig(tt.url, append(tt.opts, WithHTTPClient(mc))...)
+			require.NoError(t, err)
+			client := NewClient(cfg)
+
+			_, err = client.Stop()
+			if !tt.allowed {
+				require.ErrorIs(t, err, xrpl.ErrAdminRequest)
+				require.Equal(t, 0, mc.RequestCount)
+
+				results, err := client.RequestBatch([]XRPLRequest{&admin.LedgerAcceptRequest{}})
+				require.NoError(t, err)
+				require.ErrorIs(t, results[0].Err, xrpl.ErrAdminRequest)
+				require.Equal(t, 0, mc.RequestCount)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 1, mc.RequestCount)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	account "github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/Peersyst/xrpl-go/xrpl/ratelimit"
+)
+
+// BatchResult is the outcome of one request of a batch: its response, or the error it
+// failed with.
+type BatchResult[T any] struct {
+	Response T
+	Err      error
+}
+
+type batchResponseID struct {
+	ID *int `json:"id"`
+}
+
+// RequestBatch sends several requests in a single HTTP round-trip, using the batch
+// method of rippled, and returns their results in the order of reqs.
+//
+// Requests that fail validation or that the server answers with an error fail on their
+// own, in their BatchResult. The returned error is only set when the batch as a whole
+// failed, e.g. because of a network error. The batch is retried as a whole, as the
+// configured RetryPolicy decides, and waits once for the rate limiter.
+// Middlewares are not run for batched requests.
+func (c *Client) RequestBatch(reqs []XRPLRequest) ([]BatchResult[XRPLResponse], error) {
+	return c.RequestBatchContext(context.Background(), reqs)
+}
+
+// RequestBatchContext is like RequestBatch but uses ctx to cancel the HTTP request, and any
+// retry backoff in between attempts, or to bound its duration.
+func (c *Client) RequestBatchContext(ctx context.Context, reqs []XRPLRequest) ([]BatchResult[XRPLResponse], error) {
+	results := make([]BatchResult[XRPLResponse], len(reqs))
+
+	// Only the valid requests are sent, their index in reqs is their ID.
+	var items []json.RawMessage
+	var ids []int
+	method := "batch"
+	idempotent := true
+	for i, req := range reqs {
+		if err := req.Validate(); err != nil {
+			results[i].Err = err
+			continue
+		}
+		if err := xrpl.CheckEndpoint(req, c.isAdmin()); err != nil {
+			results[i].Err = err
+			continue
+		}
+		if err := c.capabilities.Check(req); err != nil {
+			results[i].Err = err
+			continue
+		}
+		item, err := createBatchItem(req, i)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		items = append(items, item)
+		ids = append(ids, i)
+
+		if !IsIdempotent(req) {
+			method = req.Method()
+			idempotent = false
+		}
+	}
+	if len(items) == 0 {
+		return results, nil
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"method": "batch",
+		"params": items,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []json.RawMessage
+	err = c.retry(ctx, method, idempotent, func() (*Attempt, error) {
+		b, attempt, err := c.post(ctx, body)
+		if err != nil {
+			c.observeLoad(nil, attempt)
+			return attempt, err
+		}
+		// A batch answered with a single object failed as a whole.
+		if err := json.Unmarshal(b, &responses); err != nil {
+			_, decodeErr := decodeResponse(b)
+			if decodeErr == nil {
+				decodeErr = err
+			}
+			attempt.ErrorCode = xrpl.ErrorCode(decodeErr)
+			c.observeLoad(nil, attempt)
+			return attempt, decodeErr
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(responses) != len(items) {
+		return nil, ErrBatchResponseMismatch
+	}
+
+	signal := ratelimit.SignalOK
+	for pos, raw := range responses {
+		var id batchResponseID
+		if err := json.Unmarshal(raw, &id); err != nil {
+			return nil, err
+		}
+		i := ids[pos]
+		if id.ID != nil {
+			i = *id.ID
+		}
+		if i < 0 || i >= len(reqs) || results[i].Response != nil || results[i].Err != nil {
+			return nil, ErrBatchResponseMismatch
+		}
+
+		jr, err := decodeResponse(raw)
+		signal = max(signal, ratelimit.SignalFor(jr.warning(), xrpl.ErrorCode(err)))
+		if err != nil {
+			results[i].Err = xrpl.WrapClioError(reqs[i], err)
+			continue
+		}
+		results[i].Response = &jr
+	}
+	if c.cfg.rateLimiter != nil {
+		c.cfg.rateLimiter.Observe(signal)
+	}
+
+	return results, nil
+}
+
+// createBatchItem formats a request of a batch, identified by id.
+func createBatchItem(req XRPLRequest, id int) (json.RawMessage, error) {
+	b, err := createRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var item map[string]json.RawMessage
+	if err := json.Unmarshal(b, &item); err != nil {
+		return nil, err
+	}
+	item["id"], err = json.Marshal(id)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(item)
+}
+
+// batchQuery sends reqs in a batch and decodes every successful response into a T.
+func batchQuery[T any](ctx context.Context, c *Client, reqs []XRPLRequest) ([]BatchResult[*T], error) {
+	results, err := c.RequestBatchContext(ctx, reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	typed := make([]BatchResult[*T], len(results))
+	for i, res := range results {
+		if res.Err != nil {
+			typed[i].Err = res.Err
+			continue
+		}
+		var v T
+		if err := res.Response.GetResult(&v); err != nil {
+			typed[i].Err = err
+			continue
+		}
+		typed[i].Response = &v
+	}
+	return typed, nil
+}
+
+// GetAccountInfos retrieves information about several accounts in a single HTTP round-trip.
+// The results are in the order of reqs, each with its response or its own error.
+func (c *Client) GetAccountInfos(reqs []account.InfoRequest) ([]BatchResult[*account.InfoResponse], error) {
+	return c.GetAccountInfosContext(context.Background(), reqs)
+}
+
+// GetAccountInfosContext is like GetAccountInfos but uses ctx to cancel the request or bound its duration.
+func (c *Client) GetAccountInfosContext(ctx context.Context, reqs []account.InfoRequest) ([]BatchResult[*account.InfoResponse], error) {
+	batch := make([]XRPLRequest, len(reqs))
+	for i := range reqs {
+		batch[i] = &reqs[i]
+	}
+	return batchQuery[account.InfoResponse](ctx, c, batch)
+}
@@ -0,0 +1,181 @@
+package rpc
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/Peersyst/xrpl-go/xrpl/ratelimit"
+	"github.com/Peersyst/xrpl-go/xrpl/rpc/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_RequestBatch(t *testing.T) {
+	const addr = "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn"
+
+	tests := []struct {
+		name     string
+		reqs     []XRPLRequest
+		response string
+		wantBody string
+		want     []BatchResult[XRPLResponse]
+		wantErr  error
+	}{
+		{
+			name: "matched by id",
+			reqs: []XRPLRequest{
+				&account.ChannelsRequest{Account: addr},
+				&account.InfoRequest{Account: addr},
+			},
+			response: `[
+				{"id": 1, "result": {"account_data": {"Account": "` + addr + `"}}},
+				{"id": 0, "result": {"account": "` + addr + `", "channels": []}}
+			]`,
+			wantBody: `{"method": "batch", "params": [
+				{"id": 0, "method": "account_channels", "params": [{"account": "` + addr + `", "api_version": 2}]},
+				{"id": 1, "method": "account_info", "params": [{"account": "` + addr + `", "api_version": 2}]}
+			]}`,
+			want: []BatchResult[XRPLResponse]{
+				{Response: &Response{Result: AnyJSON{"account": addr, "channels": []any{}}}},
+				{Response: &Response{Result: AnyJSON{"account_data": map[string]any{"Account": addr}}}},
+			},
+		},
+		{
+			name: "matched by position",
+			reqs: []XRPLRequest{
+				&account.InfoRequest{Account: addr},
+				&account.InfoRequest{Account: "rInvalid"},
+			},
+			response: `[
+				{"result": {"account_data": {"Account": "` + addr + `"}}},
+				{"result": {"error": "actMalformed"}}
+			]`,
+			want: []BatchResult[XRPLResponse]{
+				{Response: &Response{Result: AnyJSON{"account_data": map[string]any{"Account": addr}}}},
+				{Err: &ClientError{ErrorString: "actMalformed", Code: "actMalformed", Result: map[string]any{"error": "actMalformed"}}},
+			},
+		},
+		{
+			name: "invalid requests are not sent",
+			reqs: []XRPLRequest{
+				&account.ChannelsRequest{},
+				&account.InfoRequest{Account: addr},
+			},
+			response: `[{"id": 1, "result": {"account_data": {"Account": "` + addr + `"}}}]`,
+			wantBody: `{"method": "batch", "params": [
+				{"id": 1, "method": "account_info", "params": [{"account": "` + addr + `", "api_version": 2}]}
+			]}`,
+			want: []BatchResult[XRPLResponse]{
+				{Err: account.ErrNoAccountID},
+				{Response: &Response{Result: AnyJSON{"account_data": map[string]any{"Account": addr}}}},
+			},
+		},
+		{
+			name:     "missing response",
+			reqs:     []XRPLRequest{&account.InfoRequest{Account: addr}, &account.InfoRequest{Account: addr}},
+			response: `[{"result": {}}]`,
+			wantErr:  ErrBatchResponseMismatch,
+		},
+		{
+			name:     "unknown id",
+			reqs:     []XRPLRequest{&account.InfoRequest{Account: addr}},
+			response: `[{"id": 5, "result": {}}]`,
+			wantErr:  ErrBatchResponseMismatch,
+		},
+		{
+			name:     "batch failed as a whole",
+			reqs:     []XRPLRequest{&account.InfoRequest{Account: addr}},
+			response: `{"result": {"error": "noPermission"}}`,
+			wantErr:  &ClientError{ErrorString: "noPermission", Code: "noPermission", Result: map[string]any{"error": "noPermission"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := &testutil.JSONRPCMockClient{}
+			var body string
+			mc.DoFunc = func(req *http.Request) (*http.Response, error) {
+				b, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+				body = string(b)
+				mc.RequestCount++
+				return testutil.MockResponse(tt.response, 200, mc)(req)
+			}
+
+			cfg, err := NewClientConfig("http://testnode/", WithHTTPClient(mc))
+			require.NoError(t, err)
+
+			results, err := NewClient(cfg).RequestBatch(tt.reqs)
+			require.Equal(t, 1, mc.RequestCount)
+			if tt.wantErr != nil {
+				require.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, results)
+			if tt.wantBody != "" {
+				require.JSONEq(t, tt.wantBody, body)
+			}
+		})
+	}
+}
+
+func TestClient_RequestBatchWithoutValidRequests(t *testing.T) {
+	mc := &testutil.JSONRPCMockClient{}
+	cfg, err := NewClientConfig("http://testnode/", WithHTTPClient(mc))
+	require.NoError(t, err)
+
+	results, err := NewClient(cfg).RequestBatch([]XRPLRequest{&account.ChannelsRequest{}})
+	require.NoError(t, err)
+	require.Equal(t, []BatchResult[XRPLResponse]{{Err: account.ErrNoAccountID}}, results)
+	require.Equal(t, 0, mc.RequestCount)
+}
+
+func TestClient_RequestBatchRateLimited(t *testing.T) {
+	mc := &testutil.JSONRPCMockClient{}
+	mc.DoFunc = testutil.MockResponse(`[
+		{"id": 0, "result": {"account_data": {}}},
+		{"id": 1, "result": {"account_data": {}, "warning": "load"}}
+	]`, 200, mc)
+
+	l := ratelimit.NewLimiter(1000, 10)
+	cfg, err := NewClientConfig("http://testnode/", WithHTTPClient(mc), WithRateLimiter(l))
+	require.NoError(t, err)
+
+	_, err = NewClient(cfg).RequestBatch([]XRPLRequest{
+		&account.InfoRequest{Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn"},
+		&account.InfoRequest{Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn"},
+	})
+	require.NoError(t, err)
+
+	stats := l.Stats()
+	require.Equal(t, uint64(1), stats.Requests)
+	require.Equal(t, uint64(1), stats.LoadWarnings)
+}
+
+func TestClient_GetAccountInfos(t *testing.T) {
+	mc := &testutil.JSONRPCMockClient{}
+	mc.DoFunc = testutil.MockResponse(`[
+		{"id": 0, "result": {"account_data": {"Account": "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn", "Balance": "1000", "Sequence": 5}, "validated": true}},
+		{"id": 1, "result": {"error": "actNotFound"}}
+	]`, 200, mc)
+
+	cfg, err := NewClientConfig("http://testnode/", WithHTTPClient(mc))
+	require.NoError(t, err)
+
+	results, err := NewClient(cfg).GetAccountInfos([]account.InfoRequest{
+		{Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn"},
+		{Account: "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	require.NoError(t, results[0].Err)
+	require.Equal(t, "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn", results[0].Response.AccountData.Account.String())
+	require.Equal(t, uint32(5), results[0].Response.AccountData.Sequence)
+	require.True(t, results[0].Response.Validated)
+
+	require.Nil(t, results[1].Response)
+	require.Equal(t, "actNotFound", results[1].Err.(*ClientError).ErrorCode())
+}
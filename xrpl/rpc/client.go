@@ -3,105 +3,271 @@ package rpc
 import (
 	"bytes"
 	"context"
+	"io"
 	"net/http"
 	"time"
 
 	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/binary-codec/definitions"
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/autofill"
 	"github.com/Peersyst/xrpl-go/xrpl/common"
-	"github.com/Peersyst/xrpl-go/xrpl/hash"
-	account "github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/Peersyst/xrpl-go/xrpl/queries"
 	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
-	rpctypes "github.com/Peersyst/xrpl-go/xrpl/rpc/types"
+	"github.com/Peersyst/xrpl-go/xrpl/ratelimit"
+	"github.com/Peersyst/xrpl-go/xrpl/submission"
 	"github.com/Peersyst/xrpl-go/xrpl/transaction"
-	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
 
 	"github.com/Peersyst/xrpl-go/xrpl/wallet"
 )
 
+var _ xrpl.Client = (*Client)(nil)
+var _ xrpl.Admin = (*Client)(nil)
+
 type Client struct {
-	cfg *Config
+	// Typed queries and iterators, sent through the client.
+	queries.Methods
+
+	cfg          *Config
+	definitions  xrpl.DefinitionsCache
+	capabilities xrpl.CapabilitiesCache
 
 	NetworkID uint32
 }
 
 func NewClient(cfg *Config) *Client {
-	return &Client{
+	c := &Client{
 		cfg: cfg,
 	}
+	c.Methods = queries.NewMethods(c.Requester())
+	return c
+}
+
+// Requester returns the client as an xrpl.Requester, so transport independent helpers
+// can send requests through it. Requests must implement XRPLRequest.
+func (c *Client) Requester() xrpl.Requester {
+	return xrpl.RequesterFunc(func(ctx context.Context, req xrpl.Request) (xrpl.Response, error) {
+		r, ok := req.(XRPLRequest)
+		if !ok {
+			return nil, ErrUnsupportedRequest
+		}
+		return c.RequestContext(ctx, r)
+	})
+}
+
+// Definitions returns the binary codec definitions of the server, fetched with the
+// server_definitions method. They are cached, and only fetched again when the server
+// reports a new hash.
+func (c *Client) Definitions(ctx context.Context) (*definitions.Definitions, error) {
+	return c.definitions.Get(ctx, c.Requester())
+}
+
+// Codec returns a codec that encodes and decodes with the definitions of the server,
+// see Definitions.
+func (c *Client) Codec(ctx context.Context) (*binarycodec.Codec, error) {
+	return c.definitions.Codec(ctx, c.Requester())
+}
+
+// Capabilities tells whether the server is a Clio or a rippled server and, for Clio, its
+// forwarding and cache state. The server is probed with server_info the first time, after
+// which the client refuses Clio-only requests to rippled servers with an
+// xrpl.ClioOnlyError without sending them. Use xrpl.ProbeCapabilities for a fresh state.
+func (c *Client) Capabilities(ctx context.Context) (*xrpl.Capabilities, error) {
+	return c.capabilities.Get(ctx, c.Requester())
+}
+
+// isAdmin reports whether the server accepts admin methods from the client.
+func (c *Client) isAdmin() bool {
+	if c.cfg.admin != nil {
+		return *c.cfg.admin
+	}
+	return xrpl.IsLocalURL(c.cfg.URL)
+}
+
+// autofiller returns the autofill engine configured with the current client settings.
+func (c *Client) autofiller() *autofill.Engine {
+	return autofill.NewEngine(c.Requester(), autofill.Config{
+		NetworkID:   c.NetworkID,
+		MaxFeeXRP:   c.cfg.maxFeeXRP,
+		FeeCushion:  c.cfg.feeCushion,
+		FeeStrategy: c.cfg.feeStrategy,
+		FeeReporter: c.cfg.feeReporter,
+		Sequences:   c.cfg.sequences,
+	})
 }
 
 // Request sends a request to the XRPL server and returns the response and any error encountered.
 func (c *Client) Request(reqParams XRPLRequest) (XRPLResponse, error) {
+	return c.RequestContext(context.Background(), reqParams)
+}
+
+// RequestContext is like Request but uses ctx to cancel the HTTP request, and any
+// retry backoff in between attempts, or to bound its duration.
+//
+// The request goes through the configured middlewares first. Failed attempts are
+// retried as the configured RetryPolicy decides. Requests that are not idempotent,
+// such as submit, are only retried when the failure shows the server did not process them.
+func (c *Client) RequestContext(ctx context.Context, reqParams XRPLRequest) (XRPLResponse, error) {
+	res, err := xrpl.Chain(c.do, c.cfg.middlewares...)(ctx, reqParams)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// do is the last handler of the middleware chain: it sends the request to the server.
+func (c *Client) do(ctx context.Context, req xrpl.Request) (xrpl.Response, error) {
+	reqParams, ok := req.(XRPLRequest)
+	if !ok {
+		return nil, ErrUnsupportedRequest
+	}
 
 	err := reqParams.Validate()
 	if err != nil {
 		return nil, err
 	}
+	if err := xrpl.CheckEndpoint(reqParams, c.isAdmin()); err != nil {
+		return nil, err
+	}
+	if err := c.capabilities.Check(reqParams); err != nil {
+		return nil, err
+	}
 
 	body, err := createRequest(reqParams)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.cfg.URL, bytes.NewReader(body))
+	var res *Response
+	err = c.retry(ctx, reqParams.Method(), IsIdempotent(reqParams), func() (*Attempt, error) {
+		r, attempt, err := c.send(ctx, body)
+		c.observeLoad(r, attempt)
+		res = r
+		return attempt, err
+	})
 	if err != nil {
-		return nil, err
+		return nil, xrpl.WrapClioError(reqParams, err)
 	}
+	return res, nil
+}
 
-	// add timeout context to prevent hanging
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
-	defer cancel()
-	req = req.WithContext(ctx)
+// retry calls try, after waiting for the rate limiter, until it succeeds or the retry
+// policy gives up. try returns the failed attempt along with its error, or a nil attempt
+// if the request must not be retried. Requests that are not idempotent are only retried
+// when the failure shows the server did not process them.
+func (c *Client) retry(ctx context.Context, method string, idempotent bool, try func() (*Attempt, error)) error {
+	start := time.Now()
+	for n := 1; ; n++ {
+		if c.cfg.rateLimiter != nil {
+			if err := c.cfg.rateLimiter.Wait(ctx); err != nil {
+				return err
+			}
+		}
 
-	req.Header = c.cfg.Headers
+		attempt, err := try()
+		if err == nil {
+			return nil
+		}
+		if attempt == nil || ctx.Err() != nil {
+			return err
+		}
 
-	var response *http.Response
+		attempt.Number = n
+		attempt.Elapsed = time.Since(start)
+		attempt.Method = method
+		attempt.Err = err
+		if !idempotent && !attempt.unprocessed() {
+			return err
+		}
 
-	response, err = c.cfg.HTTPClient.Do(req)
-	if err != nil || response == nil {
-		return nil, err
+		delay, ok := c.cfg.retryPolicy.Backoff(*attempt)
+		if !ok {
+			return err
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
 	}
+}
 
-	// allow client to reuse persistent connection
-	defer response.Body.Close()
-
-	// Check for service unavailable response and retry if so
-	if response.StatusCode == 503 {
+// observeLoad reports the load signal of a response to the rate limiter, if any.
+// Attempts that got no response carry no signal.
+func (c *Client) observeLoad(res *Response, attempt *Attempt) {
+	if c.cfg.rateLimiter == nil {
+		return
+	}
+	switch {
+	case res != nil:
+		c.cfg.rateLimiter.Observe(ratelimit.SignalFor(res.warning(), ""))
+	case attempt == nil || attempt.StatusCode == 0:
+		return
+	case attempt.StatusCode == http.StatusTooManyRequests || attempt.StatusCode == http.StatusServiceUnavailable:
+		c.cfg.rateLimiter.Observe(ratelimit.SignalSlowDown)
+	default:
+		c.cfg.rateLimiter.Observe(ratelimit.SignalFor("", attempt.ErrorCode))
+	}
+}
 
-		maxRetries := 3
-		backoffDuration := 1 * time.Second
+// send makes a single attempt to send the request body.
+// On failure it also returns the attempt, unless the request could not be built.
+func (c *Client) send(ctx context.Context, body []byte) (*Response, *Attempt, error) {
+	b, attempt, err := c.post(ctx, body)
+	if err != nil {
+		return nil, attempt, err
+	}
 
-		for i := 0; i < maxRetries; i++ {
-			time.Sleep(backoffDuration)
+	jr, err := decodeResponse(b)
+	if err != nil {
+		attempt.ErrorCode = xrpl.ErrorCode(err)
+		return nil, attempt, err
+	}
 
-			// Make request again after waiting
-			response, err = c.cfg.HTTPClient.Do(req)
-			if err != nil {
-				return nil, err
-			}
+	return &jr, nil, nil
+}
 
-			if response.StatusCode != 503 {
-				break
-			}
+// post sends body to the server and returns the body of its response.
+// On failure it also returns the attempt, unless the request could not be built.
+func (c *Client) post(ctx context.Context, body []byte) ([]byte, *Attempt, error) {
+	// A new request is built every time, as sending it drains its body.
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
 
-			// Increase backoff duration for the next retry
-			backoffDuration *= 2
+	req.Header = c.cfg.Headers
+	if h := headersFromContext(ctx); h != nil {
+		req.Header = req.Header.Clone()
+		for k, v := range h {
+			req.Header[k] = v
 		}
+	}
 
-		if response.StatusCode == 503 {
-			// Return service unavailable error here after retry 3 times
-			return nil, &ClientError{ErrorString: "Server is overloaded, rate limit exceeded"}
-		}
+	response, err := c.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, &Attempt{}, err
+	}
+	if response == nil {
+		return nil, &Attempt{}, ErrEmptyResponse
+	}
+
+	// allow client to reuse persistent connection
+	defer response.Body.Close()
 
+	attempt := &Attempt{
+		StatusCode: response.StatusCode,
+		RetryAfter: parseRetryAfter(response.Header.Get("Retry-After"), time.Now()),
 	}
 
-	var jr Response
-	jr, err = checkForError(response)
-	if err != nil {
-		return nil, err
+	if response.StatusCode == http.StatusServiceUnavailable {
+		_, _ = io.Copy(io.Discard, response.Body)
+		return nil, attempt, &ClientError{ErrorString: "Server is overloaded, rate limit exceeded", StatusCode: response.StatusCode}
 	}
 
-	return &jr, nil
+	b, err := readBody(response)
+	if err != nil {
+		return nil, attempt, err
+	}
+	return b, attempt, nil
 }
 
 // SubmitTxBlob sends a pre-signed transaction blob to the server.
@@ -109,22 +275,12 @@ func (c *Client) Request(reqParams XRPLRequest) (XRPLResponse, error) {
 // or a signing public key, and then submits it using a submission request.
 // The failHard flag determines how strictly errors are handled.
 func (c *Client) SubmitTxBlob(txBlob string, failHard bool) (*requests.SubmitResponse, error) {
-	tx, err := binarycodec.Decode(txBlob)
-	if err != nil {
-		return nil, err
-	}
-
-	_, okTxSig := tx["TxSignature"].(string)
-	_, okPubKey := tx["SigningPubKey"].(string)
-
-	if !okTxSig && !okPubKey {
-		return nil, ErrMissingTxSignatureOrSigningPubKey
-	}
+	return c.SubmitTxBlobContext(context.Background(), txBlob, failHard)
+}
 
-	return c.submitRequest(&requests.SubmitRequest{
-		TxBlob:   txBlob,
-		FailHard: failHard,
-	})
+// SubmitTxBlobContext is like SubmitTxBlob but uses ctx to cancel the request or bound its duration.
+func (c *Client) SubmitTxBlobContext(ctx context.Context, txBlob string, failHard bool) (*requests.SubmitResponse, error) {
+	return c.autofiller().SubmitTxBlob(ctx, txBlob, failHard)
 }
 
 // SubmitTxBlobAndWait sends a pre-signed transaction blob to the server,
@@ -132,161 +288,90 @@ func (c *Client) SubmitTxBlob(txBlob string, failHard bool) (*requests.SubmitRes
 // and then waits until the transaction is confirmed in a ledger. It returns
 // the transaction response if the submission is successful.
 func (c *Client) SubmitTxBlobAndWait(txBlob string, failHard bool) (*requests.TxResponse, error) {
+	return c.SubmitTxBlobAndWaitContext(context.Background(), txBlob, failHard)
+}
+
+// SubmitTxBlobAndWaitContext is like SubmitTxBlobAndWait but uses ctx to cancel the
+// submission and the wait for ledger confirmation.
+func (c *Client) SubmitTxBlobAndWaitContext(ctx context.Context, txBlob string, failHard bool) (*requests.TxResponse, error) {
 	tx, err := binarycodec.Decode(txBlob)
 	if err != nil {
 		return nil, err
 	}
 
-	lastLedgerSequence, ok := tx["LastLedgerSequence"].(uint32)
-	if !ok {
-
+	if _, ok := tx["LastLedgerSequence"].(uint32); !ok {
 		return nil, ErrMissingLastLedgerSequenceInTransaction
-
 	}
 
-	txResponse, err := c.SubmitTxBlob(txBlob, failHard)
+	rec, err := submission.NewManager(c.Requester(), submission.Config{
+		PollInterval: c.cfg.retryDelay,
+		MaxChecks:    c.cfg.maxRetries,
+		FailHard:     failHard,
+	}).SubmitAndWait(ctx, txBlob)
 	if err != nil {
 		return nil, err
 	}
-
-	if txResponse.EngineResult != "tesSUCCESS" {
-		return nil, &ClientError{ErrorString: "transaction failed to submit with engine result: " + txResponse.EngineResult}
-	}
-
-	txHash, err := hash.SignTxBlob(txBlob)
-	if err != nil {
+	if err := rec.Result.Err(); err != nil {
 		return nil, err
 	}
-
-	return c.waitForTransaction(txHash, lastLedgerSequence)
+	return rec.Result.Tx, nil
 }
 
 // SubmitTx signs the transaction (if necessary) and submits it to the server
 // via a submission request. It applies the provided submit options to decide whether
 // to autofill missing fields and enforce failHard mode during submission.
-func (c *Client) SubmitTx(tx transaction.FlatTransaction, opts *rpctypes.SubmitOptions) (*requests.SubmitResponse, error) {
-	txBlob, err := c.getSignedTx(tx, opts.Autofill, opts.Wallet)
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) SubmitTx(tx transaction.FlatTransaction, opts *xrpl.SubmitOptions) (*requests.SubmitResponse, error) {
+	return c.SubmitTxContext(context.Background(), tx, opts)
+}
 
-	return c.submitRequest(&requests.SubmitRequest{
-		TxBlob:   txBlob,
-		FailHard: opts.FailHard,
-	})
+// SubmitTxContext is like SubmitTx but uses ctx to cancel autofill and submission.
+func (c *Client) SubmitTxContext(ctx context.Context, tx transaction.FlatTransaction, opts *xrpl.SubmitOptions) (*requests.SubmitResponse, error) {
+	return c.autofiller().SubmitTx(ctx, tx, opts)
 }
 
 // SubmitTxAndWait prepares a transaction by ensuring it is fully signed,
 // submits it to the server, and waits for ledger confirmation.
 // It validates that the transaction's EngineResult is successful before returning
 // the transaction response.
-func (c *Client) SubmitTxAndWait(tx transaction.FlatTransaction, opts *rpctypes.SubmitOptions) (*requests.TxResponse, error) {
-	// Get the signed transaction blob.
-	txBlob, err := c.getSignedTx(tx, opts.Autofill, opts.Wallet)
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) SubmitTxAndWait(tx transaction.FlatTransaction, opts *xrpl.SubmitOptions) (*requests.TxResponse, error) {
+	return c.SubmitTxAndWaitContext(context.Background(), tx, opts)
+}
 
-	// Delegate to SubmitTxBlobAndWait to handle submission, engine result check,
-	// ledger sequence validation, and waiting for confirmation.
-	return c.SubmitTxBlobAndWait(txBlob, opts.FailHard)
+// SubmitTxAndWaitContext is like SubmitTxAndWait but uses ctx to cancel autofill,
+// submission and the wait for ledger confirmation.
+func (c *Client) SubmitTxAndWaitContext(ctx context.Context, tx transaction.FlatTransaction, opts *xrpl.SubmitOptions) (*requests.TxResponse, error) {
+	return c.autofiller().SubmitTxAndWait(ctx, tx, opts, c.SubmitTxBlobAndWaitContext)
 }
 
 func (c *Client) SubmitMultisigned(txBlob string, failHard bool) (*requests.SubmitMultisignedResponse, error) {
-	tx, err := binarycodec.Decode(txBlob)
-	if err != nil {
-		return nil, err
-	}
-	signers, okSigners := tx["Signers"].([]interface{})
-
-	if okSigners && len(signers) > 0 {
-		for _, sig := range signers {
-			signer := sig.(map[string]any)
-			signerData := signer["Signer"].(map[string]any)
-			if signerData["SigningPubKey"] == "" && signerData["TxnSignature"] == "" {
-				return nil, ErrSignerDataIsEmpty
-			}
-		}
-	}
+	return c.SubmitMultisignedContext(context.Background(), txBlob, failHard)
+}
 
-	return c.submitMultisignedRequest(&requests.SubmitMultisignedRequest{
-		Tx:       tx,
-		FailHard: failHard,
-	})
+// SubmitMultisignedContext is like SubmitMultisigned but uses ctx to cancel the request or bound its duration.
+func (c *Client) SubmitMultisignedContext(ctx context.Context, txBlob string, failHard bool) (*requests.SubmitMultisignedResponse, error) {
+	return c.autofiller().SubmitMultisigned(ctx, txBlob, failHard)
 }
 
 // Autofill fills in the missing fields in a transaction.
 func (c *Client) Autofill(tx *transaction.FlatTransaction) error {
-	if err := c.setValidTransactionAddresses(tx); err != nil {
-		return err
-	}
-
-	err := c.setTransactionFlags(tx)
-	if err != nil {
-		return err
-	}
+	return c.AutofillContext(context.Background(), tx)
+}
 
-	if _, ok := (*tx)["NetworkID"]; !ok {
-		if c.NetworkID != 0 {
-			(*tx)["NetworkID"] = c.NetworkID
-		}
-	}
-	if _, ok := (*tx)["Sequence"]; !ok {
-		err := c.setTransactionNextValidSequenceNumber(tx)
-		if err != nil {
-			return err
-		}
-	}
-	if _, ok := (*tx)["Fee"]; !ok {
-		err := c.calculateFeePerTransactionType(tx, 0)
-		if err != nil {
-			return err
-		}
-	}
-	if _, ok := (*tx)["LastLedgerSequence"]; !ok {
-		err := c.setLastLedgerSequence(tx)
-		if err != nil {
-			return err
-		}
-	}
-	if txType, ok := (*tx)["TransactionType"].(string); ok {
-		if acc, ok := (*tx)["Account"].(types.Address); txType == transaction.AccountDeleteTx.String() && ok {
-			err := c.checkAccountDeleteBlockers(acc)
-			if err != nil {
-				return err
-			}
-		}
-		if txType == transaction.PaymentTx.String() {
-			err := c.checkPaymentAmounts(tx)
-			if err != nil {
-				return err
-			}
-		}
-		if txType == transaction.BatchTx.String() {
-			err := c.autofillRawTransactions(tx)
-			if err != nil {
-				return err
-			}
-		}
-	}
-	return nil
+// AutofillContext is like Autofill but uses ctx to cancel the queries it issues.
+func (c *Client) AutofillContext(ctx context.Context, tx *transaction.FlatTransaction) error {
+	return c.autofiller().Autofill(ctx, tx)
 }
 
 // AutofillMultisigned fills in the missing fields in a multisigned transaction.
 // This function is used to fill in the missing fields in a multisigned transaction.
 // It fills in the missing fields in the transaction and calculates the fee per number of signers.
 func (c *Client) AutofillMultisigned(tx *transaction.FlatTransaction, nSigners uint64) error {
-	err := c.Autofill(tx)
-	if err != nil {
-		return err
-	}
-
-	err = c.calculateFeePerTransactionType(tx, nSigners)
-	if err != nil {
-		return err
-	}
+	return c.AutofillMultisignedContext(context.Background(), tx, nSigners)
+}
 
-	return nil
+// AutofillMultisignedContext is like AutofillMultisigned but uses ctx to cancel the queries it issues.
+func (c *Client) AutofillMultisignedContext(ctx context.Context, tx *transaction.FlatTransaction, nSigners uint64) error {
+	return c.autofiller().AutofillMultisigned(ctx, tx, nSigners)
 }
 
 // FaucetProvider returns the faucet provider for the client.
@@ -307,82 +392,3 @@ func (c *Client) FundWallet(wallet *wallet.Wallet) error {
 
 	return nil
 }
-
-func (c *Client) autofillRawTransactions(tx *transaction.FlatTransaction) error {
-	needsNetworkID, err := c.txNeedsNetworkID()
-	if err != nil {
-		return err
-	}
-
-	rawTxs, ok := (*tx)["RawTransactions"].([]map[string]any)
-	if !ok {
-		return ErrRawTransactionsFieldIsNotAnArray
-	}
-
-	accountSeq := make(map[string]uint32, len(rawTxs))
-
-	for _, rawTx := range rawTxs {
-		innerRawTx, ok := rawTx["RawTransaction"].(map[string]any)
-		if !ok {
-			return ErrRawTransactionFieldIsNotAnObject
-		}
-
-		// Validate `Fee` field
-		if innerRawTx["Fee"] == nil {
-			innerRawTx["Fee"] = "0"
-		} else if innerRawTx["Fee"] != "0" {
-			return types.ErrBatchInnerTransactionInvalid
-		}
-
-		// Validate `SigningPubKey` field
-		if innerRawTx["SigningPubKey"] == nil {
-			innerRawTx["SigningPubKey"] = ""
-		} else if innerRawTx["SigningPubKey"] != "" {
-			return ErrSigningPubKeyFieldMustBeEmpty
-		}
-
-		// Validate `TxnSignature` field
-		if innerRawTx["TxnSignature"] != nil {
-			return ErrTxnSignatureFieldMustBeEmpty
-		}
-		if innerRawTx["Signers"] != nil {
-			return ErrSignersFieldMustBeEmpty
-		}
-
-		// Validate `NetworkID` field
-		if innerRawTx["NetworkID"] == nil && needsNetworkID {
-			innerRawTx["NetworkID"] = c.NetworkID
-		}
-
-		// Validate `Sequence` field
-		if innerRawTx["Sequence"] == nil && innerRawTx["TicketSequence"] == nil {
-
-			acc, ok := innerRawTx["Account"].(string)
-			if !ok {
-				return ErrAccountFieldIsNotAString
-			}
-
-			if accountSeq[acc] != 0 {
-				innerRawTx["Sequence"] = accountSeq[acc]
-				accountSeq[acc]++
-			} else {
-				accountInfo, err := c.GetAccountInfo(&account.InfoRequest{
-					Account: types.Address(acc),
-				})
-				if err != nil {
-					return err
-				}
-				var seq uint32
-				if innerRawTx["Account"] == (*tx)["Account"] {
-					seq = accountInfo.AccountData.Sequence + 1
-				} else {
-					seq = accountInfo.AccountData.Sequence
-				}
-				accountSeq[acc] = seq + 1
-				innerRawTx["Sequence"] = seq
-			}
-		}
-	}
-
-	return nil
-}
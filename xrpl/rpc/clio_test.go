@@ -0,0 +1,88 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/clio"
+	cliotypes "github.com/Peersyst/xrpl-go/xrpl/queries/clio/types"
+	"github.com/Peersyst/xrpl-go/xrpl/rpc/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+const testNFTokenID = "00080000B4F4AFC5FBCBD76873F18006173D2193467D3EE70000099B00000000"
+
+func newCountingClient(t *testing.T, responses ...string) (*Client, *testutil.JSONRPCMockClient) {
+	t.Helper()
+	mc := &testutil.JSONRPCMockClient{}
+	mc.DoFunc = func(req *http.Request) (*http.Response, error) {
+		res := responses[mc.RequestCount]
+		mc.RequestCount++
+		return testutil.MockResponse(res, 200, mc)(req)
+	}
+	cfg, err := NewClientConfig("http://testnode/", WithHTTPClient(mc))
+	require.NoError(t, err)
+	return NewClient(cfg), mc
+}
+
+func TestClient_ClioOnlyOnRippled(t *testing.T) {
+	t.Run("probed", func(t *testing.T) {
+		client, mc := newCountingClient(t, `{"result": {"info": {"build_version": "2.3.0"}}}`)
+
+		caps, err := client.Capabilities(context.Background())
+		require.NoError(t, err)
+		require.False(t, caps.Clio)
+
+		_, err = client.GetNFTInfo(&clio.NFTInfoRequest{NFTokenID: testNFTokenID})
+		require.Equal(t, &xrpl.ClioOnlyError{Method: "nft_info"}, err)
+		require.Equal(t, 1, mc.RequestCount)
+
+		results, err := client.RequestBatch([]XRPLRequest{&clio.MPTHoldersRequest{MPTIssuanceID: "000004C463C52827307480341125DA0577DEFC38405B0E3E"}})
+		require.NoError(t, err)
+		require.ErrorIs(t, results[0].Err, xrpl.ErrClioOnly)
+		require.Equal(t, 1, mc.RequestCount)
+	})
+
+	t.Run("not probed", func(t *testing.T) {
+		client, mc := newCountingClient(t, `{"result": {"error": "unknownCmd", "error_message": "Unknown method.", "status": "error"}}`)
+
+		_, err := client.GetNFTHistory(&clio.NFTHistoryRequest{NFTokenID: testNFTokenID})
+		require.ErrorIs(t, err, xrpl.ErrClioOnly)
+		require.Equal(t, 1, mc.RequestCount)
+	})
+}
+
+func TestClient_GetMPTHolders(t *testing.T) {
+	client, _ := newCountingClient(t,
+		`{"result": {"info": {"clio_version": "2.3.1", "cache": {"size": 10, "is_full": false, "latest_ledger_seq": 83}}}}`,
+		`{"result": {
+			"mpt_issuance_id": "000004C463C52827307480341125DA0577DEFC38405B0E3E",
+			"mptokens": [{"account": "rfyWeZWZbPS5bLAbxqZGkJCsYEtt8ByFzY", "flags": 0, "mpt_amount": "20", "mptoken_index": "36D91DEE5EFE4A93119A8B84C944A528F2B444329F3846E49FE921040DE17E65"}],
+			"ledger_index": 83,
+			"validated": true
+		}}`,
+	)
+
+	caps, err := client.Capabilities(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, &xrpl.Capabilities{
+		Clio:    true,
+		Version: "2.3.1",
+		Cache:   &cliotypes.Cache{Size: 10, LatestLedgerSeq: 83},
+	}, caps)
+
+	res, err := client.GetMPTHolders(&clio.MPTHoldersRequest{MPTIssuanceID: "000004C463C52827307480341125DA0577DEFC38405B0E3E"})
+	require.NoError(t, err)
+	require.Equal(t, &clio.MPTHoldersResponse{
+		MPTIssuanceID: "000004C463C52827307480341125DA0577DEFC38405B0E3E",
+		MPTokens: []cliotypes.MPTHolder{{
+			Account:      "rfyWeZWZbPS5bLAbxqZGkJCsYEtt8ByFzY",
+			MPTAmount:    "20",
+			MPTokenIndex: "36D91DEE5EFE4A93119A8B84C944A528F2B444329F3846E49FE921040DE17E65",
+		}},
+		LedgerIndex: 83,
+		Validated:   true,
+	}, res)
+}
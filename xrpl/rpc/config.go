@@ -6,7 +6,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/autofill"
 	"github.com/Peersyst/xrpl-go/xrpl/common"
+	"github.com/Peersyst/xrpl-go/xrpl/ratelimit"
+	"github.com/Peersyst/xrpl-go/xrpl/sequence"
 )
 
 var ErrEmptyURL = errors.New("empty port and IP provided")
@@ -20,17 +24,34 @@ type Config struct {
 	URL        string
 	Headers    map[string][]string
 
+	// Middleware config
+	middlewares []xrpl.Middleware
+
 	// Retry config
+	retryPolicy RetryPolicy
+
+	// Rate limit config
+	rateLimiter *ratelimit.Limiter
+
+	// Transaction wait config
 	maxRetries int
 	retryDelay time.Duration
 
 	// Fee config
-	maxFeeXRP  float32
-	feeCushion float32
+	maxFeeXRP   float32
+	feeCushion  float32
+	feeStrategy autofill.FeeStrategy
+	feeReporter autofill.FeeReporter
+
+	// Sequence config
+	sequences *sequence.Allocator
 
 	// Faucet config
 	faucetProvider common.FaucetProvider
 
+	// Admin config, nil if the URL decides
+	admin *bool
+
 	timeout time.Duration
 }
 
@@ -42,6 +63,62 @@ func WithHTTPClient(cl HTTPClient) ConfigOpt {
 	}
 }
 
+// WithMiddleware adds middlewares run around every request, in the given order: the
+// first one sees the request first. It can be passed several times.
+// Default: no middleware
+func WithMiddleware(mws ...xrpl.Middleware) ConfigOpt {
+	return func(c *Config) {
+		c.middlewares = append(c.middlewares, mws...)
+	}
+}
+
+// WithRetryPolicy sets the policy deciding which failed requests are sent again.
+// Use NoRetry to disable retries.
+// Default: DefaultRetryPolicy()
+func WithRetryPolicy(p RetryPolicy) ConfigOpt {
+	return func(c *Config) {
+		c.retryPolicy = p
+	}
+}
+
+// WithRateLimiter sets the limiter every request, including retries, waits for before
+// being sent. The limiter backs off when responses carry load warnings, and can be
+// shared by several clients talking to the same server.
+// Default: nil, no rate limit
+func WithRateLimiter(l *ratelimit.Limiter) ConfigOpt {
+	return func(c *Config) {
+		c.rateLimiter = l
+	}
+}
+
+// WithMaxRetries sets the number of times SubmitTxBlobAndWait checks a transaction
+// whose LastLedgerSequence has passed, before giving up if the server lacks the
+// ledgers proving it expired.
+// Default: 10
+func WithMaxRetries(maxRetries int) ConfigOpt {
+	return func(c *Config) {
+		c.maxRetries = maxRetries
+	}
+}
+
+// WithRetryDelay sets the delay between checks of a submitted transaction.
+// Default: 1 second
+func WithRetryDelay(retryDelay time.Duration) ConfigOpt {
+	return func(c *Config) {
+		c.retryDelay = retryDelay
+	}
+}
+
+// WithSequenceAllocator sets the allocator handing out the sequences of autofilled
+// transactions, instead of querying account_info for each of them. Share it with the
+// other clients submitting for the same accounts.
+// Default: nil
+func WithSequenceAllocator(a *sequence.Allocator) ConfigOpt {
+	return func(c *Config) {
+		c.sequences = a
+	}
+}
+
 func WithMaxFeeXRP(maxFeeXRP float32) ConfigOpt {
 	return func(c *Config) {
 		c.maxFeeXRP = maxFeeXRP
@@ -54,12 +131,39 @@ func WithFeeCushion(feeCushion float32) ConfigOpt {
 	}
 }
 
+// WithFeeStrategy sets the strategy estimating the network fee of autofilled transactions,
+// e.g. autofill.OpenLedgerFeeStrategy to skip the queue during fee spikes. The fee is
+// still capped by WithMaxFeeXRP.
+// Default: autofill.BaseFeeStrategy with the fee cushion
+func WithFeeStrategy(s autofill.FeeStrategy) ConfigOpt {
+	return func(c *Config) {
+		c.feeStrategy = s
+	}
+}
+
+// WithFeeReporter sets the function called with every fee set on autofilled transactions,
+// along with the reason the fee strategy chose it, e.g. to log it.
+func WithFeeReporter(r autofill.FeeReporter) ConfigOpt {
+	return func(c *Config) {
+		c.feeReporter = r
+	}
+}
+
 func WithFaucetProvider(fp common.FaucetProvider) ConfigOpt {
 	return func(c *Config) {
 		c.faucetProvider = fp
 	}
 }
 
+// WithAdmin sets whether the server accepts admin methods from the client, such as
+// ledger_accept. Admin requests are refused by the client otherwise.
+// Default: true if the URL is a local one (localhost, a loopback or unspecified address)
+func WithAdmin(admin bool) ConfigOpt {
+	return func(c *Config) {
+		c.admin = &admin
+	}
+}
+
 func WithTimeout(timeout time.Duration) ConfigOpt {
 	return func(c *Config) {
 		c.timeout = timeout
@@ -87,6 +191,8 @@ func NewClientConfig(url string, opts ...ConfigOpt) (*Config, error) {
 			"Content-Type": {"application/json"},
 		},
 
+		retryPolicy: DefaultRetryPolicy(),
+
 		maxRetries: common.DefaultMaxRetries,
 		retryDelay: common.DefaultRetryDelay,
 
@@ -98,6 +204,10 @@ func NewClientConfig(url string, opts ...ConfigOpt) (*Config, error) {
 		opt(cfg)
 	}
 
+	if cfg.retryPolicy == nil {
+		cfg.retryPolicy = NoRetry
+	}
+
 	// Ensure the HTTPClient has the correct timeout if user did not set one
 	if hc, ok := cfg.HTTPClient.(*http.Client); ok && cfg.timeout == 0 {
 		hc.Timeout = common.DefaultTimeout
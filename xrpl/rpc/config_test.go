@@ -5,8 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Peersyst/xrpl-go/xrpl"
 	"github.com/Peersyst/xrpl-go/xrpl/common"
 	"github.com/Peersyst/xrpl-go/xrpl/faucet"
+	"github.com/Peersyst/xrpl-go/xrpl/ratelimit"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -53,7 +55,7 @@ func TestConfigCreation(t *testing.T) {
 			"Content-Type": {"application/json"},
 		}
 		req.Header = cfg.Headers
-		assert.Equal(t, &Config{HTTPClient: customHttpClient{}, URL: "http://s1.ripple.com:51234/", Headers: headers, maxRetries: common.DefaultMaxRetries, retryDelay: common.DefaultRetryDelay, feeCushion: common.DefaultFeeCushion, maxFeeXRP: common.DefaultMaxFeeXRP, faucetProvider: nil}, cfg)
+		assert.Equal(t, &Config{HTTPClient: customHttpClient{}, URL: "http://s1.ripple.com:51234/", Headers: headers, retryPolicy: DefaultRetryPolicy(), maxRetries: common.DefaultMaxRetries, retryDelay: common.DefaultRetryDelay, feeCushion: common.DefaultFeeCushion, maxFeeXRP: common.DefaultMaxFeeXRP, faucetProvider: nil}, cfg)
 		assert.NoError(t, err)
 	})
 }
@@ -85,3 +87,34 @@ func TestWithTimeout(t *testing.T) {
 
 	require.Equal(t, timeOut, cfg.timeout)
 }
+
+func TestWithRetryPolicy(t *testing.T) {
+	cfg, _ := NewClientConfig("http://s1.ripple.com:51234", WithRetryPolicy(NoRetry))
+	require.Equal(t, NoRetry, cfg.retryPolicy)
+
+	cfg, _ = NewClientConfig("http://s1.ripple.com:51234", WithRetryPolicy(nil))
+	require.Equal(t, NoRetry, cfg.retryPolicy)
+}
+
+func TestWithMaxRetries(t *testing.T) {
+	cfg, _ := NewClientConfig("http://s1.ripple.com:51234", WithMaxRetries(3), WithRetryDelay(time.Millisecond))
+
+	require.Equal(t, 3, cfg.maxRetries)
+	require.Equal(t, time.Millisecond, cfg.retryDelay)
+}
+
+func TestWithRateLimiter(t *testing.T) {
+	cfg, _ := NewClientConfig("http://s1.ripple.com:51234")
+	require.Nil(t, cfg.rateLimiter)
+
+	l := ratelimit.NewLimiter(5, 1)
+	cfg, _ = NewClientConfig("http://s1.ripple.com:51234", WithRateLimiter(l))
+	require.Same(t, l, cfg.rateLimiter)
+}
+
+func TestWithMiddleware(t *testing.T) {
+	noop := func(next xrpl.Handler) xrpl.Handler { return next }
+
+	cfg, _ := NewClientConfig("http://s1.ripple.com:51234", WithMiddleware(noop), WithMiddleware(noop, noop))
+	require.Len(t, cfg.middlewares, 3)
+}
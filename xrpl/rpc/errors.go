@@ -1,31 +1,60 @@
 package rpc
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/Peersyst/xrpl-go/xrpl/autofill"
+)
 
 // Static errors
 var (
 	ErrIncorrectID                            = errors.New("incorrect id")
-	ErrMissingTxSignatureOrSigningPubKey      = errors.New("transaction must have a TxSignature or SigningPubKey set")
-	ErrSignerDataIsEmpty                      = errors.New("signer data is empty")
 	ErrCannotFundWalletWithoutClassicAddress  = errors.New("cannot fund wallet without classic address")
 	ErrMissingLastLedgerSequenceInTransaction = errors.New("missing LastLedgerSequence in transaction")
-	ErrMissingWallet                          = errors.New("wallet must be provided when submitting an unsigned transaction")
+	ErrUnsupportedRequest                     = errors.New("request does not implement XRPLRequest")
+	ErrEmptyResponse                          = errors.New("empty HTTP response")
+	ErrBatchResponseMismatch                  = errors.New("batch response does not match the requests")
+
+	// Autofill errors, shared with the websocket client.
+	ErrMissingTxSignatureOrSigningPubKey = autofill.ErrMissingTxSignatureOrSigningPubKey
+	ErrMissingWallet                     = autofill.ErrMissingWallet
+
+	ErrRawTransactionsFieldIsNotAnArray = autofill.ErrRawTransactionsFieldIsNotAnArray
+	ErrRawTransactionFieldIsNotAnObject = autofill.ErrRawTransactionFieldIsNotAnObject
 
-	ErrRawTransactionsFieldIsNotAnArray = errors.New("RawTransactions field is not an array")
-	ErrRawTransactionFieldIsNotAnObject = errors.New("RawTransaction field is not an object")
+	ErrSigningPubKeyFieldMustBeEmpty = autofill.ErrSigningPubKeyFieldMustBeEmpty
+	ErrTxnSignatureFieldMustBeEmpty  = autofill.ErrTxnSignatureFieldMustBeEmpty
+	ErrSignersFieldMustBeEmpty       = autofill.ErrSignersFieldMustBeEmpty
+	ErrAccountFieldIsNotAString      = autofill.ErrAccountFieldIsNotAString
 
-	ErrSigningPubKeyFieldMustBeEmpty = errors.New("SigningPubKey field must be empty")
-	ErrTxnSignatureFieldMustBeEmpty  = errors.New("TxnSignature field must be empty")
-	ErrSignersFieldMustBeEmpty       = errors.New("Signers field must be empty")
-	ErrAccountFieldIsNotAString      = errors.New("Account field is not a string")
+	ErrSignerIsNotAnObject = autofill.ErrSignerIsNotAnObject
+	ErrSignerDataIsEmpty   = autofill.ErrSignerDataIsEmpty
 )
 
 // Dynamic errors
 
 type ClientError struct {
 	ErrorString string
+	// Code is the rippled error code, e.g. "actNotFound", when the server answered
+	// with an error result.
+	Code string
+	// StatusCode is the HTTP status code of the response, when the server answered with
+	// an HTTP error instead of a result.
+	StatusCode int
+	// Result is the error result answered by the server, if any.
+	Result map[string]any
 }
 
 func (e *ClientError) Error() string {
 	return e.ErrorString
 }
+
+// ErrorCode returns the rippled error code of the error, if any.
+func (e *ClientError) ErrorCode() string {
+	return e.Code
+}
+
+// ErrorResult returns the error result answered by the server, if any.
+func (e *ClientError) ErrorResult() map[string]any {
+	return e.Result
+}
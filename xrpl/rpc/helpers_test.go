@@ -79,7 +79,41 @@ func TestCreateRequest(t *testing.T) {
 	})
 }
 
-func TestCheckForError(t *testing.T) {
+func TestReadBody(t *testing.T) {
+
+	t.Run("Error Response with error code", func(t *testing.T) {
+
+		json := "Null Method" // https://xrpl.org/error-formatting.html#universal-errors
+
+		b := io.NopCloser(bytes.NewReader([]byte(json)))
+		res := &http.Response{
+			StatusCode: 400,
+			Body:       b,
+		}
+
+		body, err := readBody(res)
+		assert.Equal(t, []byte(json), body)
+		expErr := &ClientError{ErrorString: "Null Method", StatusCode: 400}
+		assert.Equal(t, expErr, err)
+	})
+
+	t.Run("Error Response", func(t *testing.T) {
+
+		json := `{"result": {"error": "ledgerIndexMalformed", "status": "error"}}`
+
+		b := io.NopCloser(bytes.NewReader([]byte(json)))
+		res := &http.Response{
+			StatusCode: 200, // error response still returns a 200
+			Body:       b,
+		}
+
+		body, err := readBody(res)
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(json), body)
+	})
+}
+
+func TestDecodeResponse(t *testing.T) {
 
 	t.Run("Error Response", func(t *testing.T) {
 
@@ -96,32 +130,25 @@ func TestCheckForError(t *testing.T) {
 			}
 		}`
 
-		b := io.NopCloser(bytes.NewReader([]byte(json)))
-		res := &http.Response{
-			StatusCode: 200, // error response still returns a 200
-			Body:       b,
-		}
-
-		bodyBytes, err := checkForError(res)
-		assert.NotNil(t, bodyBytes)
-		expError := &ClientError{ErrorString: "ledgerIndexMalformed"}
+		res, err := decodeResponse([]byte(json))
+		assert.NotNil(t, res.Result)
+		expError := &ClientError{ErrorString: "ledgerIndexMalformed", Code: "ledgerIndexMalformed", Result: map[string]any{
+			"error": "ledgerIndexMalformed",
+			"request": map[string]any{
+				"account":      "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59",
+				"command":      "account_info",
+				"ledger_index": "-",
+				"strict":       true,
+			},
+			"status": "error",
+		}}
 		assert.Equal(t, expError, err)
 	})
 
-	t.Run("Error Response with error code", func(t *testing.T) {
-
-		json := "Null Method" // https://xrpl.org/error-formatting.html#universal-errors
+	t.Run("Invalid JSON", func(t *testing.T) {
 
-		b := io.NopCloser(bytes.NewReader([]byte(json)))
-		res := &http.Response{
-			StatusCode: 400,
-			Body:       b,
-		}
-
-		bodyBytes, err := checkForError(res)
-		assert.NotNil(t, bodyBytes)
-		expErrpr := &ClientError{ErrorString: "Null Method"}
-		assert.Equal(t, expErrpr, err)
+		_, err := decodeResponse([]byte("Null Method"))
+		assert.Error(t, err)
 	})
 
 	t.Run("No error Response", func(t *testing.T) {
@@ -148,15 +175,9 @@ func TestCheckForError(t *testing.T) {
 			}
 		  }`
 
-		b := io.NopCloser(bytes.NewReader([]byte(json)))
-		res := &http.Response{
-			StatusCode: 200,
-			Body:       b,
-		}
-
-		bodyBytes, err := checkForError(res)
+		res, err := decodeResponse([]byte(json))
 
 		assert.Nil(t, err)
-		assert.NotNil(t, bodyBytes)
+		assert.Equal(t, "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn", res.Result["account"])
 	})
 }
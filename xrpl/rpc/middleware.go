@@ -0,0 +1,27 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+)
+
+type headersKey struct{}
+
+// ContextWithHeaders returns a copy of ctx carrying HTTP headers to add to the requests
+// sent with it, on top of the configured ones. Middlewares use it to set per-request
+// headers, such as authorization tokens.
+func ContextWithHeaders(ctx context.Context, h http.Header) context.Context {
+	if prev := headersFromContext(ctx); prev != nil {
+		merged := prev.Clone()
+		for k, v := range h {
+			merged[k] = v
+		}
+		h = merged
+	}
+	return context.WithValue(ctx, headersKey{}, h)
+}
+
+func headersFromContext(ctx context.Context) http.Header {
+	h, _ := ctx.Value(headersKey{}).(http.Header)
+	return h
+}
@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/Peersyst/xrpl-go/xrpl/rpc/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Middleware(t *testing.T) {
+	t.Run("observes requests", func(t *testing.T) {
+		mc := &testutil.JSONRPCMockClient{}
+		mc.DoFunc = testutil.MockResponse(`{"result": {"error": "actNotFound", "status": "error"}}`, 200, mc)
+
+		var infos []xrpl.RequestInfo
+		cfg, err := NewClientConfig("http://testnode/",
+			WithHTTPClient(mc),
+			WithMiddleware(xrpl.Observe(func(_ context.Context, info xrpl.RequestInfo) {
+				infos = append(infos, info)
+			})),
+		)
+		require.NoError(t, err)
+
+		_, err = NewClient(cfg).Request(&account.ChannelsRequest{Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn"})
+		require.EqualError(t, err, "actNotFound")
+
+		require.Len(t, infos, 1)
+		require.Equal(t, "account_channels", infos[0].Method)
+		require.Equal(t, 2, infos[0].APIVersion)
+		require.Equal(t, "actNotFound", infos[0].ErrorCode)
+	})
+
+	t.Run("sets per-request headers", func(t *testing.T) {
+		mc := &testutil.JSONRPCMockClient{}
+		var got http.Header
+		mc.DoFunc = func(req *http.Request) (*http.Response, error) {
+			got = req.Header
+			return testutil.MockResponse(`{"result": {}}`, 200, mc)(req)
+		}
+
+		auth := func(next xrpl.Handler) xrpl.Handler {
+			return func(ctx context.Context, req xrpl.Request) (xrpl.Response, error) {
+				ctx = ContextWithHeaders(ctx, http.Header{"Authorization": {"Bearer token"}})
+				return next(ctx, req)
+			}
+		}
+
+		cfg, err := NewClientConfig("http://testnode/", WithHTTPClient(mc), WithMiddleware(auth))
+		require.NoError(t, err)
+
+		_, err = NewClient(cfg).Request(&account.ChannelsRequest{Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn"})
+		require.NoError(t, err)
+		require.Equal(t, "Bearer token", got.Get("Authorization"))
+		require.Equal(t, "application/json", got.Get("Content-Type"))
+
+		// The configured headers are left untouched.
+		require.NotContains(t, cfg.Headers, "Authorization")
+	})
+
+	t.Run("mutates requests", func(t *testing.T) {
+		mc := &testutil.JSONRPCMockClient{}
+		var body string
+		mc.DoFunc = func(req *http.Request) (*http.Response, error) {
+			b := make([]byte, req.ContentLength)
+			_, _ = req.Body.Read(b)
+			body = string(b)
+			return testutil.MockResponse(`{"result": {}}`, 200, mc)(req)
+		}
+
+		limit := func(next xrpl.Handler) xrpl.Handler {
+			return func(ctx context.Context, req xrpl.Request) (xrpl.Response, error) {
+				if r, ok := req.(*account.ChannelsRequest); ok && r.Limit == 0 {
+					cp := *r
+					cp.Limit = 10
+					req = &cp
+				}
+				return next(ctx, req)
+			}
+		}
+
+		cfg, err := NewClientConfig("http://testnode/", WithHTTPClient(mc), WithMiddleware(limit))
+		require.NoError(t, err)
+
+		_, err = NewClient(cfg).Request(&account.ChannelsRequest{Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn"})
+		require.NoError(t, err)
+		require.Contains(t, body, `"limit":10`)
+	})
+}
@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/Peersyst/xrpl-go/xrpl/rpc/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_AccountTransactionsAll(t *testing.T) {
+	pages := []string{
+		`{"result": {"transactions": [{"hash": "A"}, {"hash": "B"}], "marker": {"ledger": 100, "seq": 2}}}`,
+		`{"result": {"transactions": [{"hash": "C"}]}}`,
+	}
+
+	mc := &testutil.JSONRPCMockClient{}
+	var bodies []string
+	mc.DoFunc = func(req *http.Request) (*http.Response, error) {
+		b, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(b))
+		page := pages[mc.RequestCount]
+		mc.RequestCount++
+		return testutil.MockResponse(page, 200, mc)(req)
+	}
+
+	cfg, err := NewClientConfig("http://testnode/", WithHTTPClient(mc))
+	require.NoError(t, err)
+	client := NewClient(cfg)
+
+	var hashes []string
+	for tx, err := range client.AccountTransactionsAll(context.Background(), &account.TransactionsRequest{
+		Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn",
+		Limit:   2,
+	}) {
+		require.NoError(t, err)
+		hashes = append(hashes, string(tx.Hash))
+	}
+
+	require.Equal(t, []string{"A", "B", "C"}, hashes)
+	require.Len(t, bodies, 2)
+	require.NotContains(t, bodies[0], `"marker"`)
+	require.JSONEq(t, `{
+		"method": "account_tx",
+		"params": [{"api_version": 2, "account": "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn", "limit": 2, "marker": {"ledger": 100, "seq": 2}}]
+	}`, bodies[1])
+}
@@ -1,12 +1,17 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
+	"io"
+	"net/http"
+	"os"
 	"testing"
 
 	"github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
 	account "github.com/Peersyst/xrpl-go/xrpl/queries/account"
 	accounttypes "github.com/Peersyst/xrpl-go/xrpl/queries/account/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/amm"
 	channel "github.com/Peersyst/xrpl-go/xrpl/queries/channel"
 	common "github.com/Peersyst/xrpl-go/xrpl/queries/common"
 	ledgerqueries "github.com/Peersyst/xrpl-go/xrpl/queries/ledger"
@@ -1239,6 +1244,49 @@ func TestClient_GetCurrentLedger(t *testing.T) {
 	}
 }
 
+func TestClient_GetAMMInfo(t *testing.T) {
+	mc := testutil.JSONRPCMockClient{}
+	mc.DoFunc = testutil.MockResponse(`{
+		"result": {
+			"amm": {
+				"account": "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM",
+				"amount": "296890496",
+				"amount2": {"currency": "TST", "issuer": "rP9jPyP5kyvFRb6ZiRghAGw5u8SGAmU4bd", "value": "25.81656470648473"},
+				"asset2_frozen": false,
+				"auction_slot": {
+					"account": "rJVUeRqDFNs2xqA7ncVE6ZoAhPUoaJJSQm",
+					"discounted_fee": 60,
+					"expiration": "2023-07-04T10:45:51+0000",
+					"price": {"currency": "039C99CD9AB0B70B32ECDA51EAAE471625608EA2", "issuer": "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM", "value": "0"},
+					"time_interval": 0
+				},
+				"lp_token": {"currency": "039C99CD9AB0B70B32ECDA51EAAE471625608EA2", "issuer": "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM", "value": "87533.41976112682"},
+				"trading_fee": 600,
+				"vote_slots": [{"account": "rJVUeRqDFNs2xqA7ncVE6ZoAhPUoaJJSQm", "trading_fee": 600, "vote_weight": 100000}]
+			},
+			"ledger_current_index": 316745,
+			"validated": false
+		}
+	}`, 200, &mc)
+
+	cfg, err := NewClientConfig("http://testnode/", WithHTTPClient(&mc))
+	require.NoError(t, err)
+
+	res, err := NewClient(cfg).GetAMMInfo(&amm.InfoRequest{
+		Asset:  &ledger.Asset{Currency: "XRP"},
+		Asset2: &ledger.Asset{Currency: "TST", Issuer: "rP9jPyP5kyvFRb6ZiRghAGw5u8SGAmU4bd"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.XRPCurrencyAmount(296890496), res.AMM.Amount)
+	require.Equal(t, types.IssuedCurrencyAmount{Currency: "TST", Issuer: "rP9jPyP5kyvFRb6ZiRghAGw5u8SGAmU4bd", Value: "25.81656470648473"}, res.AMM.Amount2)
+	require.Equal(t, uint32(60), res.AMM.AuctionSlot.DiscountedFee)
+	require.Equal(t, uint32(100000), res.AMM.VoteSlots[0].VoteWeight)
+	require.Equal(t, common.LedgerIndex(316745), res.LedgerCurrentIndex)
+
+	_, err = NewClient(cfg).GetAMMInfo(&amm.InfoRequest{})
+	require.ErrorIs(t, err, amm.ErrNoAMMSpecified)
+}
+
 func TestClient_GetLedgerData(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -1322,6 +1370,50 @@ func TestClient_GetLedgerData(t *testing.T) {
 	}
 }
 
+func TestClient_GetLedgerEntry(t *testing.T) {
+	mc := testutil.JSONRPCMockClient{}
+	mc.DoFunc = testutil.MockResponse(`{
+		"result": {
+			"index": "13F1A95D7AAB7108D5CE7EEAF504B2894B8C674E6D68499076441C4837282BF8",
+			"ledger_hash": "842B57C1CC0613299A686D3E9F310EC0422C84D3911E5056389AA7E5808A93C8",
+			"ledger_index": 6,
+			"node": {
+				"Account": "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn",
+				"Balance": "1000000",
+				"Flags": 0,
+				"LedgerEntryType": "AccountRoot",
+				"OwnerCount": 0,
+				"PreviousTxnID": "E3FE6EA3D48F0C2B639448020EA4F03D4F4F8FFDB243A852A0F59177921B4879",
+				"PreviousTxnLgrSeq": 6,
+				"Sequence": 5,
+				"index": "13F1A95D7AAB7108D5CE7EEAF504B2894B8C674E6D68499076441C4837282BF8"
+			},
+			"validated": true
+		}
+	}`, 200, &mc)
+
+	cfg, err := NewClientConfig("http://testnode/", WithHTTPClient(&mc))
+	require.NoError(t, err)
+
+	res, err := NewClient(cfg).GetLedgerEntry(&ledgerqueries.EntryRequest{
+		AccountRoot: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn",
+		LedgerIndex: common.Validated,
+	})
+	require.NoError(t, err)
+	require.Equal(t, common.LedgerIndex(6), res.LedgerIndex)
+	require.True(t, res.Validated)
+
+	obj, err := res.Object()
+	require.NoError(t, err)
+	root, ok := obj.(*ledger.AccountRoot)
+	require.True(t, ok)
+	require.Equal(t, uint32(5), root.Sequence)
+	require.Equal(t, types.Hash256("13F1A95D7AAB7108D5CE7EEAF504B2894B8C674E6D68499076441C4837282BF8"), root.Index)
+
+	_, err = NewClient(cfg).GetLedgerEntry(&ledgerqueries.EntryRequest{})
+	require.ErrorIs(t, err, ledgerqueries.ErrNoEntrySelector)
+}
+
 func TestClient_GetLedger(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -2966,3 +3058,58 @@ func TestClient_GetRandom(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Definitions(t *testing.T) {
+	doc, err := os.ReadFile("../../binary-codec/definitions/definitions.json")
+	require.NoError(t, err)
+	var result map[string]any
+	require.NoError(t, json.Unmarshal(doc, &result))
+	result["hash"] = "56ADA2A0E7A2F3CE34ABF7C9C2C2F8E0F5CD6A4A54F4D5CE7D4AEC0AD9C7C3AD"
+	// A transaction type enabled by an amendment the embedded definitions don't know.
+	result["TRANSACTION_TYPES"].(map[string]any)["NewTransaction"] = 250
+	full, err := json.Marshal(map[string]any{"result": result})
+	require.NoError(t, err)
+
+	mc := &testutil.JSONRPCMockClient{}
+	var bodies []string
+	mc.DoFunc = func(req *http.Request) (*http.Response, error) {
+		b, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(b))
+		res := string(full)
+		if mc.RequestCount > 0 {
+			res = `{"result": {"hash": "56ADA2A0E7A2F3CE34ABF7C9C2C2F8E0F5CD6A4A54F4D5CE7D4AEC0AD9C7C3AD"}}`
+		}
+		mc.RequestCount++
+		return testutil.MockResponse(res, 200, mc)(req)
+	}
+
+	cfg, err := NewClientConfig("http://testnode/", WithHTTPClient(mc))
+	require.NoError(t, err)
+	client := NewClient(cfg)
+
+	defs, err := client.Definitions(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int32(250), defs.TransactionTypes["NewTransaction"])
+
+	c, err := client.Codec(context.Background())
+	require.NoError(t, err)
+	require.Same(t, defs, c.Definitions())
+
+	encoded, err := c.Encode(map[string]any{
+		"TransactionType": "NewTransaction",
+		"Account":         "rMBzp8CgpE441cp5PVyA9rpVV7oT8hP3ys",
+		"Sequence":        uint32(1),
+	})
+	require.NoError(t, err)
+	decoded, err := c.Decode(encoded)
+	require.NoError(t, err)
+	require.Equal(t, "NewTransaction", decoded["TransactionType"])
+
+	require.Len(t, bodies, 2)
+	require.JSONEq(t, `{"method": "server_definitions", "params": [{"api_version": 2}]}`, bodies[0])
+	require.JSONEq(t, `{
+		"method": "server_definitions",
+		"params": [{"api_version": 2, "hash": "56ADA2A0E7A2F3CE34ABF7C9C2C2F8E0F5CD6A4A54F4D5CE7D4AEC0AD9C7C3AD"}]
+	}`, bodies[1])
+}
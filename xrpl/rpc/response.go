@@ -40,6 +40,25 @@ func (r Response) GetResult(v any) error {
 	return nil
 }
 
+// err returns the error of the result, if the server answered with one.
+func (r Response) err() error {
+	// result will have 'error' if error response
+	if code, ok := r.Result["error"].(string); ok {
+		return &ClientError{ErrorString: code, Code: code, Result: r.Result}
+	}
+	return nil
+}
+
+// warning returns the warning of the response. Over JSON-RPC rippled puts it in the
+// result, e.g. "load" when the client is close to being throttled.
+func (r Response) warning() string {
+	if r.Warning != "" {
+		return r.Warning
+	}
+	w, _ := r.Result["warning"].(string)
+	return w
+}
+
 type XRPLResponse interface {
 	GetResult(v any) error
 }
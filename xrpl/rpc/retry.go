@@ -0,0 +1,195 @@
+package rpc
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/admin"
+)
+
+// nonIdempotentMethods are the methods that may change the server or ledger state when
+// they are sent twice. They are only retried when the failure proves the server did not
+// process them, so a result is never hidden by a replay.
+var nonIdempotentMethods = map[string]bool{
+	"submit":             true,
+	"submit_multisigned": true,
+	"ledger_accept":      true,
+	"ledger_cleaner":     true,
+	"connect":            true,
+	"stop":               true,
+}
+
+// IsIdempotent reports whether req can be sent twice without changing the server or
+// ledger state twice. Besides nonIdempotentMethods, the admin methods that either read
+// or set a value, such as feature or log_level, are not idempotent when they set it.
+func IsIdempotent(req xrpl.Request) bool {
+	switch r := req.(type) {
+	case *admin.FeatureVoteRequest:
+		return false
+	case *admin.LogLevelRequest:
+		return r.Severity == ""
+	case *admin.CanDeleteRequest:
+		return r.CanDelete == ""
+	}
+	return !nonIdempotentMethods[req.Method()]
+}
+
+// Attempt describes a failed attempt to send a request.
+type Attempt struct {
+	// Number is the number of attempts made so far, starting at 1.
+	Number int
+	// Elapsed is the time since the first attempt started.
+	Elapsed time.Duration
+	// Method is the method of the request.
+	Method string
+	// StatusCode is the HTTP status code of the response, or 0 if none was received.
+	StatusCode int
+	// ErrorCode is the rippled error code of the response, e.g. "tooBusy", if any.
+	ErrorCode string
+	// RetryAfter is the delay asked for by the Retry-After header of the response, if any.
+	RetryAfter time.Duration
+	// Err is the error the attempt failed with.
+	Err error
+}
+
+// RetryPolicy decides whether a failed request is sent again and how long to wait before.
+//
+// The client only asks the policy about attempts that are safe to replay, see Replayable:
+// non-idempotent requests such as submit are only retried when the failure shows the
+// server did not process them.
+type RetryPolicy interface {
+	// Backoff returns the delay before retrying after the failed attempt a,
+	// or false to give up and return the error of a.
+	Backoff(a Attempt) (time.Duration, bool)
+}
+
+// ExponentialBackoff is a RetryPolicy that waits exponentially longer between retries.
+type ExponentialBackoff struct {
+	// MaxRetries is the maximum number of retries after the first attempt.
+	MaxRetries int
+	// InitialDelay is the delay before the first retry.
+	InitialDelay time.Duration
+	// MaxDelay caps the delay between two attempts. Zero means no cap.
+	MaxDelay time.Duration
+	// Multiplier is the factor the delay grows by after every retry.
+	Multiplier float64
+	// Jitter randomizes every delay by up to this fraction of it, e.g. 0.2 for ±20%.
+	Jitter float64
+	// MaxElapsedTime stops retrying once the next attempt would start this long after
+	// the first one. Zero means no limit.
+	MaxElapsedTime time.Duration
+
+	// RetryNetworkErrors retries requests that failed without an HTTP response.
+	RetryNetworkErrors bool
+	// RetryStatusCodes are the HTTP status codes that are retried.
+	RetryStatusCodes []int
+	// RetryErrorCodes are the rippled error codes that are retried.
+	RetryErrorCodes []string
+}
+
+// DefaultRetryPolicy returns the policy used by clients that don't set one: up to 3 retries
+// on network errors, HTTP 429 and 503, and the slowDown and tooBusy error codes, waiting
+// 1s, 2s and 4s (±20%) in between or as long as the Retry-After header asks.
+func DefaultRetryPolicy() *ExponentialBackoff {
+	return &ExponentialBackoff{
+		MaxRetries:         3,
+		InitialDelay:       1 * time.Second,
+		MaxDelay:           30 * time.Second,
+		Multiplier:         2,
+		Jitter:             0.2,
+		MaxElapsedTime:     1 * time.Minute,
+		RetryNetworkErrors: true,
+		RetryStatusCodes:   []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+		RetryErrorCodes:    []string{"slowDown", "tooBusy"},
+	}
+}
+
+// Backoff implements RetryPolicy.
+func (b *ExponentialBackoff) Backoff(a Attempt) (time.Duration, bool) {
+	if a.Number > b.MaxRetries || !b.retryable(a) {
+		return 0, false
+	}
+
+	delay := a.RetryAfter
+	if delay == 0 {
+		delay = time.Duration(float64(b.InitialDelay) * math.Pow(b.Multiplier, float64(a.Number-1)))
+		if b.Jitter > 0 {
+			delay += time.Duration(float64(delay) * b.Jitter * (2*rand.Float64() - 1))
+		}
+		if b.MaxDelay > 0 && delay > b.MaxDelay {
+			delay = b.MaxDelay
+		}
+	}
+
+	if b.MaxElapsedTime > 0 && a.Elapsed+delay > b.MaxElapsedTime {
+		return 0, false
+	}
+	return delay, true
+}
+
+func (b *ExponentialBackoff) retryable(a Attempt) bool {
+	switch {
+	case a.ErrorCode != "":
+		return slices.Contains(b.RetryErrorCodes, a.ErrorCode)
+	case a.StatusCode != 0:
+		return slices.Contains(b.RetryStatusCodes, a.StatusCode)
+	default:
+		return b.RetryNetworkErrors
+	}
+}
+
+// NoRetry is a RetryPolicy that never retries.
+var NoRetry RetryPolicy = noRetry{}
+
+type noRetry struct{}
+
+func (noRetry) Backoff(Attempt) (time.Duration, bool) {
+	return 0, false
+}
+
+// Replayable reports whether req can be sent again after the failed attempt a, to the
+// same server or another one, without applying it twice or hiding its result: req is
+// idempotent, or the failure shows the server did not process it.
+func Replayable(req xrpl.Request, a Attempt) bool {
+	return IsIdempotent(req) || a.unprocessed()
+}
+
+// unprocessed reports whether the failure of a shows the server did not process the request.
+func (a Attempt) unprocessed() bool {
+	switch {
+	case a.ErrorCode == "slowDown" || a.ErrorCode == "tooBusy":
+		return true
+	case a.StatusCode == http.StatusTooManyRequests || a.StatusCode == http.StatusServiceUnavailable:
+		return true
+	case a.StatusCode == 0:
+		return isDialError(a.Err)
+	}
+	return false
+}
+
+// isDialError reports whether err happened while connecting, before the request was sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as an HTTP date.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if s, err := strconv.Atoi(v); err == nil && s > 0 {
+		return time.Duration(s) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,268 @@
+package rpc
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/admin"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/server"
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	"github.com/Peersyst/xrpl-go/xrpl/ratelimit"
+	"github.com/Peersyst/xrpl-go/xrpl/rpc/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExponentialBackoff_Backoff(t *testing.T) {
+	policy := &ExponentialBackoff{
+		MaxRetries:         3,
+		InitialDelay:       time.Second,
+		MaxDelay:           3 * time.Second,
+		Multiplier:         2,
+		MaxElapsedTime:     10 * time.Second,
+		RetryNetworkErrors: true,
+		RetryStatusCodes:   []int{http.StatusServiceUnavailable},
+		RetryErrorCodes:    []string{"tooBusy"},
+	}
+
+	tests := []struct {
+		name      string
+		attempt   Attempt
+		wantDelay time.Duration
+		wantRetry bool
+	}{
+		{
+			name:      "network error, first retry",
+			attempt:   Attempt{Number: 1},
+			wantDelay: time.Second,
+			wantRetry: true,
+		},
+		{
+			name:      "delay grows exponentially",
+			attempt:   Attempt{Number: 2, StatusCode: http.StatusServiceUnavailable},
+			wantDelay: 2 * time.Second,
+			wantRetry: true,
+		},
+		{
+			name:      "delay is capped",
+			attempt:   Attempt{Number: 3, ErrorCode: "tooBusy"},
+			wantDelay: 3 * time.Second,
+			wantRetry: true,
+		},
+		{
+			name:    "max retries reached",
+			attempt: Attempt{Number: 4},
+		},
+		{
+			name:      "Retry-After header",
+			attempt:   Attempt{Number: 1, StatusCode: http.StatusServiceUnavailable, RetryAfter: 5 * time.Second},
+			wantDelay: 5 * time.Second,
+			wantRetry: true,
+		},
+		{
+			name:    "max elapsed time reached",
+			attempt: Attempt{Number: 2, Elapsed: 9 * time.Second},
+		},
+		{
+			name:    "status code not retried",
+			attempt: Attempt{Number: 1, StatusCode: http.StatusInternalServerError},
+		},
+		{
+			name:    "error code not retried",
+			attempt: Attempt{Number: 1, StatusCode: http.StatusOK, ErrorCode: "actNotFound"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retry := policy.Backoff(tt.attempt)
+			require.Equal(t, tt.wantRetry, retry)
+			require.Equal(t, tt.wantDelay, delay)
+		})
+	}
+}
+
+func TestExponentialBackoff_Jitter(t *testing.T) {
+	policy := &ExponentialBackoff{
+		MaxRetries:         1,
+		InitialDelay:       time.Second,
+		Multiplier:         2,
+		Jitter:             0.2,
+		RetryNetworkErrors: true,
+	}
+
+	for i := 0; i < 100; i++ {
+		delay, retry := policy.Backoff(Attempt{Number: 1})
+		require.True(t, retry)
+		require.GreaterOrEqual(t, delay, 800*time.Millisecond)
+		require.LessOrEqual(t, delay, 1200*time.Millisecond)
+	}
+}
+
+func TestReplayable(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	readErr := &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}
+	submit := &requests.SubmitRequest{TxBlob: "1200"}
+
+	tests := []struct {
+		name    string
+		req     xrpl.Request
+		attempt Attempt
+		want    bool
+	}{
+		{"idempotent method", &account.InfoRequest{}, Attempt{Err: readErr}, true},
+		{"submit after read error", submit, Attempt{Err: readErr}, false},
+		{"submit after dial error", submit, Attempt{Err: dialErr}, true},
+		{"submit after 503", submit, Attempt{StatusCode: http.StatusServiceUnavailable}, true},
+		{"submit after 500", submit, Attempt{StatusCode: http.StatusInternalServerError}, false},
+		{"submit after tooBusy", submit, Attempt{StatusCode: http.StatusOK, ErrorCode: "tooBusy"}, true},
+		{"submit_multisigned after internal error", &requests.SubmitMultisignedRequest{}, Attempt{StatusCode: http.StatusOK, ErrorCode: "internal"}, false},
+		{"stop after read error", &admin.StopRequest{}, Attempt{Err: readErr}, false},
+		{"feature vote after read error", &admin.FeatureVoteRequest{Feature: "AMM", Vetoed: true}, Attempt{Err: readErr}, false},
+		{"feature query after read error", &server.FeatureOneRequest{Feature: "AMM"}, Attempt{Err: readErr}, true},
+		{"log_level set after read error", &admin.LogLevelRequest{Severity: "debug"}, Attempt{Err: readErr}, false},
+		{"log_level get after read error", &admin.LogLevelRequest{}, Attempt{Err: readErr}, true},
+		{"can_delete set after read error", &admin.CanDeleteRequest{CanDelete: admin.CanDeleteNow}, Attempt{Err: readErr}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Replayable(tt.req, tt.attempt))
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	require.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	require.Equal(t, 3*time.Second, parseRetryAfter("3", now))
+	require.Equal(t, 10*time.Second, parseRetryAfter(now.Add(10*time.Second).Format(http.TimeFormat), now))
+	require.Equal(t, time.Duration(0), parseRetryAfter("soon", now))
+}
+
+func TestClient_RequestRetries(t *testing.T) {
+	fastRetries := WithRetryPolicy(&ExponentialBackoff{
+		MaxRetries:         2,
+		InitialDelay:       time.Millisecond,
+		Multiplier:         2,
+		RetryNetworkErrors: true,
+		RetryErrorCodes:    []string{"tooBusy"},
+	})
+	okResponse := `{"result": {"account": "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn"}}`
+
+	t.Run("network error is retried with the full body", func(t *testing.T) {
+		mc := &testutil.JSONRPCMockClient{}
+		var bodies []string
+		mc.DoFunc = func(req *http.Request) (*http.Response, error) {
+			mc.RequestCount++
+			b, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			bodies = append(bodies, string(b))
+			if mc.RequestCount == 1 {
+				return nil, &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}
+			}
+			return testutil.MockResponse(okResponse, 200, mc)(req)
+		}
+
+		cfg, err := NewClientConfig("http://testnode/", WithHTTPClient(mc), fastRetries)
+		require.NoError(t, err)
+
+		_, err = NewClient(cfg).Request(&account.ChannelsRequest{Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn"})
+		require.NoError(t, err)
+		require.Equal(t, 2, mc.RequestCount)
+		require.NotEmpty(t, bodies[1])
+		require.Equal(t, bodies[0], bodies[1])
+	})
+
+	t.Run("submit is not replayed after a read error", func(t *testing.T) {
+		readErr := &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}
+		mc := &testutil.JSONRPCMockClient{}
+		mc.DoFunc = func(req *http.Request) (*http.Response, error) {
+			mc.RequestCount++
+			return nil, readErr
+		}
+
+		cfg, err := NewClientConfig("http://testnode/", WithHTTPClient(mc), fastRetries)
+		require.NoError(t, err)
+
+		_, err = NewClient(cfg).Request(&requests.SubmitRequest{TxBlob: "1200"})
+		require.ErrorIs(t, err, readErr)
+		require.Equal(t, 1, mc.RequestCount)
+	})
+
+	t.Run("submit is retried on tooBusy", func(t *testing.T) {
+		mc := &testutil.JSONRPCMockClient{}
+		mc.DoFunc = func(req *http.Request) (*http.Response, error) {
+			mc.RequestCount++
+			if mc.RequestCount == 1 {
+				return testutil.MockResponse(`{"result": {"error": "tooBusy"}}`, 200, mc)(req)
+			}
+			return testutil.MockResponse(`{"result": {"engine_result": "tesSUCCESS"}}`, 200, mc)(req)
+		}
+
+		cfg, err := NewClientConfig("http://testnode/", WithHTTPClient(mc), fastRetries)
+		require.NoError(t, err)
+
+		_, err = NewClient(cfg).Request(&requests.SubmitRequest{TxBlob: "1200"})
+		require.NoError(t, err)
+		require.Equal(t, 2, mc.RequestCount)
+	})
+
+	t.Run("NoRetry", func(t *testing.T) {
+		mc := &testutil.JSONRPCMockClient{}
+		mc.DoFunc = func(req *http.Request) (*http.Response, error) {
+			mc.RequestCount++
+			return testutil.MockResponse(`Service Unavailable`, 503, mc)(req)
+		}
+
+		cfg, err := NewClientConfig("http://testnode/", WithHTTPClient(mc), WithRetryPolicy(NoRetry))
+		require.NoError(t, err)
+
+		_, err = NewClient(cfg).Request(&account.ChannelsRequest{Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn"})
+		require.EqualError(t, err, "Server is overloaded, rate limit exceeded")
+		require.Equal(t, 1, mc.RequestCount)
+	})
+}
+
+func TestClient_RequestRateLimited(t *testing.T) {
+	responses := []string{
+		`{"result": {"account": "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn", "warning": "load"}}`,
+		`{"result": {"error": "slowDown"}}`,
+		`{"result": {"account": "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn"}}`,
+	}
+	mc := &testutil.JSONRPCMockClient{}
+	mc.DoFunc = func(req *http.Request) (*http.Response, error) {
+		body := responses[mc.RequestCount]
+		mc.RequestCount++
+		return testutil.MockResponse(body, 200, mc)(req)
+	}
+
+	l := ratelimit.NewLimiter(1000, 10)
+	cfg, err := NewClientConfig("http://testnode/",
+		WithHTTPClient(mc),
+		WithRateLimiter(l),
+		WithRetryPolicy(&ExponentialBackoff{MaxRetries: 1, InitialDelay: time.Millisecond, RetryErrorCodes: []string{"slowDown"}}),
+	)
+	require.NoError(t, err)
+	client := NewClient(cfg)
+
+	_, err = client.Request(&account.ChannelsRequest{Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn"})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), l.Stats().LoadWarnings)
+	require.Equal(t, 500.0, l.Stats().Rate)
+
+	// The slowDown error is retried, and the retry waits for the limiter too.
+	_, err = client.Request(&account.ChannelsRequest{Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn"})
+	require.NoError(t, err)
+
+	stats := l.Stats()
+	require.Equal(t, uint64(3), stats.Requests)
+	require.Equal(t, uint64(1), stats.SlowDowns)
+	require.InDelta(t, 175.0, stats.Rate, 1e-9)
+}
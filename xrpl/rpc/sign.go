@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"context"
+
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+)
+
+// Sign has the server sign a transaction with the keys of the request, and returns it
+// in JSON and binary formats. Requests carrying secret keys are only sent to admin
+// endpoints, see xrpl.CheckEndpoint.
+func (c *Client) Sign(req *requests.SignRequest) (*requests.SignResponse, error) {
+	return c.SignContext(context.Background(), req)
+}
+
+// SignContext is like Sign but uses ctx to cancel the request or bound its duration.
+func (c *Client) SignContext(ctx context.Context, req *requests.SignRequest) (*requests.SignResponse, error) {
+	res, err := c.RequestContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var sr requests.SignResponse
+	err = res.GetResult(&sr)
+	if err != nil {
+		return nil, err
+	}
+	return &sr, nil
+}
+
+// SignFor has the server add one signature to a multi-signed transaction. Requests
+// carrying secret keys are only sent to admin endpoints, see xrpl.CheckEndpoint.
+func (c *Client) SignFor(req *requests.SignForRequest) (*requests.SignForResponse, error) {
+	return c.SignForContext(context.Background(), req)
+}
+
+// SignForContext is like SignFor but uses ctx to cancel the request or bound its duration.
+func (c *Client) SignForContext(ctx context.Context, req *requests.SignForRequest) (*requests.SignForResponse, error) {
+	res, err := c.RequestContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var sr requests.SignForResponse
+	err = res.GetResult(&sr)
+	if err != nil {
+		return nil, err
+	}
+	return &sr, nil
+}
+
+// SignAndSubmit has the server sign a transaction with the keys of the request, then
+// submit it. Requests carrying secret keys are only sent to admin endpoints, see
+// xrpl.CheckEndpoint.
+func (c *Client) SignAndSubmit(req *requests.SignAndSubmitRequest) (*requests.SubmitResponse, error) {
+	return c.SignAndSubmitContext(context.Background(), req)
+}
+
+// SignAndSubmitContext is like SignAndSubmit but uses ctx to cancel the request or bound its duration.
+func (c *Client) SignAndSubmitContext(ctx context.Context, req *requests.SignAndSubmitRequest) (*requests.SubmitResponse, error) {
+	res, err := c.RequestContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var sr requests.SubmitResponse
+	err = res.GetResult(&sr)
+	if err != nil {
+		return nil, err
+	}
+	return &sr, nil
+}
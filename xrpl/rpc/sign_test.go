@@ -0,0 +1,129 @@
+package rpc
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	"github.com/Peersyst/xrpl-go/xrpl/rpc/testutil"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+const testSignedBlob = "12000322000000002400000001201B0000000A68400000000000000A732103AB40A0490F9B7ED8DF29D246BF2D6269820A0EE7742ACDD457BEA7C7D0931EDB74473045022100D184EB4AE5956FF600E7536EE459345C7BBCF097A84CC61A93B9AF7197EDB98702201CEA8009B7BEEBAA2AACC0359B41C427C1C5B550A4CA4B80CF2174AF2D6D5DCE81144B4E9C06F24296074F7BC48F92A97916C6DC5EA9"
+
+func TestClient_Sign(t *testing.T) {
+	mc := &testutil.JSONRPCMockClient{}
+	var body string
+	mc.DoFunc = func(req *http.Request) (*http.Response, error) {
+		b, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		body = string(b)
+		return testutil.MockResponse(`{"result": {
+			"tx_blob": "`+testSignedBlob+`",
+			"tx_json": {"Account": "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn", "TransactionType": "AccountSet", "hash": "ABCD"}
+		}}`, 200, mc)(req)
+	}
+
+	cfg, err := NewClientConfig("http://localhost:5005/", WithHTTPClient(mc))
+	require.NoError(t, err)
+
+	res, err := NewClient(cfg).Sign(&requests.SignRequest{
+		TxJSON: transaction.FlatTransaction{
+			"Account":         "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn",
+			"TransactionType": "AccountSet",
+		},
+		SigningCredentials: requests.SigningCredentials{Seed: "sEdTM1uX8pu2do5XvTnutH6HsouMaM2", KeyType: "ed25519"},
+		FeeMultMax:         1000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, testSignedBlob, res.TxBlob)
+	require.Equal(t, "ABCD", res.Hash())
+	require.JSONEq(t, `{
+		"method": "sign",
+		"params": [{
+			"api_version": 2,
+			"tx_json": {"Account": "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn", "TransactionType": "AccountSet"},
+			"seed": "sEdTM1uX8pu2do5XvTnutH6HsouMaM2",
+			"key_type": "ed25519",
+			"fee_mult_max": 1000
+		}]
+	}`, body)
+}
+
+func TestClient_SignRefused(t *testing.T) {
+	mc := &testutil.JSONRPCMockClient{}
+	mc.DoFunc = func(req *http.Request) (*http.Response, error) {
+		mc.RequestCount++
+		return testutil.MockResponse(`{"result": {}}`, 200, mc)(req)
+	}
+
+	cfg, err := NewClientConfig("https://s.altnet.rippletest.net:51234/", WithHTTPClient(mc))
+	require.NoError(t, err)
+	client := NewClient(cfg)
+
+	req := &requests.SignForRequest{
+		Account:            "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn",
+		TxJSON:             transaction.FlatTransaction{"TransactionType": "AccountSet"},
+		SigningCredentials: requests.SigningCredentials{Secret: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"},
+	}
+	_, err = client.SignFor(req)
+	require.ErrorIs(t, err, xrpl.ErrSecretRequest)
+
+	results, err := client.RequestBatch([]XRPLRequest{req})
+	require.NoError(t, err)
+	require.ErrorIs(t, results[0].Err, xrpl.ErrSecretRequest)
+	require.Equal(t, 0, mc.RequestCount)
+}
+
+func TestClient_SubmitTxWithRemoteSigner(t *testing.T) {
+	responses := []string{
+		`{"result": {"tx_blob": "` + testSignedBlob + `", "tx_json": {"hash": "ABCD"}}}`,
+		`{"result": {"engine_result": "tesSUCCESS", "tx_blob": "` + testSignedBlob + `"}}`,
+	}
+
+	mc := &testutil.JSONRPCMockClient{}
+	var bodies []string
+	mc.DoFunc = func(req *http.Request) (*http.Response, error) {
+		b, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(b))
+		res := responses[mc.RequestCount]
+		mc.RequestCount++
+		return testutil.MockResponse(res, 200, mc)(req)
+	}
+
+	cfg, err := NewClientConfig("http://127.0.0.1:5005/", WithHTTPClient(mc))
+	require.NoError(t, err)
+	client := NewClient(cfg)
+
+	res, err := client.SubmitTx(transaction.FlatTransaction{
+		"Account":         "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn",
+		"TransactionType": "AccountSet",
+	}, &xrpl.SubmitOptions{
+		Signer: &xrpl.RemoteSigner{
+			Requester:   client.Requester(),
+			Credentials: requests.SigningCredentials{Secret: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"},
+			Offline:     true,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "tesSUCCESS", res.EngineResult)
+
+	require.Len(t, bodies, 2)
+	require.JSONEq(t, `{
+		"method": "sign",
+		"params": [{
+			"api_version": 2,
+			"tx_json": {"Account": "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn", "TransactionType": "AccountSet"},
+			"secret": "snoPBrXtMeMyMHUVTgbuqAfg1SUTb",
+			"offline": true
+		}]
+	}`, bodies[0])
+	require.JSONEq(t, `{
+		"method": "submit",
+		"params": [{"api_version": 2, "tx_blob": "`+testSignedBlob+`"}]
+	}`, bodies[1])
+}
@@ -1,11 +1,8 @@
 package types
 
 import (
-	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+	"github.com/Peersyst/xrpl-go/xrpl"
 )
 
-type SubmitOptions struct {
-	Autofill bool
-	Wallet   *wallet.Wallet
-	FailHard bool
-}
+// SubmitOptions is an alias of xrpl.SubmitOptions, shared by every client.
+type SubmitOptions = xrpl.SubmitOptions
@@ -0,0 +1,184 @@
+// Package sequence hands out the sequences of accounts locally, so that one account can
+// submit many transactions concurrently without querying account_info for each of them.
+//
+// An Allocator is shared by the clients submitting for the same accounts, see the
+// WithSequenceAllocator option of the rpc, websocket and pool clients.
+package sequence
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+// Allocator hands out the sequences of accounts. It syncs an account with account_info on
+// its first use, then increments its sequence locally. It is safe for concurrent use.
+type Allocator struct {
+	mu       sync.Mutex
+	accounts map[types.Address]*accountState
+}
+
+// accountState is the sequence state of an account.
+type accountState struct {
+	mu sync.Mutex
+	// next is the next sequence to hand out, zero until synced.
+	next uint32
+	// free holds the sequences below next that were released, in order.
+	free []uint32
+}
+
+// NewAllocator returns an Allocator without any account.
+func NewAllocator() *Allocator {
+	return &Allocator{accounts: make(map[types.Address]*accountState)}
+}
+
+func (a *Allocator) account(addr types.Address) *accountState {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s, ok := a.accounts[addr]
+	if !ok {
+		s = &accountState{}
+		a.accounts[addr] = s
+	}
+	return s
+}
+
+// Next returns the next sequence of addr: the lowest released one, if any, or the one
+// after the last handed out. The account is synced with the account_info of the current
+// ledger, sent through r, on its first use and after a Resync.
+func (a *Allocator) Next(ctx context.Context, r xrpl.Requester, addr types.Address) (uint32, error) {
+	s := a.account(addr)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.free) > 0 {
+		seq := s.free[0]
+		s.free = s.free[1:]
+		return seq, nil
+	}
+	if s.next == 0 {
+		res, err := xrpl.Query[account.InfoResponse](ctx, r, &account.InfoRequest{
+			Account:     addr,
+			LedgerIndex: common.LedgerTitle("current"),
+		})
+		if err != nil {
+			return 0, err
+		}
+		s.next = uint32(res.AccountData.Sequence)
+	}
+	seq := s.next
+	s.next++
+	return seq, nil
+}
+
+// Resync forgets the sequences of addr: the next call to Next syncs it again.
+func (a *Allocator) Resync(addr types.Address) {
+	s := a.account(addr)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next = 0
+	s.free = nil
+}
+
+// Release gives back a sequence of addr that was never used by a transaction in a ledger,
+// e.g. because the transaction failed to be signed or was rejected. It is handed out again
+// by Next.
+func (a *Allocator) Release(addr types.Address, seq uint32) {
+	s := a.account(addr)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.release(seq)
+}
+
+func (s *accountState) release(seq uint32) {
+	if s.next == 0 || seq >= s.next {
+		return
+	}
+	i, found := slices.BinarySearch(s.free, seq)
+	if !found {
+		s.free = slices.Insert(s.free, i, seq)
+	}
+	// Released sequences at the end are handed out in order anyway.
+	for len(s.free) > 0 && s.free[len(s.free)-1] == s.next-1 {
+		s.free = s.free[:len(s.free)-1]
+		s.next--
+	}
+}
+
+// Expired reports whether the expiration of the transaction of addr with sequence seq
+// leaves a gap, which blocks the transactions with the following sequences until it is
+// filled, e.g. with NoopAccountSet. If the sequence was the last one handed out, it is
+// released instead, and Expired returns false.
+func (a *Allocator) Expired(addr types.Address, seq uint32) bool {
+	s := a.account(addr)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.next == 0 || seq >= s.next || slices.Contains(s.free, seq) {
+		return false
+	}
+	if seq+1 == s.next {
+		s.release(seq)
+		return false
+	}
+	return true
+}
+
+// Submitted updates the sequences of addr after the submission of its transaction with
+// sequence seq, and reports whether it left a gap, like Expired.
+//
+// A tefPAST_SEQ, tefALREADY or terPRE_SEQ result means the local sequence is out of sync
+// with the ledger, and resyncs the account. A tefMAX_LEDGER result means the transaction
+// expired. The sequences of transactions rejected with a tem or tel result, or a tef
+// result that never consumes a sequence, are released. Other results leave the sequences
+// as they are, since the transaction may still use its sequence.
+func (a *Allocator) Submitted(addr types.Address, seq uint32, engineResult string) bool {
+	switch {
+	case engineResult == "tefPAST_SEQ" || engineResult == "tefALREADY" || engineResult == "terPRE_SEQ":
+		a.Resync(addr)
+	case engineResult == "tefMAX_LEDGER":
+		return a.Expired(addr, seq)
+	case strings.HasPrefix(engineResult, "tem") || strings.HasPrefix(engineResult, "tel") || unusedSequenceResults[engineResult]:
+		a.Release(addr, seq)
+	}
+	return false
+}
+
+// unusedSequenceResults are the tef results of transactions that were not applied because
+// of the transaction itself, the sequence of which is never consumed.
+var unusedSequenceResults = map[string]bool{
+	"tefBAD_ADD_AUTH":                true,
+	"tefBAD_AUTH":                    true,
+	"tefBAD_AUTH_MASTER":             true,
+	"tefBAD_LEDGER":                  true,
+	"tefBAD_QUORUM":                  true,
+	"tefBAD_SIGNATURE":               true,
+	"tefCREATED":                     true,
+	"tefEXCEPTION":                   true,
+	"tefFAILURE":                     true,
+	"tefINTERNAL":                    true,
+	"tefINVARIANT_FAILED":            true,
+	"tefMASTER_DISABLED":             true,
+	"tefNFTOKEN_IS_NOT_TRANSFERABLE": true,
+	"tefNOT_MULTI_SIGNING":           true,
+	"tefNO_AUTH_REQUIRED":            true,
+	"tefTOO_BIG":                     true,
+	"tefWRONG_PRIOR":                 true,
+}
+
+// NoopAccountSet returns an AccountSet transaction of addr changing nothing, used to fill
+// the gap left by the sequence seq. It still has to be autofilled and signed.
+func NoopAccountSet(addr types.Address, seq uint32) transaction.FlatTransaction {
+	return transaction.FlatTransaction{
+		"TransactionType": transaction.AccountSetTx.String(),
+		"Account":         string(addr),
+		"Sequence":        seq,
+	}
+}
@@ -0,0 +1,189 @@
+package sequence
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/testify/require"
+)
+
+const testAccount types.Address = "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe"
+
+type mockResponse map[string]any
+
+func (r mockResponse) GetResult(v any) error {
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{TagName: "json", Result: &v})
+	if err != nil {
+		return err
+	}
+	return dec.Decode(map[string]any(r))
+}
+
+// accountInfo answers account_info requests with the sequence it holds, and counts them.
+type accountInfo struct {
+	sequence atomic.Uint32
+	calls    atomic.Int32
+}
+
+func (a *accountInfo) RequestContext(_ context.Context, req xrpl.Request) (xrpl.Response, error) {
+	if req.Method() != "account_info" {
+		return nil, errors.New("unexpected request " + req.Method())
+	}
+	a.calls.Add(1)
+	return mockResponse{"account_data": map[string]any{"Sequence": a.sequence.Load()}}, nil
+}
+
+func newAccountInfo(seq uint32) *accountInfo {
+	a := &accountInfo{}
+	a.sequence.Store(seq)
+	return a
+}
+
+func TestAllocator_Next(t *testing.T) {
+	ctx := context.Background()
+	r := newAccountInfo(100)
+	a := NewAllocator()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := make(map[uint32]bool)
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			seq, err := a.Next(ctx, r, testAccount)
+			require.NoError(t, err)
+			mu.Lock()
+			defer mu.Unlock()
+			seen[seq] = true
+		}()
+	}
+	wg.Wait()
+
+	// Every sequence is handed out once, with a single account_info request.
+	require.Len(t, seen, 50)
+	for seq := uint32(100); seq < 150; seq++ {
+		require.True(t, seen[seq], seq)
+	}
+	require.EqualValues(t, 1, r.calls.Load())
+}
+
+func TestAllocator_Release(t *testing.T) {
+	ctx := context.Background()
+	r := newAccountInfo(1)
+	a := NewAllocator()
+	for range 4 {
+		_, err := a.Next(ctx, r, testAccount)
+		require.NoError(t, err)
+	}
+
+	// Released sequences are handed out again, lowest first.
+	a.Release(testAccount, 3)
+	a.Release(testAccount, 2)
+	a.Release(testAccount, 2)
+	a.Release(testAccount, 9)
+	for _, expected := range []uint32{2, 3, 5} {
+		seq, err := a.Next(ctx, r, testAccount)
+		require.NoError(t, err)
+		require.Equal(t, expected, seq)
+	}
+
+	// Releasing the last sequences rewinds the account.
+	a.Release(testAccount, 4)
+	a.Release(testAccount, 5)
+	seq, err := a.Next(ctx, r, testAccount)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, seq)
+}
+
+func TestAllocator_Expired(t *testing.T) {
+	ctx := context.Background()
+	r := newAccountInfo(1)
+	a := NewAllocator()
+	for range 3 {
+		_, err := a.Next(ctx, r, testAccount)
+		require.NoError(t, err)
+	}
+
+	// Followed by sequences 2 and 3, which are blocked until 1 is filled.
+	require.True(t, a.Expired(testAccount, 1))
+	// The last one is handed out again instead.
+	require.False(t, a.Expired(testAccount, 3))
+	seq, err := a.Next(ctx, r, testAccount)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, seq)
+
+	require.False(t, a.Expired(testAccount, 10))
+	require.False(t, a.Expired("rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", 1))
+}
+
+func TestAllocator_Submitted(t *testing.T) {
+	ctx := context.Background()
+	r := newAccountInfo(1)
+	a := NewAllocator()
+	for range 3 {
+		_, err := a.Next(ctx, r, testAccount)
+		require.NoError(t, err)
+	}
+
+	require.False(t, a.Submitted(testAccount, 2, "tesSUCCESS"))
+	require.False(t, a.Submitted(testAccount, 2, "temBAD_FEE"))
+	seq, err := a.Next(ctx, r, testAccount)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, seq)
+
+	// The sequence of a local error is never used either.
+	require.False(t, a.Submitted(testAccount, 1, "telCAN_NOT_QUEUE_FULL"))
+	seq, err = a.Next(ctx, r, testAccount)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, seq)
+
+	// Queued or not, the sequence may still be used.
+	require.False(t, a.Submitted(testAccount, 1, "terQUEUED"))
+	require.False(t, a.Submitted(testAccount, 1, "tefNO_TICKET"))
+	seq, err = a.Next(ctx, r, testAccount)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, seq)
+
+	// An expired transaction leaves a gap, unless its sequence was the last one.
+	require.True(t, a.Submitted(testAccount, 2, "tefMAX_LEDGER"))
+	require.False(t, a.Submitted(testAccount, 4, "tefMAX_LEDGER"))
+	seq, err = a.Next(ctx, r, testAccount)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, seq)
+	require.EqualValues(t, 1, r.calls.Load())
+
+	// Out of sync with the ledger: synced again with account_info.
+	r.sequence.Store(7)
+	require.False(t, a.Submitted(testAccount, 3, "tefPAST_SEQ"))
+	seq, err = a.Next(ctx, r, testAccount)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, seq)
+	require.EqualValues(t, 2, r.calls.Load())
+
+	r.sequence.Store(5)
+	require.False(t, a.Submitted(testAccount, 8, "terPRE_SEQ"))
+	seq, err = a.Next(ctx, r, testAccount)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, seq)
+
+	// Already applied or queued: the sequence is used.
+	r.sequence.Store(9)
+	require.False(t, a.Submitted(testAccount, 5, "tefALREADY"))
+	seq, err = a.Next(ctx, r, testAccount)
+	require.NoError(t, err)
+	require.EqualValues(t, 9, seq)
+}
+
+func TestNoopAccountSet(t *testing.T) {
+	tx := NoopAccountSet(testAccount, 12)
+	require.Equal(t, "AccountSet", tx["TransactionType"])
+	require.Equal(t, string(testAccount), tx["Account"])
+	require.Equal(t, uint32(12), tx["Sequence"])
+}
@@ -0,0 +1,271 @@
+package sequence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	ledger "github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	"github.com/Peersyst/xrpl-go/xrpl/paginate"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	"github.com/Peersyst/xrpl-go/xrpl/submission"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+const (
+	// MaxTickets is the number of tickets an account can hold at once.
+	MaxTickets = 250
+
+	DefaultMinFreeTickets = 5
+	DefaultTicketTopUp    = 20
+)
+
+var (
+	ErrNoTickets = errors.New("no ticket available: every ticket the account can hold is leased")
+)
+
+// TicketClient is a client a TicketPool queries and submits with, such as rpc.Client,
+// websocket.Client or pool.Pool.
+type TicketClient interface {
+	Requester() xrpl.Requester
+	SubmitTxAndWaitContext(ctx context.Context, tx transaction.FlatTransaction, opts *xrpl.SubmitOptions) (*requests.TxResponse, error)
+}
+
+// TicketPoolConfig holds the settings of a TicketPool.
+type TicketPoolConfig struct {
+	// Account is the account the tickets belong to.
+	Account types.Address
+	// Signer signs the TicketCreate transactions topping the pool up.
+	Signer xrpl.Signer
+	// MinFree is the number of free tickets at which the pool is topped up.
+	// Default: DefaultMinFreeTickets.
+	MinFree int
+	// TopUp is the number of tickets created by a top-up, at most MaxTickets.
+	// Default: DefaultTicketTopUp.
+	TopUp int
+}
+
+// TicketPool leases the tickets of an account to concurrent submitters, so that their
+// transactions don't depend on each other's sequence. It discovers the tickets of the
+// account with account_objects, and creates more with TicketCreate when few are left.
+// It is safe for concurrent use.
+type TicketPool struct {
+	c   TicketClient
+	cfg TicketPoolConfig
+
+	mu     sync.Mutex
+	synced bool
+	free   []uint32
+	leased map[uint32]bool
+	// used holds the consumed tickets, which a ledger older than their transaction may
+	// still hold.
+	used map[uint32]bool
+	// topUp is closed when the running top-up is done, nil if there is none.
+	topUp    chan struct{}
+	topUpErr error
+}
+
+// NewTicketPool returns a TicketPool of the tickets of cfg.Account, which are discovered
+// on the first lease.
+func NewTicketPool(c TicketClient, cfg TicketPoolConfig) *TicketPool {
+	if cfg.MinFree <= 0 {
+		cfg.MinFree = DefaultMinFreeTickets
+	}
+	if cfg.TopUp <= 0 {
+		cfg.TopUp = DefaultTicketTopUp
+	}
+	cfg.TopUp = min(cfg.TopUp, MaxTickets)
+	return &TicketPool{c: c, cfg: cfg, leased: make(map[uint32]bool), used: make(map[uint32]bool)}
+}
+
+// Sync discovers the tickets of the account with account_objects. Tickets that are not
+// leased become free, and free tickets no longer in the ledger are forgotten.
+func (p *TicketPool) Sync(ctx context.Context) error {
+	var tickets []uint32
+	for obj, err := range paginate.AccountObjects(ctx, p.c.Requester(), &account.ObjectsRequest{
+		Account: p.cfg.Account,
+		Type:    account.TicketObject,
+	}) {
+		if err != nil {
+			return err
+		}
+		ticket, err := decodeTicket(obj)
+		if err != nil {
+			return err
+		}
+		tickets = append(tickets, ticket.TicketSequence)
+	}
+	slices.Sort(tickets)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	inLedger := make(map[uint32]bool, len(tickets))
+	p.free = p.free[:0]
+	for _, t := range tickets {
+		inLedger[t] = true
+		if !p.leased[t] && !p.used[t] {
+			p.free = append(p.free, t)
+		}
+	}
+	for t := range p.used {
+		if !inLedger[t] {
+			delete(p.used, t)
+		}
+	}
+	p.synced = true
+	return nil
+}
+
+// Lease returns a free ticket, which must be given back with Done. It waits for a top-up
+// if no ticket is free, and starts one in the background if few are left.
+func (p *TicketPool) Lease(ctx context.Context) (uint32, error) {
+	p.mu.Lock()
+	synced := p.synced
+	p.mu.Unlock()
+	if !synced {
+		if err := p.Sync(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	for {
+		p.mu.Lock()
+		if len(p.free) == 0 && p.topUpErr != nil {
+			err := p.topUpErr
+			p.topUpErr = nil
+			p.mu.Unlock()
+			return 0, err
+		}
+		if len(p.free) <= p.cfg.MinFree && p.topUp == nil {
+			p.startTopUp(ctx)
+		}
+		if len(p.free) > 0 {
+			t := p.free[0]
+			p.free = p.free[1:]
+			p.leased[t] = true
+			p.mu.Unlock()
+			return t, nil
+		}
+		done := p.topUp
+		p.mu.Unlock()
+
+		if done == nil {
+			return 0, ErrNoTickets
+		}
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-done:
+		}
+	}
+}
+
+// Done gives back a leased ticket, given the error of the submission of its transaction.
+// The ticket is consumed if the transaction was validated, even with a failure result,
+// and free again if it was rejected or expired. Any other error leaves the outcome of the
+// transaction unknown: the ticket stays leased until Return is called.
+func (p *TicketPool) Done(ticket uint32, err error) {
+	var failed *submission.FailedError
+	var rejected *submission.RejectedError
+	switch {
+	case err == nil || errors.As(err, &failed):
+		p.consume(ticket)
+	case errors.As(err, &rejected) && rejected.EngineResult == "tefNO_TICKET":
+		// Already used, or never created.
+		p.consume(ticket)
+	case errors.As(err, &rejected) || errors.Is(err, submission.ErrExpired):
+		p.Return(ticket)
+	}
+}
+
+func (p *TicketPool) consume(ticket uint32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.leased, ticket)
+	p.used[ticket] = true
+}
+
+// Return frees a leased ticket whose transaction never made it into a ledger.
+func (p *TicketPool) Return(ticket uint32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.leased[ticket] {
+		return
+	}
+	delete(p.leased, ticket)
+	i, _ := slices.BinarySearch(p.free, ticket)
+	p.free = slices.Insert(p.free, i, ticket)
+}
+
+// Free returns the number of free tickets.
+func (p *TicketPool) Free() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.free)
+}
+
+// SubmitTxAndWait submits a copy of tx with a leased ticket, and waits for its outcome
+// with the SubmitTxAndWaitContext method of the client. The ticket is given back with Done.
+func (p *TicketPool) SubmitTxAndWait(ctx context.Context, tx transaction.FlatTransaction, opts *xrpl.SubmitOptions) (*requests.TxResponse, error) {
+	ticket, err := p.Lease(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The caller may submit tx again, e.g. with another ticket.
+	tx = maps.Clone(tx)
+	tx["Sequence"] = uint32(0)
+	tx["TicketSequence"] = ticket
+
+	res, err := p.c.SubmitTxAndWaitContext(ctx, tx, opts)
+	p.Done(ticket, err)
+	return res, err
+}
+
+// startTopUp creates tickets in the background. p.mu must be held.
+func (p *TicketPool) startTopUp(ctx context.Context) {
+	count := min(p.cfg.TopUp, MaxTickets-len(p.free)-len(p.leased))
+	if count <= 0 {
+		return
+	}
+	done := make(chan struct{})
+	p.topUp = done
+
+	go func() {
+		err := p.createTickets(context.WithoutCancel(ctx), count)
+		p.mu.Lock()
+		p.topUp = nil
+		p.topUpErr = err
+		p.mu.Unlock()
+		close(done)
+	}()
+}
+
+// createTickets submits a TicketCreate of count tickets, then discovers them.
+func (p *TicketPool) createTickets(ctx context.Context, count int) error {
+	_, err := p.c.SubmitTxAndWaitContext(ctx, transaction.FlatTransaction{
+		"TransactionType": transaction.TicketCreateTx.String(),
+		"Account":         string(p.cfg.Account),
+		"TicketCount":     uint32(count),
+	}, &xrpl.SubmitOptions{Autofill: true, Signer: p.cfg.Signer})
+	if err != nil {
+		return err
+	}
+	return p.Sync(ctx)
+}
+
+// decodeTicket decodes a Ticket object of account_objects, whatever the JSON decoding of
+// its numbers by the client.
+func decodeTicket(obj ledger.FlatLedgerObject) (ledger.Ticket, error) {
+	var ticket ledger.Ticket
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return ticket, err
+	}
+	return ticket, json.Unmarshal(b, &ticket)
+}
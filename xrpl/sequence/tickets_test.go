@@ -0,0 +1,181 @@
+package sequence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	"github.com/Peersyst/xrpl-go/xrpl/submission"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+// ticketClient holds the tickets of an account. TicketCreate transactions create
+// tickets, and other transactions fail with err.
+type ticketClient struct {
+	mu        sync.Mutex
+	tickets   []uint32
+	next      uint32
+	creates   int
+	createErr error
+	err       error
+	submitted []transaction.FlatTransaction
+}
+
+func newTicketClient(tickets ...uint32) *ticketClient {
+	return &ticketClient{tickets: tickets, next: 100}
+}
+
+func (c *ticketClient) Requester() xrpl.Requester {
+	return xrpl.RequesterFunc(func(_ context.Context, req xrpl.Request) (xrpl.Response, error) {
+		if req.Method() != "account_objects" {
+			return nil, errors.New("unexpected request " + req.Method())
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		objects := make([]any, 0, len(c.tickets))
+		for _, t := range c.tickets {
+			// Numbers decoded like the rpc client does, with json.Decoder.UseNumber.
+			objects = append(objects, map[string]any{"LedgerEntryType": "Ticket", "TicketSequence": json.Number(strconv.FormatUint(uint64(t), 10))})
+		}
+		return mockResponse{"account_objects": objects}, nil
+	})
+}
+
+func (c *ticketClient) SubmitTxAndWaitContext(_ context.Context, tx transaction.FlatTransaction, _ *xrpl.SubmitOptions) (*requests.TxResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if tx["TransactionType"] == "TicketCreate" {
+		c.creates++
+		if c.createErr != nil {
+			return nil, c.createErr
+		}
+		for range tx["TicketCount"].(uint32) {
+			c.tickets = append(c.tickets, c.next)
+			c.next++
+		}
+		return &requests.TxResponse{Validated: true}, nil
+	}
+	c.submitted = append(c.submitted, tx)
+	return &requests.TxResponse{Validated: true}, c.err
+}
+
+func TestTicketPool_Lease(t *testing.T) {
+	ctx := context.Background()
+	c := newTicketClient(3, 1, 2, 4, 5, 6, 7, 8)
+	p := NewTicketPool(c, TicketPoolConfig{Account: testAccount, MinFree: 2})
+	require.NoError(t, p.Sync(ctx))
+	require.Equal(t, 8, p.Free())
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	leased := make(map[uint32]bool)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ticket, err := p.Lease(ctx)
+			require.NoError(t, err)
+			mu.Lock()
+			defer mu.Unlock()
+			leased[ticket] = true
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, map[uint32]bool{1: true, 2: true, 3: true, 4: true, 5: true}, leased)
+	require.Equal(t, 3, p.Free())
+	require.Equal(t, 0, c.creates)
+}
+
+func TestTicketPool_TopUp(t *testing.T) {
+	ctx := context.Background()
+	c := newTicketClient(1)
+	p := NewTicketPool(c, TicketPoolConfig{Account: testAccount, MinFree: 2, TopUp: 3})
+
+	ticket, err := p.Lease(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, ticket)
+
+	// Waits for the top-up started by the first lease.
+	for _, expected := range []uint32{100, 101, 102} {
+		ticket, err = p.Lease(ctx)
+		require.NoError(t, err)
+		require.Equal(t, expected, ticket)
+	}
+	p.mu.Lock()
+	for p.topUp != nil {
+		done := p.topUp
+		p.mu.Unlock()
+		<-done
+		p.mu.Lock()
+	}
+	p.mu.Unlock()
+	require.Equal(t, 2, c.creates)
+
+	c.createErr = errors.New("connection closed")
+	for range 3 {
+		_, err = p.Lease(ctx)
+		require.NoError(t, err)
+	}
+	_, err = p.Lease(ctx)
+	require.ErrorIs(t, err, c.createErr)
+}
+
+func TestTicketPool_Done(t *testing.T) {
+	ctx := context.Background()
+	c := newTicketClient(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	p := NewTicketPool(c, TicketPoolConfig{Account: testAccount, MinFree: 1})
+
+	tickets := make([]uint32, 4)
+	for i := range tickets {
+		ticket, err := p.Lease(ctx)
+		require.NoError(t, err)
+		tickets[i] = ticket
+	}
+	require.Equal(t, 6, p.Free())
+
+	p.Done(tickets[0], nil)
+	p.Done(tickets[1], &submission.FailedError{TransactionResult: "tecNO_DST"})
+	p.Done(tickets[2], submission.ErrExpired)
+	p.Done(tickets[3], errors.New("connection closed"))
+	require.Equal(t, 7, p.Free())
+
+	// The ticket of the expired transaction is leased again.
+	ticket, err := p.Lease(ctx)
+	require.NoError(t, err)
+	require.Equal(t, tickets[2], ticket)
+
+	// Consumed tickets are not freed by a ledger that still holds them, nor unknown ones.
+	require.NoError(t, p.Sync(ctx))
+	require.Equal(t, 6, p.Free())
+	p.Return(tickets[3])
+	require.Equal(t, 7, p.Free())
+}
+
+func TestTicketPool_SubmitTxAndWait(t *testing.T) {
+	ctx := context.Background()
+	c := newTicketClient(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	p := NewTicketPool(c, TicketPoolConfig{Account: testAccount})
+
+	c.err = &submission.RejectedError{EngineResult: "temBAD_FEE"}
+	_, err := p.SubmitTxAndWait(ctx, transaction.FlatTransaction{"TransactionType": "AccountSet"}, nil)
+	require.Error(t, err)
+	require.Equal(t, uint32(0), c.submitted[0]["Sequence"])
+	require.Equal(t, uint32(1), c.submitted[0]["TicketSequence"])
+	require.Equal(t, 10, p.Free())
+
+	c.err = nil
+	tx := transaction.FlatTransaction{"TransactionType": "AccountSet"}
+	_, err = p.SubmitTxAndWait(ctx, tx, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), c.submitted[1]["TicketSequence"])
+	require.Equal(t, 9, p.Free())
+	// The transaction of the caller is left as is.
+	require.Equal(t, transaction.FlatTransaction{"TransactionType": "AccountSet"}, tx)
+}
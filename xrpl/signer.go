@@ -0,0 +1,68 @@
+package xrpl
+
+import (
+	"context"
+
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+// Signer signs transactions and returns their blob and hash. It is implemented by
+// wallet.Wallet, which signs locally, and by RemoteSigner, which has a server sign.
+type Signer interface {
+	Sign(tx map[string]interface{}) (string, string, error)
+}
+
+// RemoteSigner signs transactions with the sign and sign_for methods of a server that
+// holds, or is sent, the keys of an account, so that it can replace a local wallet.
+// Secret keys are sent in clear text, so clients refuse to send them to endpoints that
+// are not admin ones, see CheckEndpoint.
+type RemoteSigner struct {
+	// Requester sends the requests, e.g. a client's Requester.
+	Requester Requester
+	// Credentials are the keys the server signs with.
+	Credentials requests.SigningCredentials
+	// Account is the signer of multi-signed transactions, see Multisign.
+	Account types.Address
+	// Offline asks the server not to fill in the missing fields of the transactions.
+	Offline bool
+}
+
+// Sign has the server sign tx and returns its blob and hash. Unlike wallet.Wallet, it
+// does not update tx.
+func (s *RemoteSigner) Sign(tx map[string]interface{}) (string, string, error) {
+	return s.SignContext(context.Background(), tx)
+}
+
+// SignContext is like Sign but uses ctx to cancel the request or bound its duration.
+func (s *RemoteSigner) SignContext(ctx context.Context, tx map[string]interface{}) (string, string, error) {
+	res, err := Query[requests.SignResponse](ctx, s.Requester, &requests.SignRequest{
+		TxJSON:             transaction.FlatTransaction(tx),
+		SigningCredentials: s.Credentials,
+		Offline:            s.Offline,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return res.TxBlob, res.Hash(), nil
+}
+
+// Multisign has the server add the signature of Account to tx, and returns the blob and
+// hash of the resulting transaction.
+func (s *RemoteSigner) Multisign(tx map[string]interface{}) (string, string, error) {
+	return s.MultisignContext(context.Background(), tx)
+}
+
+// MultisignContext is like Multisign but uses ctx to cancel the request or bound its duration.
+func (s *RemoteSigner) MultisignContext(ctx context.Context, tx map[string]interface{}) (string, string, error) {
+	res, err := Query[requests.SignForResponse](ctx, s.Requester, &requests.SignForRequest{
+		Account:            s.Account,
+		TxJSON:             transaction.FlatTransaction(tx),
+		SigningCredentials: s.Credentials,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return res.TxBlob, res.Hash(), nil
+}
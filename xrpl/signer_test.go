@@ -0,0 +1,41 @@
+package xrpl
+
+import (
+	"context"
+	"testing"
+
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	"github.com/Peersyst/xrpl-go/xrpl/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ Signer = (*wallet.Wallet)(nil)
+	_ Signer = (*RemoteSigner)(nil)
+)
+
+func TestRemoteSigner(t *testing.T) {
+	var sent []Request
+	r := RequesterFunc(func(_ context.Context, req Request) (Response, error) {
+		sent = append(sent, req)
+		return testJSONResponse(`{"tx_blob": "120003", "tx_json": {"hash": "ABCD"}}`), nil
+	})
+
+	creds := requests.SigningCredentials{Secret: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"}
+	s := &RemoteSigner{Requester: r, Credentials: creds, Account: "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn"}
+	tx := map[string]interface{}{"TransactionType": "AccountSet"}
+
+	blob, hash, err := s.Sign(tx)
+	require.NoError(t, err)
+	require.Equal(t, "120003", blob)
+	require.Equal(t, "ABCD", hash)
+
+	blob, hash, err = s.Multisign(tx)
+	require.NoError(t, err)
+	require.Equal(t, "120003", blob)
+	require.Equal(t, "ABCD", hash)
+
+	require.Len(t, sent, 2)
+	require.Equal(t, creds, sent[0].(*requests.SignRequest).SigningCredentials)
+	require.Equal(t, "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn", sent[1].(*requests.SignForRequest).Account.String())
+}
@@ -0,0 +1,417 @@
+// Package submission submits signed transactions following the reliable transaction
+// submission rules of the XRP Ledger, and determines their final outcome.
+//
+// A transaction is only declared Validated once it is in a validated ledger, and Expired
+// once the server not finding it holds every validated ledger it could have been
+// included in, up to its LastLedgerSequence. The Manager only needs an xrpl.Requester, so it works with
+// every client.
+package submission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/hash"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/server"
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+const DefaultPollInterval = time.Second
+
+var (
+	ErrMissingLastLedgerSequence = errors.New("transaction must have a LastLedgerSequence")
+	ErrUnknownTransaction        = errors.New("transaction not submitted with this manager")
+	ErrExpired                   = errors.New("transaction expired: its LastLedgerSequence passed without it being validated")
+	ErrPending                   = errors.New("transaction outcome not final yet")
+)
+
+// State is the state of a submitted transaction.
+type State int
+
+const (
+	// Pending transactions may still be included in a validated ledger.
+	Pending State = iota
+	// Validated transactions are in a validated ledger. Their result is final, and may be
+	// a tec failure, which still claims the fee.
+	Validated
+	// Expired transactions can no longer be included in a validated ledger.
+	Expired
+	// Rejected transactions were refused at submission, and cannot succeed as signed.
+	Rejected
+)
+
+func (s State) String() string {
+	switch s {
+	case Pending:
+		return "Pending"
+	case Validated:
+		return "Validated"
+	case Expired:
+		return "Expired"
+	case Rejected:
+		return "Rejected"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// Result is the outcome of a submitted transaction.
+type Result struct {
+	State State
+	// TransactionResult is the result code of a Validated transaction, from its metadata,
+	// or the engine result a Rejected transaction was refused with.
+	TransactionResult string
+	// LedgerIndex is the ledger a Validated transaction is in.
+	LedgerIndex uint32
+	// Tx is the tx response of a Validated transaction.
+	Tx *requests.TxResponse
+}
+
+// Final reports whether the outcome can no longer change.
+func (r Result) Final() bool {
+	return r.State != Pending
+}
+
+// Err returns nil for transactions Validated with tesSUCCESS, and an error describing the
+// outcome otherwise: a *FailedError, a *RejectedError, ErrExpired or ErrPending.
+func (r Result) Err() error {
+	switch r.State {
+	case Validated:
+		if r.TransactionResult != "tesSUCCESS" {
+			return &FailedError{TransactionResult: r.TransactionResult}
+		}
+		return nil
+	case Rejected:
+		return &RejectedError{EngineResult: r.TransactionResult}
+	case Expired:
+		return ErrExpired
+	}
+	return ErrPending
+}
+
+// FailedError is the error of a transaction validated with a failure result, which
+// claimed the fee but had no other effect.
+type FailedError struct {
+	TransactionResult string
+}
+
+func (e *FailedError) Error() string {
+	return "transaction failed with result: " + e.TransactionResult
+}
+
+// RejectedError is the error of a transaction rejected at submission.
+type RejectedError struct {
+	EngineResult string
+}
+
+func (e *RejectedError) Error() string {
+	return "transaction failed to submit with engine result: " + e.EngineResult
+}
+
+// Config holds the settings of a Manager.
+type Config struct {
+	// Store persists the submitted transactions. Default: a new MemoryStore.
+	Store Store
+	// PollInterval is the delay between the checks of Wait. Default: DefaultPollInterval.
+	PollInterval time.Duration
+	// MaxChecks bounds the checks of Wait once the LastLedgerSequence of the transaction
+	// has passed, but the server lacks the ledgers proving it expired. Wait then returns
+	// the Pending record. Zero waits until the context is done.
+	MaxChecks int
+	// FailHard is sent with every submission, see the submit method.
+	FailHard bool
+}
+
+// Manager submits signed transactions and follows them until their outcome is final.
+// It is safe for concurrent use.
+type Manager struct {
+	r   xrpl.Requester
+	cfg Config
+}
+
+// NewManager returns a Manager that sends its requests through r.
+func NewManager(r xrpl.Requester, cfg Config) *Manager {
+	if cfg.Store == nil {
+		cfg.Store = NewMemoryStore()
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	return &Manager{r: r, cfg: cfg}
+}
+
+// Submit persists and submits a signed transaction, which must have a LastLedgerSequence.
+// Submitting a transaction again resubmits it, unless its outcome is final.
+//
+// The returned record is Pending, unless the transaction is malformed (tem) or can't be
+// applied on its first submission (tef), in which case it is Rejected.
+func (m *Manager) Submit(ctx context.Context, txBlob string) (*Record, error) {
+	tx, err := binarycodec.Decode(txBlob)
+	if err != nil {
+		return nil, err
+	}
+	lastLedgerSequence, ok := tx["LastLedgerSequence"].(uint32)
+	if !ok {
+		return nil, ErrMissingLastLedgerSequence
+	}
+	txHash, err := hash.SignTxBlob(txBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	rec, found, err := m.cfg.Store.Load(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if found && rec.Result.Final() {
+		return &rec, nil
+	}
+	if !found {
+		info, err := xrpl.Query[server.InfoResponse](ctx, m.r, &server.InfoRequest{})
+		if err != nil {
+			return nil, err
+		}
+		rec = Record{
+			Hash:               txHash,
+			TxBlob:             txBlob,
+			LastLedgerSequence: lastLedgerSequence,
+			MinLedger:          uint32(info.Info.ValidatedLedger.Seq) + 1,
+		}
+		// Persist before submitting, so that the transaction is followed even if the
+		// process stops right after the submission.
+		if err := m.cfg.Store.Save(ctx, rec); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.submit(ctx, &rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// Check determines the current outcome of a transaction submitted with Submit, and
+// resubmits it if its last submission was retryable (ter or tel) and it may still be
+// included in a ledger. A queued transaction (terQUEUED) is only resubmitted once the
+// server dropped it from its queue.
+func (m *Manager) Check(ctx context.Context, txHash string) (*Record, error) {
+	rec, found, err := m.cfg.Store.Load(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrUnknownTransaction
+	}
+	if rec.Result.Final() {
+		return &rec, nil
+	}
+
+	info, err := xrpl.Query[server.InfoResponse](ctx, m.r, &server.InfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+	rec.ValidatedLedger = uint32(info.Info.ValidatedLedger.Seq)
+
+	// The transaction is looked up in the ledgers it could be included in, so that the
+	// server answering that it is not found also tells whether it holds all of them.
+	// Another request could reach another server, e.g. through a pool.
+	tx, err := xrpl.Query[requests.TxResponse](ctx, m.r, &requests.TxRequest{
+		Transaction: txHash,
+		MinLedger:   common.LedgerIndex(rec.MinLedger),
+		MaxLedger:   common.LedgerIndex(rec.LastLedgerSequence),
+	})
+	if err != nil && xrpl.ErrorCode(err) != "txnNotFound" {
+		return nil, err
+	}
+	if err == nil && tx.Validated {
+		return m.validated(ctx, rec, tx)
+	}
+	if err != nil && searchedAll(err) {
+		rec.ValidatedLedger = max(rec.ValidatedLedger, rec.LastLedgerSequence)
+		rec.Result = Result{State: Expired}
+		return &rec, m.cfg.Store.Save(ctx, rec)
+	}
+	if rec.ValidatedLedger > rec.LastLedgerSequence {
+		return &rec, m.cfg.Store.Save(ctx, rec)
+	}
+
+	switch {
+	case rec.Submissions == 0 || retryable(rec.EngineResult):
+		return &rec, m.submit(ctx, &rec)
+	case rec.EngineResult == "terQUEUED":
+		// A queued transaction is only submitted again once the queue dropped it.
+		queued, err := m.queued(ctx, rec.TxBlob)
+		if err != nil {
+			return nil, err
+		}
+		if !queued {
+			return &rec, m.submit(ctx, &rec)
+		}
+	}
+	return &rec, m.cfg.Store.Save(ctx, rec)
+}
+
+// queued reports whether the queue of the server holds a transaction of the account of
+// txBlob with its sequence or ticket.
+func (m *Manager) queued(ctx context.Context, txBlob string) (bool, error) {
+	tx, err := binarycodec.Decode(txBlob)
+	if err != nil {
+		return false, err
+	}
+	addr, _ := tx["Account"].(string)
+	seq, _ := tx["Sequence"].(uint32)
+	ticket, _ := tx["TicketSequence"].(uint32)
+
+	info, err := xrpl.Query[account.InfoResponse](ctx, m.r, &account.InfoRequest{
+		Account:     types.Address(addr),
+		LedgerIndex: common.LedgerTitle("current"),
+		Queue:       true,
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, q := range info.QueueData.Transactions {
+		if (seq != 0 && q.Seq == int(seq)) || (ticket != 0 && q.Ticket == int(ticket)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Observe records the outcome of a transaction submitted with Submit, from a validated
+// tx response obtained elsewhere, e.g. from a transaction stream. Responses of transactions
+// that are not validated are ignored.
+func (m *Manager) Observe(ctx context.Context, tx *requests.TxResponse) (*Record, error) {
+	rec, found, err := m.cfg.Store.Load(ctx, strings.ToUpper(string(tx.Hash)))
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrUnknownTransaction
+	}
+	if rec.Result.Final() || !tx.Validated {
+		return &rec, nil
+	}
+	return m.validated(ctx, rec, tx)
+}
+
+// Wait checks a transaction submitted with Submit every PollInterval, until its outcome
+// is final or ctx is done. See Config.MaxChecks for servers lacking ledgers.
+func (m *Manager) Wait(ctx context.Context, txHash string) (*Record, error) {
+	unproven := 0
+	for {
+		rec, err := m.Check(ctx, txHash)
+		if err != nil {
+			return nil, err
+		}
+		if rec.Result.Final() {
+			return rec, nil
+		}
+		if rec.ValidatedLedger > rec.LastLedgerSequence {
+			unproven++
+			if m.cfg.MaxChecks > 0 && unproven >= m.cfg.MaxChecks {
+				return rec, nil
+			}
+		}
+		if err := sleepContext(ctx, m.cfg.PollInterval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// SubmitAndWait submits a signed transaction, and waits for its final outcome.
+func (m *Manager) SubmitAndWait(ctx context.Context, txBlob string) (*Record, error) {
+	rec, err := m.Submit(ctx, txBlob)
+	if err != nil {
+		return nil, err
+	}
+	if rec.Result.Final() {
+		return rec, nil
+	}
+	return m.Wait(ctx, rec.Hash)
+}
+
+// validated saves rec as validated by tx.
+func (m *Manager) validated(ctx context.Context, rec Record, tx *requests.TxResponse) (*Record, error) {
+	rec.Result = Result{
+		State:             Validated,
+		TransactionResult: transactionResult(tx.Meta),
+		LedgerIndex:       uint32(tx.LedgerIndex),
+		Tx:                tx,
+	}
+	return &rec, m.cfg.Store.Save(ctx, rec)
+}
+
+// submit submits the transaction of rec, and saves its engine result. A transport error
+// leaves the record as is: the transaction may or may not have reached the server.
+func (m *Manager) submit(ctx context.Context, rec *Record) error {
+	res, err := xrpl.Query[requests.SubmitResponse](ctx, m.r, &requests.SubmitRequest{
+		TxBlob:   rec.TxBlob,
+		FailHard: m.cfg.FailHard,
+	})
+	if err != nil {
+		return err
+	}
+
+	first := rec.Submissions == 0
+	rec.Submissions++
+	rec.EngineResult = res.EngineResult
+	// A tef result on a resubmission may be caused by the transaction itself, already
+	// included in a ledger, e.g. tefPAST_SEQ or tefALREADY.
+	if strings.HasPrefix(res.EngineResult, "tem") || (first && strings.HasPrefix(res.EngineResult, "tef")) {
+		rec.Result = Result{State: Rejected, TransactionResult: res.EngineResult}
+	}
+	return m.cfg.Store.Save(ctx, *rec)
+}
+
+// retryable reports whether a transaction with the engine result may succeed if
+// submitted again. terQUEUED is not: the queue applies the transaction on its own.
+func retryable(engineResult string) bool {
+	if engineResult == "terQUEUED" {
+		return false
+	}
+	return strings.HasPrefix(engineResult, "ter") || strings.HasPrefix(engineResult, "tel")
+}
+
+// transactionResult returns the result code of transaction metadata.
+func transactionResult(meta any) string {
+	switch m := meta.(type) {
+	case map[string]any:
+		r, _ := m["TransactionResult"].(string)
+		return r
+	case transaction.TxObjMeta:
+		return m.TransactionResult
+	case *transaction.TxObjMeta:
+		return m.TransactionResult
+	}
+	return ""
+}
+
+// searchedAll reports whether the server answering a txnNotFound error held every
+// ledger of the range the transaction was looked up in.
+func searchedAll(err error) bool {
+	searched, _ := xrpl.ErrorResult(err)["searched_all"].(bool)
+	return searched
+}
+
+// sleepContext pauses for d or until ctx is done, whichever happens first.
+// It returns the context error if ctx finished before d elapsed.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,379 @@
+package submission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/xrpl"
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/testify/require"
+)
+
+// codedError is a server error carrying a rippled error code and result, like the
+// clients return.
+type codedError struct {
+	code   string
+	result map[string]any
+}
+
+func (e *codedError) Error() string               { return e.code }
+func (e *codedError) ErrorCode() string           { return e.code }
+func (e *codedError) ErrorResult() map[string]any { return e.result }
+
+// mockServer answers server_info, tx, submit and account_info requests from its fields.
+type mockServer struct {
+	// validated is the validated ledger of the server, and complete its complete_ledgers.
+	validated uint32
+	complete  string
+	// txComplete, if set, is the complete_ledgers of the server answering tx, e.g. another
+	// server of a pool.
+	txComplete string
+	// tx is the tx result, or nil if the transaction is not found.
+	tx map[string]any
+	// engineResults are the engine results of the submissions, in order. The last one
+	// is repeated.
+	engineResults []string
+	submissions   int
+	// queue holds the sequences of the transactions in the queue of the server.
+	queue []int
+}
+
+func (s *mockServer) RequestContext(_ context.Context, req xrpl.Request) (xrpl.Response, error) {
+	var result any
+	switch req.Method() {
+	case "server_info":
+		result = map[string]any{"info": map[string]any{
+			"complete_ledgers": s.complete,
+			"validated_ledger": map[string]any{"seq": s.validated},
+		}}
+	case "tx":
+		if s.tx == nil {
+			complete := s.complete
+			if s.txComplete != "" {
+				complete = s.txComplete
+			}
+			r := req.(*requests.TxRequest)
+			searched := hasLedgers(complete, uint32(r.MinLedger), uint32(r.MaxLedger))
+			return nil, &codedError{code: "txnNotFound", result: map[string]any{"searched_all": searched}}
+		}
+		result = s.tx
+	case "submit":
+		r := s.engineResults[min(s.submissions, len(s.engineResults)-1)]
+		s.submissions++
+		result = map[string]any{"engine_result": r}
+	case "account_info":
+		txs := make([]map[string]any, 0, len(s.queue))
+		for _, seq := range s.queue {
+			txs = append(txs, map[string]any{"seq": seq})
+		}
+		result = map[string]any{"queue_data": map[string]any{"transactions": txs}}
+	default:
+		return nil, errors.New("unexpected request " + req.Method())
+	}
+
+	// Round-trip through JSON so results are decoded the same way as over the wire.
+	b, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var res mockResponse
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type mockResponse map[string]any
+
+func (r mockResponse) GetResult(v any) error {
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{TagName: "json", Result: &v, DecodeHook: mapstructure.TextUnmarshallerHookFunc()})
+	if err != nil {
+		return err
+	}
+	return dec.Decode(map[string]any(r))
+}
+
+func testTxBlob(t *testing.T, lastLedgerSequence uint32) string {
+	t.Helper()
+	tx := map[string]any{
+		"TransactionType": "AccountSet",
+		"Account":         "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe",
+		"Fee":             "12",
+		"Sequence":        uint32(1),
+		"SigningPubKey":   "",
+	}
+	if lastLedgerSequence > 0 {
+		tx["LastLedgerSequence"] = lastLedgerSequence
+	}
+	blob, err := binarycodec.Encode(tx)
+	require.NoError(t, err)
+	return blob
+}
+
+func TestManager_SubmitAndWait(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("validated", func(t *testing.T) {
+		s := &mockServer{validated: 10, complete: "1-10", engineResults: []string{"tesSUCCESS"}}
+		m := NewManager(s, Config{PollInterval: 1})
+
+		rec, err := m.Submit(ctx, testTxBlob(t, 20))
+		require.NoError(t, err)
+		require.Equal(t, Pending, rec.Result.State)
+		require.EqualValues(t, 11, rec.MinLedger)
+
+		// Found, but not validated yet.
+		s.tx = map[string]any{"ledger_index": 11, "validated": false}
+		rec, err = m.Check(ctx, rec.Hash)
+		require.NoError(t, err)
+		require.Equal(t, Pending, rec.Result.State)
+
+		s.tx = map[string]any{"ledger_index": 11, "validated": true, "meta": map[string]any{"TransactionResult": "tecNO_DST"}}
+		rec, err = m.Wait(ctx, rec.Hash)
+		require.NoError(t, err)
+		require.Equal(t, Validated, rec.Result.State)
+		require.Equal(t, "tecNO_DST", rec.Result.TransactionResult)
+		require.EqualValues(t, 11, rec.Result.LedgerIndex)
+		require.Equal(t, &FailedError{TransactionResult: "tecNO_DST"}, rec.Result.Err())
+		require.Equal(t, 1, s.submissions)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		s := &mockServer{validated: 10, complete: "1-10", engineResults: []string{"tesSUCCESS"}}
+		m := NewManager(s, Config{PollInterval: 1})
+
+		rec, err := m.Submit(ctx, testTxBlob(t, 20))
+		require.NoError(t, err)
+
+		s.validated, s.complete = 21, "1-21"
+		rec, err = m.Wait(ctx, rec.Hash)
+		require.NoError(t, err)
+		require.Equal(t, Expired, rec.Result.State)
+		require.ErrorIs(t, rec.Result.Err(), ErrExpired)
+	})
+
+	t.Run("missing ledgers", func(t *testing.T) {
+		s := &mockServer{validated: 10, complete: "1-10", engineResults: []string{"tesSUCCESS"}}
+		m := NewManager(s, Config{PollInterval: 1, MaxChecks: 3})
+
+		rec, err := m.Submit(ctx, testTxBlob(t, 20))
+		require.NoError(t, err)
+
+		// Ledger 15 is missing: the transaction may be in it.
+		s.validated, s.complete = 21, "1-14,16-21"
+		rec, err = m.Wait(ctx, rec.Hash)
+		require.NoError(t, err)
+		require.Equal(t, Pending, rec.Result.State)
+		require.EqualValues(t, 21, rec.ValidatedLedger)
+		require.ErrorIs(t, rec.Result.Err(), ErrPending)
+	})
+
+	t.Run("not found by a server lacking ledgers", func(t *testing.T) {
+		s := &mockServer{validated: 10, complete: "1-10", engineResults: []string{"tesSUCCESS"}}
+		m := NewManager(s, Config{PollInterval: 1})
+
+		rec, err := m.Submit(ctx, testTxBlob(t, 20))
+		require.NoError(t, err)
+
+		// server_info and tx answered by different servers: the complete ledgers of the
+		// first one don't prove the second one could have found the transaction.
+		s.validated, s.complete, s.txComplete = 21, "1-21", "1-14,16-21"
+		rec, err = m.Check(ctx, rec.Hash)
+		require.NoError(t, err)
+		require.Equal(t, Pending, rec.Result.State)
+		require.EqualValues(t, 21, rec.ValidatedLedger)
+
+		s.txComplete = ""
+		rec, err = m.Check(ctx, rec.Hash)
+		require.NoError(t, err)
+		require.Equal(t, Expired, rec.Result.State)
+	})
+
+	t.Run("resubmitted", func(t *testing.T) {
+		s := &mockServer{validated: 10, complete: "1-10", engineResults: []string{"terQUEUED", "telINSUF_FEE_P", "tesSUCCESS"}}
+		m := NewManager(s, Config{PollInterval: 1})
+
+		rec, err := m.Submit(ctx, testTxBlob(t, 20))
+		require.NoError(t, err)
+		require.Equal(t, Pending, rec.Result.State)
+
+		for range 3 {
+			rec, err = m.Check(ctx, rec.Hash)
+			require.NoError(t, err)
+		}
+		require.Equal(t, Pending, rec.Result.State)
+		require.Equal(t, "tesSUCCESS", rec.EngineResult)
+		// Not resubmitted once applied.
+		require.Equal(t, 3, rec.Submissions)
+		require.Equal(t, 3, s.submissions)
+	})
+
+	t.Run("queued", func(t *testing.T) {
+		s := &mockServer{validated: 10, complete: "1-10", engineResults: []string{"terQUEUED", "tesSUCCESS"}, queue: []int{1}}
+		m := NewManager(s, Config{PollInterval: 1})
+
+		rec, err := m.Submit(ctx, testTxBlob(t, 20))
+		require.NoError(t, err)
+
+		// Not resubmitted while in the queue.
+		for range 3 {
+			rec, err = m.Check(ctx, rec.Hash)
+			require.NoError(t, err)
+		}
+		require.Equal(t, Pending, rec.Result.State)
+		require.Equal(t, 1, s.submissions)
+
+		// Dropped from the queue: submitted again.
+		s.queue = nil
+		rec, err = m.Check(ctx, rec.Hash)
+		require.NoError(t, err)
+		require.Equal(t, "tesSUCCESS", rec.EngineResult)
+		require.Equal(t, 2, s.submissions)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		s := &mockServer{validated: 10, complete: "1-10", engineResults: []string{"temBAD_FEE"}}
+		m := NewManager(s, Config{PollInterval: 1})
+
+		rec, err := m.SubmitAndWait(ctx, testTxBlob(t, 20))
+		require.NoError(t, err)
+		require.Equal(t, Rejected, rec.Result.State)
+		require.Equal(t, &RejectedError{EngineResult: "temBAD_FEE"}, rec.Result.Err())
+
+		// Final outcomes are not submitted again.
+		_, err = m.Submit(ctx, testTxBlob(t, 20))
+		require.NoError(t, err)
+		require.Equal(t, 1, s.submissions)
+	})
+
+	t.Run("tef on resubmission", func(t *testing.T) {
+		s := &mockServer{validated: 10, complete: "1-10", engineResults: []string{"terQUEUED", "tefPAST_SEQ"}}
+		m := NewManager(s, Config{PollInterval: 1})
+
+		rec, err := m.Submit(ctx, testTxBlob(t, 20))
+		require.NoError(t, err)
+		rec, err = m.Check(ctx, rec.Hash)
+		require.NoError(t, err)
+		require.Equal(t, Pending, rec.Result.State)
+		require.Equal(t, "tefPAST_SEQ", rec.EngineResult)
+	})
+
+	t.Run("missing LastLedgerSequence", func(t *testing.T) {
+		m := NewManager(&mockServer{}, Config{})
+		_, err := m.Submit(ctx, testTxBlob(t, 0))
+		require.ErrorIs(t, err, ErrMissingLastLedgerSequence)
+	})
+
+	t.Run("unknown transaction", func(t *testing.T) {
+		m := NewManager(&mockServer{}, Config{})
+		_, err := m.Check(ctx, "E08D6E9754025BA2534A78707605E0601F03ACE063687A0CA1BDDACFCD1698C7")
+		require.ErrorIs(t, err, ErrUnknownTransaction)
+	})
+}
+
+func TestManager_Pending(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	s := &mockServer{validated: 10, complete: "1-10", engineResults: []string{"tesSUCCESS"}}
+	m := NewManager(s, Config{Store: store})
+
+	rec, err := m.Submit(ctx, testTxBlob(t, 20))
+	require.NoError(t, err)
+
+	// A new manager on the same store, e.g. after a restart, resumes the transaction.
+	pending, err := store.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, rec.Hash, pending[0].Hash)
+
+	s.tx = map[string]any{"ledger_index": 12, "validated": true, "meta": map[string]any{"TransactionResult": "tesSUCCESS"}}
+	rec, err = NewManager(s, Config{Store: store}).Check(ctx, pending[0].Hash)
+	require.NoError(t, err)
+	require.Equal(t, Validated, rec.Result.State)
+	require.NoError(t, rec.Result.Err())
+
+	pending, err = store.Pending(ctx)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
+
+func TestManager_Observe(t *testing.T) {
+	ctx := context.Background()
+	s := &mockServer{validated: 10, complete: "1-10", engineResults: []string{"tesSUCCESS"}}
+	m := NewManager(s, Config{})
+
+	rec, err := m.Submit(ctx, testTxBlob(t, 20))
+	require.NoError(t, err)
+
+	// Not validated yet.
+	tx := &requests.TxResponse{Hash: types.Hash256(strings.ToLower(rec.Hash)), LedgerIndex: 12}
+	rec, err = m.Observe(ctx, tx)
+	require.NoError(t, err)
+	require.Equal(t, Pending, rec.Result.State)
+
+	tx.Validated = true
+	tx.Meta = transaction.TxObjMeta{TransactionResult: "tesSUCCESS"}
+	rec, err = m.Observe(ctx, tx)
+	require.NoError(t, err)
+	require.Equal(t, Validated, rec.Result.State)
+	require.Equal(t, "tesSUCCESS", rec.Result.TransactionResult)
+	require.EqualValues(t, 12, rec.Result.LedgerIndex)
+
+	_, err = m.Observe(ctx, &requests.TxResponse{Hash: "E08D6E9754025BA2534A78707605E0601F03ACE063687A0CA1BDDACFCD1698C7", Validated: true})
+	require.ErrorIs(t, err, ErrUnknownTransaction)
+}
+
+// hasLedgers reports whether the complete_ledgers ranges of a server, such as
+// "32570-6595042,6595044-6595090", hold every ledger from min to max.
+func hasLedgers(completeLedgers string, min, max uint32) bool {
+	for _, r := range strings.Split(completeLedgers, ",") {
+		first, last, found := strings.Cut(strings.TrimSpace(r), "-")
+		if !found {
+			last = first
+		}
+		lo, err := strconv.ParseUint(first, 10, 32)
+		if err != nil {
+			continue
+		}
+		hi, err := strconv.ParseUint(last, 10, 32)
+		if err != nil {
+			continue
+		}
+		if uint32(lo) <= min && max <= uint32(hi) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestHasLedgers(t *testing.T) {
+	tests := []struct {
+		complete string
+		min, max uint32
+		expected bool
+	}{
+		{"1-100", 10, 20, true},
+		{"15-100", 10, 20, false},
+		{"1-14,16-100", 10, 20, false},
+		{"1-9, 10-30", 10, 20, true},
+		{"10", 10, 10, true},
+		{"empty", 10, 20, false},
+		{"", 10, 20, false},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, hasLedgers(tt.complete, tt.min, tt.max), tt.complete)
+	}
+}
+
+func TestState_String(t *testing.T) {
+	require.Equal(t, "Validated", Validated.String())
+	require.Equal(t, "State(9)", State(9).String())
+}
@@ -0,0 +1,79 @@
+package submission
+
+import (
+	"context"
+	"sync"
+)
+
+// Record is what a Manager persists of a submitted transaction, so that its outcome can
+// still be determined after a restart.
+type Record struct {
+	// Hash is the hash of the signed transaction.
+	Hash string
+	// TxBlob is the signed transaction, resubmitted as is.
+	TxBlob string
+	// LastLedgerSequence is the last ledger the transaction can be included in.
+	LastLedgerSequence uint32
+	// MinLedger is the first ledger the transaction can be included in: the one after the
+	// last validated ledger when it was first submitted.
+	MinLedger uint32
+	// ValidatedLedger is the last validated ledger of the server when the transaction was
+	// last checked.
+	ValidatedLedger uint32
+	// EngineResult is the preliminary result of the last submission.
+	EngineResult string
+	// Submissions is the number of times the transaction was submitted.
+	Submissions int
+	// Result is the outcome of the transaction.
+	Result Result
+}
+
+// Store persists the records of a Manager. Implementations must be safe for concurrent use.
+type Store interface {
+	// Save creates or replaces the record of r.Hash.
+	Save(ctx context.Context, r Record) error
+	// Load returns the record of hash, and false if there is none.
+	Load(ctx context.Context, hash string) (Record, bool, error)
+	// Pending returns the records whose outcome is not final yet.
+	Pending(ctx context.Context) ([]Record, error)
+}
+
+// MemoryStore is a Store keeping records in memory. Records are lost when the process
+// exits: use a persistent Store to resume the submissions of a previous process.
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]Record
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]Record)}
+}
+
+func (s *MemoryStore) Save(_ context.Context, r Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[r.Hash] = r
+	return nil
+}
+
+func (s *MemoryStore) Load(_ context.Context, hash string) (Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.records[hash]
+	return r, ok, nil
+}
+
+func (s *MemoryStore) Pending(_ context.Context) ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pending []Record
+	for _, r := range s.records {
+		if !r.Result.Final() {
+			pending = append(pending, r)
+		}
+	}
+	return pending, nil
+}
@@ -2,6 +2,7 @@ package integration
 
 import (
 	"github.com/Peersyst/xrpl-go/xrpl/common"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/admin"
 	"github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
 	"github.com/Peersyst/xrpl-go/xrpl/transaction"
 	"github.com/Peersyst/xrpl-go/xrpl/wallet"
@@ -21,6 +22,12 @@ type Client interface {
 	SubmitMultisigned(blob string, validate bool) (*transactions.SubmitMultisignedResponse, error)
 }
 
+// LedgerAcceptor is implemented by the clients able to close the ledgers of a
+// stand-alone server.
+type LedgerAcceptor interface {
+	LedgerAccept() (*admin.LedgerAcceptResponse, error)
+}
+
 type Connectable interface {
 	Connect() error
 	Disconnect() error
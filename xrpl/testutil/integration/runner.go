@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/Peersyst/xrpl-go/pkg/crypto"
@@ -10,6 +11,10 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// ErrLedgerAcceptUnsupported is returned by Runner.LedgerAccept when the client can't
+// close ledgers.
+var ErrLedgerAcceptUnsupported = errors.New("client does not support ledger_accept")
+
 type Runner struct {
 	t      *testing.T
 	config *RunnerConfig
@@ -98,6 +103,18 @@ func (r *Runner) TestMultisigTransaction(blob string, expectedEngineResult strin
 	return tx, nil
 }
 
+// LedgerAccept closes the current ledger, when running against a server in stand-alone
+// mode, so the transactions submitted so far are validated. The client must implement
+// LedgerAcceptor and be connected to an admin endpoint.
+func (r *Runner) LedgerAccept() error {
+	acceptor, ok := r.client.(LedgerAcceptor)
+	if !ok {
+		return ErrLedgerAcceptUnsupported
+	}
+	_, err := acceptor.LedgerAccept()
+	return err
+}
+
 // GetWallet returns a wallet by index.
 func (r *Runner) GetWallet(index int) *wallet.Wallet {
 	if index < 0 || index >= len(r.wallets) {
@@ -0,0 +1,258 @@
+package websocket
+
+import (
+	"context"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/admin"
+)
+
+// LedgerAccept closes the current ledger of a server in stand-alone mode, where ledgers
+// are not closed otherwise. It returns the index of the new current ledger.
+func (c *Client) LedgerAccept() (*admin.LedgerAcceptResponse, error) {
+	return c.LedgerAcceptContext(context.Background())
+}
+
+// LedgerAcceptContext is like LedgerAccept but uses ctx to cancel the request or bound its duration.
+func (c *Client) LedgerAcceptContext(ctx context.Context) (*admin.LedgerAcceptResponse, error) {
+	res, err := c.RequestContext(ctx, &admin.LedgerAcceptRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var ar admin.LedgerAcceptResponse
+	err = res.GetResult(&ar)
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
+
+// WalletPropose asks the server to generate a key pair and an address.
+func (c *Client) WalletPropose(req *admin.WalletProposeRequest) (*admin.WalletProposeResponse, error) {
+	return c.WalletProposeContext(context.Background(), req)
+}
+
+// WalletProposeContext is like WalletPropose but uses ctx to cancel the request or bound its duration.
+func (c *Client) WalletProposeContext(ctx context.Context, req *admin.WalletProposeRequest) (*admin.WalletProposeResponse, error) {
+	res, err := c.RequestContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var ar admin.WalletProposeResponse
+	err = res.GetResult(&ar)
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
+
+// ValidationCreate asks the server to generate keys to sign validations.
+func (c *Client) ValidationCreate(req *admin.ValidationCreateRequest) (*admin.ValidationCreateResponse, error) {
+	return c.ValidationCreateContext(context.Background(), req)
+}
+
+// ValidationCreateContext is like ValidationCreate but uses ctx to cancel the request or bound its duration.
+func (c *Client) ValidationCreateContext(ctx context.Context, req *admin.ValidationCreateRequest) (*admin.ValidationCreateResponse, error) {
+	res, err := c.RequestContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var ar admin.ValidationCreateResponse
+	err = res.GetResult(&ar)
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
+
+// GetPeers retrieves the peers of the server and the members of its cluster.
+func (c *Client) GetPeers() (*admin.PeersResponse, error) {
+	return c.GetPeersContext(context.Background())
+}
+
+// GetPeersContext is like GetPeers but uses ctx to cancel the request or bound its duration.
+func (c *Client) GetPeersContext(ctx context.Context) (*admin.PeersResponse, error) {
+	res, err := c.RequestContext(ctx, &admin.PeersRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var ar admin.PeersResponse
+	err = res.GetResult(&ar)
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
+
+// ConnectPeer asks the server to connect to a specific peer, with the connect method.
+func (c *Client) ConnectPeer(req *admin.ConnectRequest) (*admin.ConnectResponse, error) {
+	return c.ConnectPeerContext(context.Background(), req)
+}
+
+// ConnectPeerContext is like ConnectPeer but uses ctx to cancel the request or bound its duration.
+func (c *Client) ConnectPeerContext(ctx context.Context, req *admin.ConnectRequest) (*admin.ConnectResponse, error) {
+	res, err := c.RequestContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var ar admin.ConnectResponse
+	err = res.GetResult(&ar)
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
+
+// LogLevel changes the log verbosity of the server, or retrieves it if the request has
+// no severity.
+func (c *Client) LogLevel(req *admin.LogLevelRequest) (*admin.LogLevelResponse, error) {
+	return c.LogLevelContext(context.Background(), req)
+}
+
+// LogLevelContext is like LogLevel but uses ctx to cancel the request or bound its duration.
+func (c *Client) LogLevelContext(ctx context.Context, req *admin.LogLevelRequest) (*admin.LogLevelResponse, error) {
+	res, err := c.RequestContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var ar admin.LogLevelResponse
+	err = res.GetResult(&ar)
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
+
+// CanDelete sets or retrieves the latest ledger the server may delete with online
+// deletion in advisory mode.
+func (c *Client) CanDelete(req *admin.CanDeleteRequest) (*admin.CanDeleteResponse, error) {
+	return c.CanDeleteContext(context.Background(), req)
+}
+
+// CanDeleteContext is like CanDelete but uses ctx to cancel the request or bound its duration.
+func (c *Client) CanDeleteContext(ctx context.Context, req *admin.CanDeleteRequest) (*admin.CanDeleteResponse, error) {
+	res, err := c.RequestContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var ar admin.CanDeleteResponse
+	err = res.GetResult(&ar)
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
+
+// LedgerCleaner configures the ledger cleaner of the server.
+func (c *Client) LedgerCleaner(req *admin.LedgerCleanerRequest) (*admin.LedgerCleanerResponse, error) {
+	return c.LedgerCleanerContext(context.Background(), req)
+}
+
+// LedgerCleanerContext is like LedgerCleaner but uses ctx to cancel the request or bound its duration.
+func (c *Client) LedgerCleanerContext(ctx context.Context, req *admin.LedgerCleanerRequest) (*admin.LedgerCleanerResponse, error) {
+	res, err := c.RequestContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var ar admin.LedgerCleanerResponse
+	err = res.GetResult(&ar)
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
+
+// LedgerRequest asks the server to fetch a ledger from its peers, and returns its header
+// if the server has it already.
+func (c *Client) LedgerRequest(req *admin.LedgerRequestRequest) (*admin.LedgerRequestResponse, error) {
+	return c.LedgerRequestContext(context.Background(), req)
+}
+
+// LedgerRequestContext is like LedgerRequest but uses ctx to cancel the request or bound its duration.
+func (c *Client) LedgerRequestContext(ctx context.Context, req *admin.LedgerRequestRequest) (*admin.LedgerRequestResponse, error) {
+	res, err := c.RequestContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var ar admin.LedgerRequestResponse
+	err = res.GetResult(&ar)
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
+
+// GetCounts retrieves health information about the server.
+func (c *Client) GetCounts(req *admin.GetCountsRequest) (*admin.GetCountsResponse, error) {
+	return c.GetCountsContext(context.Background(), req)
+}
+
+// GetCountsContext is like GetCounts but uses ctx to cancel the request or bound its duration.
+func (c *Client) GetCountsContext(ctx context.Context, req *admin.GetCountsRequest) (*admin.GetCountsResponse, error) {
+	res, err := c.RequestContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var ar admin.GetCountsResponse
+	err = res.GetResult(&ar)
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
+
+// GetConsensusInfo retrieves the state of the consensus process of the server.
+func (c *Client) GetConsensusInfo() (*admin.ConsensusInfoResponse, error) {
+	return c.GetConsensusInfoContext(context.Background())
+}
+
+// GetConsensusInfoContext is like GetConsensusInfo but uses ctx to cancel the request or bound its duration.
+func (c *Client) GetConsensusInfoContext(ctx context.Context) (*admin.ConsensusInfoResponse, error) {
+	res, err := c.RequestContext(ctx, &admin.ConsensusInfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var ar admin.ConsensusInfoResponse
+	err = res.GetResult(&ar)
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
+
+// FeatureVote sets whether the server votes in favor of an amendment or vetoes it.
+func (c *Client) FeatureVote(req *admin.FeatureVoteRequest) (*admin.FeatureVoteResponse, error) {
+	return c.FeatureVoteContext(context.Background(), req)
+}
+
+// FeatureVoteContext is like FeatureVote but uses ctx to cancel the request or bound its duration.
+func (c *Client) FeatureVoteContext(ctx context.Context, req *admin.FeatureVoteRequest) (*admin.FeatureVoteResponse, error) {
+	res, err := c.RequestContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var ar admin.FeatureVoteResponse
+	err = res.GetResult(&ar)
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
+
+// Stop gracefully shuts the server down.
+func (c *Client) Stop() (*admin.StopResponse, error) {
+	return c.StopContext(context.Background())
+}
+
+// StopContext is like Stop but uses ctx to cancel the request or bound its duration.
+func (c *Client) StopContext(ctx context.Context) (*admin.StopResponse, error) {
+	res, err := c.RequestContext(ctx, &admin.StopRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var ar admin.StopResponse
+	err = res.GetResult(&ar)
+	if err != nil {
+		return nil, err
+	}
+	return &ar, nil
+}
@@ -0,0 +1,51 @@
+package websocket
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/admin"
+)
+
+func TestClient_LedgerAccept(t *testing.T) {
+	cl, cleanup := setupTestClient(t, []map[string]any{
+		{
+			"id": 1,
+			"result": map[string]any{
+				"ledger_current_index": 6643240,
+			},
+		},
+	})
+	defer cleanup()
+
+	res, err := cl.LedgerAccept()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := &admin.LedgerAcceptResponse{LedgerCurrentIndex: 6643240}
+	if !reflect.DeepEqual(expected, res) {
+		t.Errorf("Expected %+v, but got %+v", expected, res)
+	}
+}
+
+func TestClient_AdminRequestRefused(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  ClientConfig
+	}{
+		{name: "remote host", cfg: NewClientConfig().WithHost("wss://s.altnet.rippletest.net:51233")},
+		{name: "local non-admin host", cfg: NewClientConfig().WithHost("ws://localhost:6006").WithAdmin(false)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := NewClient(tt.cfg)
+			_, err := cl.GetPeers()
+			if !errors.Is(err, xrpl.ErrAdminRequest) {
+				t.Errorf("Expected error %v, but got %v", xrpl.ErrAdminRequest, err)
+			}
+		})
+	}
+}
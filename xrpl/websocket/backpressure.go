@@ -0,0 +1,148 @@
+package websocket
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	streamtypes "github.com/Peersyst/xrpl-go/xrpl/queries/subscription/types"
+)
+
+var (
+	ErrStreamOverflow = errors.New("stream buffer full")
+)
+
+// OverflowPolicy decides what happens to a stream message when the buffer of its handler
+// is full, because the handler is slower than the stream.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for the handler. Responses and other streams wait too, and the
+	// client reconnects if the ping of the connection is delayed too long.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest message of the buffer to make room for the new one.
+	OverflowDropOldest
+	// OverflowDropNewest drops the new message.
+	OverflowDropNewest
+	// OverflowDisconnect drops the new message, and closes the connection with an error
+	// wrapping ErrStreamOverflow. The client then reconnects.
+	OverflowDisconnect
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "block"
+	case OverflowDropOldest:
+		return "drop-oldest"
+	case OverflowDropNewest:
+		return "drop-newest"
+	case OverflowDisconnect:
+		return "disconnect"
+	}
+	return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+}
+
+// StreamBuffer is the buffer size of the handler of a stream, and the policy applied when
+// it is full.
+type StreamBuffer struct {
+	Size   int
+	Policy OverflowPolicy
+}
+
+// DefaultStreamBuffer is the buffer of streams without a buffer of their own, apart from
+// transactions, which are buffered with 40 messages.
+var DefaultStreamBuffer = StreamBuffer{Size: 10, Policy: OverflowBlock}
+
+// dropCounter counts the stream messages dropped, by stream type.
+type dropCounter struct {
+	mu      sync.Mutex
+	dropped map[streamtypes.Type]uint64
+}
+
+func (d *dropCounter) add(t streamtypes.Type) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.dropped == nil {
+		d.dropped = make(map[streamtypes.Type]uint64)
+	}
+	d.dropped[t]++
+}
+
+func (d *dropCounter) get(t streamtypes.Type) uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dropped[t]
+}
+
+// Dropped returns the number of messages of type t dropped because the buffer of their
+// handler was full, see ClientConfig.WithStreamBuffer. Messages of subscription handles
+// are counted too.
+func (c *Client) Dropped(t streamtypes.Type) uint64 {
+	return c.drops.get(t)
+}
+
+// streamBuffer returns the buffer of the streams of type t.
+func (c *Client) streamBuffer(t streamtypes.Type) StreamBuffer {
+	if b, ok := c.cfg.streamBuffers[t]; ok {
+		return b
+	}
+	if c.cfg.defaultStreamBuffer == nil && t == streamtypes.TransactionStreamType {
+		return StreamBuffer{Size: 40, Policy: OverflowBlock}
+	}
+	return c.defaultStreamBuffer()
+}
+
+// defaultStreamBuffer returns the buffer of the streams without settings of their own.
+func (c *Client) defaultStreamBuffer() StreamBuffer {
+	if c.cfg.defaultStreamBuffer != nil {
+		return *c.cfg.defaultStreamBuffer
+	}
+	return DefaultStreamBuffer
+}
+
+// sendStream sends a message of type t to ch, applying the overflow policy of t when ch
+// is full. It returns an error if the connection must be closed. done, if not nil, stops
+// a blocked send.
+func sendStream[T any](c *Client, t streamtypes.Type, ch chan T, v T, done <-chan struct{}) error {
+	return sendStreamWith(c, t, c.streamBuffer(t).Policy, ch, v, done)
+}
+
+// sendStreamWith is like sendStream, with the overflow policy given.
+func sendStreamWith[T any](c *Client, t streamtypes.Type, policy OverflowPolicy, ch chan T, v T, done <-chan struct{}) error {
+	if policy == OverflowBlock {
+		select {
+		case ch <- v:
+		case <-done:
+		}
+		return nil
+	}
+
+	select {
+	case ch <- v:
+		return nil
+	default:
+	}
+
+	switch policy {
+	case OverflowDropOldest:
+		for {
+			select {
+			case <-ch:
+				c.drops.add(t)
+			default:
+			}
+			select {
+			case ch <- v:
+				return nil
+			default:
+			}
+		}
+	case OverflowDisconnect:
+		c.drops.add(t)
+		return fmt.Errorf("%w: %s", ErrStreamOverflow, t)
+	default:
+		c.drops.add(t)
+		return nil
+	}
+}
@@ -0,0 +1,144 @@
+package websocket
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	streamtypes "github.com/Peersyst/xrpl-go/xrpl/queries/subscription/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/utility"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket/testutil"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendStream(t *testing.T) {
+	tests := []struct {
+		policy   OverflowPolicy
+		expected []int
+		dropped  uint64
+		err      error
+	}{
+		{policy: OverflowDropOldest, expected: []int{2, 3}, dropped: 1},
+		{policy: OverflowDropNewest, expected: []int{1, 2}, dropped: 1},
+		{policy: OverflowDisconnect, expected: []int{1, 2}, dropped: 1, err: ErrStreamOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			c := NewClient(NewClientConfig().WithStreamBuffer(streamtypes.LedgerStreamType, 2, tt.policy))
+			ch := make(chan int, c.streamBuffer(streamtypes.LedgerStreamType).Size)
+
+			var err error
+			for i := 1; i <= 3 && err == nil; i++ {
+				err = sendStream(c, streamtypes.LedgerStreamType, ch, i, nil)
+			}
+			require.ErrorIs(t, err, tt.err)
+
+			close(ch)
+			var got []int
+			for v := range ch {
+				got = append(got, v)
+			}
+			require.Equal(t, tt.expected, got)
+			require.Equal(t, tt.dropped, c.Dropped(streamtypes.LedgerStreamType))
+			require.Zero(t, c.Dropped(streamtypes.TransactionStreamType))
+		})
+	}
+
+	t.Run("block", func(t *testing.T) {
+		c := NewClient(NewClientConfig().WithStreamBuffer(streamtypes.LedgerStreamType, 0, OverflowBlock))
+		done := make(chan struct{})
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			close(done)
+		}()
+		require.NoError(t, sendStream(c, streamtypes.LedgerStreamType, make(chan int), 1, done))
+		require.Zero(t, c.Dropped(streamtypes.LedgerStreamType))
+	})
+}
+
+// ledgerFloodServer answers requests, and sends n ledger messages after each ping.
+func ledgerFloodServer(t *testing.T, n int) func(c *websocket.Conn) {
+	return func(c *websocket.Conn) {
+		for {
+			var req map[string]any
+			if err := c.ReadJSON(&req); err != nil {
+				return
+			}
+			if req["command"] == "ping" {
+				for i := 0; i < n; i++ {
+					if err := c.WriteJSON(map[string]any{"type": "ledgerClosed", "ledger_index": i}); err != nil {
+						return
+					}
+				}
+			}
+			if err := c.WriteJSON(map[string]any{"id": req["id"], "result": map[string]any{}}); err != nil {
+				t.Errorf("error writing message: %v", err)
+			}
+		}
+	}
+}
+
+func TestClient_SlowHandlerDoesNotBlockRequests(t *testing.T) {
+	ws := &testutil.MockWebSocketServer{}
+	s := ws.TestWebSocketServer(ledgerFloodServer(t, 20))
+	defer s.Close()
+
+	url, _ := testutil.ConvertHTTPToWS(s.URL)
+	cl := NewClient(NewClientConfig().
+		WithHost(url).
+		WithTimeout(2*time.Second).
+		WithStreamBuffer(streamtypes.LedgerStreamType, 5, OverflowDropNewest))
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+	cl.OnLedgerClosed(func(*streamtypes.LedgerStream) { <-unblock })
+
+	require.NoError(t, cl.Connect())
+	defer cl.Disconnect()
+
+	// The response comes after the ledger messages, which the handler doesn't read.
+	_, err := cl.Ping(&utility.PingRequest{})
+	require.NoError(t, err)
+	// 5 messages are buffered, and one is being handled if the handler was fast enough.
+	require.Contains(t, []uint64{14, 15}, cl.Dropped(streamtypes.LedgerStreamType))
+}
+
+func TestClient_OverflowDisconnect(t *testing.T) {
+	ws := &testutil.MockWebSocketServer{}
+	s := ws.TestWebSocketServer(ledgerFloodServer(t, 20))
+	defer s.Close()
+
+	url, _ := testutil.ConvertHTTPToWS(s.URL)
+	cl := NewClient(NewClientConfig().
+		WithHost(url).
+		WithDefaultStreamBuffer(5, OverflowDisconnect))
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+	cl.OnLedgerClosed(func(*streamtypes.LedgerStream) { <-unblock })
+
+	lost := make(chan error, 1)
+	cl.OnDisconnected(func(err error) {
+		if err != nil {
+			select {
+			case lost <- err:
+			default:
+			}
+		}
+	})
+
+	require.NoError(t, cl.Connect())
+	defer cl.Disconnect()
+
+	_, err := cl.Ping(&utility.PingRequest{})
+	require.True(t, errors.Is(err, ErrConnectionClosed), "unexpected error: %v", err)
+
+	select {
+	case err := <-lost:
+		require.ErrorIs(t, err, ErrStreamOverflow)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the disconnection")
+	}
+}
@@ -5,25 +5,24 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math"
-	"strconv"
-	"strings"
+	"log/slog"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
-	"github.com/Peersyst/xrpl-go/xrpl/currency"
-	"github.com/Peersyst/xrpl-go/xrpl/hash"
+	"github.com/Peersyst/xrpl-go/binary-codec/definitions"
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/autofill"
+	"github.com/Peersyst/xrpl-go/xrpl/queries"
 	transaction "github.com/Peersyst/xrpl-go/xrpl/transaction"
 	"github.com/mitchellh/mapstructure"
 
-	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
-	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
-	"github.com/Peersyst/xrpl-go/xrpl/queries/server"
 	streamtypes "github.com/Peersyst/xrpl-go/xrpl/queries/subscription/types"
 	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
 	"github.com/Peersyst/xrpl-go/xrpl/queries/utility"
-	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/Peersyst/xrpl-go/xrpl/ratelimit"
+	"github.com/Peersyst/xrpl-go/xrpl/submission"
 	"github.com/Peersyst/xrpl-go/xrpl/wallet"
 	"github.com/Peersyst/xrpl-go/xrpl/websocket/interfaces"
 	wstypes "github.com/Peersyst/xrpl-go/xrpl/websocket/types"
@@ -35,29 +34,46 @@ const (
 	DefaultFeeCushion float32 = 1.2
 	DefaultMaxFeeXRP  float32 = 2
 
-	// Sidechains are expected to have network IDs above this.
-	// Networks with ID above this restricted number are expected specify an accurate NetworkID field
-	// in every transaction to that chain to prevent replay attacks.
-	// Mainnet and testnet are exceptions. More context: https://github.com/XRPLF/rippled/pull/4370
-	RestrictedNetworks       = 1024
-	RequiredNetworkIDVersion = "1.11.0"
+	// Kept for compatibility, see the autofill package.
+	RestrictedNetworks       = autofill.RestrictedNetworks
+	RequiredNetworkIDVersion = autofill.RequiredNetworkIDVersion
 )
 
 var (
 	ErrIncorrectID          = errors.New("incorrect id")
 	ErrNotConnectedToServer = errors.New("not connected to server")
 	ErrRequestTimedOut      = errors.New("request timed out")
+	ErrConnectionClosed     = errors.New("connection closed before the response was received")
 )
 
+var _ xrpl.Client = (*Client)(nil)
+var _ xrpl.Admin = (*Client)(nil)
+
 type Client struct {
+	// Typed queries and iterators, sent through the client.
+	queries.Methods
+
 	cfg           ClientConfig
 	conn          *Connection
 	subscriptions *subscriptions
+	fanout        *fanout
+	confirmations *confirmations
+	requests      *inflight
+	drops         dropCounter
+	definitions   xrpl.DefinitionsCache
+	capabilities  xrpl.CapabilitiesCache
+
+	// Cancels the connection manager of the current connection, and holds the open
+	// path_find session.
+	mu         sync.Mutex
+	disconnect context.CancelFunc
+	pathFind   *PathFindSession
+
+	// Logging and lifecycle callbacks
+	logger *slog.Logger
+	events lifecycle
 
 	// Channels
-	errChan          chan error
-	debugChan        chan string
-	requestChan      chan *ClientResponse
 	ledgerClosedChan chan *streamtypes.LedgerStream
 	validationChan   chan *streamtypes.ValidationStream
 	transactionChan  chan *streamtypes.TransactionStream
@@ -65,22 +81,31 @@ type Client struct {
 	orderBookChan    chan *streamtypes.OrderBookStream
 	bookChangesChan  chan *streamtypes.BookChangesStream
 	consensusChan    chan *streamtypes.ConsensusStream
-	disconnectChan   chan struct{}
+	manifestChan     chan *streamtypes.ManifestStream
+	serverStatusChan chan *streamtypes.ServerStream
+	pathFindChan     chan *streamtypes.PathFindStream
 
 	idCounter atomic.Uint32
 	NetworkID uint32
 }
 
 // Creates a new websocket client with cfg.
-// This client will open and close a websocket connection for each request.
+// A single connection is shared by every request; concurrent requests are matched
+// to their responses by ID, so the client is safe for concurrent use.
 func NewClient(cfg ClientConfig) *Client {
-	return &Client{
-		cfg:            cfg,
-		requestChan:    make(chan *ClientResponse, 30),
-		conn:           NewConnection(cfg.host),
-		subscriptions:  buildNewSubscriptions(),
-		disconnectChan: make(chan struct{}),
+	c := &Client{
+		cfg:           cfg,
+		conn:          NewConnection(cfg.host),
+		subscriptions: buildNewSubscriptions(),
+		fanout:        newFanout(),
+		confirmations: newConfirmations(),
+		requests:      newInflight(),
 	}
+	if cfg.logger != nil {
+		c.logger = cfg.logger.With(slog.String("host", cfg.host))
+	}
+	c.Methods = queries.NewMethods(c.Requester())
+	return c
 }
 
 // Connect opens a websocket connection to the server. It starts reading messages in a goroutine.
@@ -94,11 +119,12 @@ func (c *Client) Connect() error {
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
+	c.mu.Lock()
+	c.disconnect = cancel
+	c.mu.Unlock()
+
+	c.connected()
 	go c.connectionManager(ctx)
-	go func() {
-		<-c.disconnectChan
-		cancel()
-	}()
 
 	return nil
 }
@@ -114,23 +140,11 @@ func (c *Client) connectionManager(disconnectCtx context.Context) {
 
 		if initRun {
 			initRun = false
-		} else {
-			c.error(fmt.Errorf("reconnecting to %s", c.cfg.host))
-			if err := c.conn.Disconnect(); err != nil {
-				c.debug(fmt.Sprintf("error to disconnect while reconnecting: %v", err))
-			}
-			if err := c.conn.Connect(); err != nil {
-				c.error(fmt.Errorf("error in connection attempt, waiting 1s and try to connect again: %w", err))
-				time.Sleep(1 * time.Second)
-				continue
-			}
-			c.error(fmt.Errorf("connected to %s", c.cfg.host))
-
-			go c.resubscribe() // Sends subscription message.
+		} else if !c.reconnect(disconnectCtx) {
+			return
 		}
 
 		tickerCtx, tickerCtxCancel := context.WithCancel(disconnectCtx)
-		defer tickerCtxCancel()
 		go func() {
 			ticker := time.NewTicker(20 * time.Second)
 			defer ticker.Stop()
@@ -139,10 +153,12 @@ func (c *Client) connectionManager(disconnectCtx context.Context) {
 				case <-tickerCtx.Done():
 					return
 				case <-ticker.C:
-					_, err := c.Ping(&utility.PingRequest{})
+					_, err := c.PingContext(tickerCtx, &utility.PingRequest{})
 					if err != nil {
-						c.error(fmt.Errorf("error while pinging: %w", err))
-						c.conn.Disconnect()
+						if tickerCtx.Err() == nil {
+							c.log(slog.LevelWarn, "ping failed, closing the connection", slog.Any("error", err))
+							c.conn.Disconnect()
+						}
 						return
 					}
 				}
@@ -150,30 +166,89 @@ func (c *Client) connectionManager(disconnectCtx context.Context) {
 		}()
 
 		err := c.readMessages()
-		if err != nil {
-			c.error(err)
-		}
 		tickerCtxCancel()
+		// Responses to requests sent on the lost connection will never arrive.
+		c.requests.failAll()
+
+		if disconnectCtx.Err() != nil {
+			// Closed with Disconnect, which reports it.
+			return
+		}
+		c.disconnected(err)
 	}
 }
 
-// This function send a subscription message, which response is read in readMessages().
+// reconnect connects again until it succeeds, and restores the subscriptions.
+// It returns false if the client was disconnected in the meantime.
+func (c *Client) reconnect(disconnectCtx context.Context) bool {
+	for attempt := 1; ; attempt++ {
+		c.reconnecting(attempt)
+		if err := c.conn.Disconnect(); err != nil && !errors.Is(err, ErrNotConnected) {
+			c.debug("error closing the connection before reconnecting", slog.Any("error", err))
+		}
+		err := c.conn.Connect()
+		if err == nil {
+			break
+		}
+		c.error("reconnection attempt failed, trying again in 1s", err)
+		if sleepContext(disconnectCtx, 1*time.Second) != nil {
+			return false
+		}
+	}
+
+	if disconnectCtx.Err() != nil {
+		_ = c.conn.Disconnect()
+		return false
+	}
+	c.connected()
+
+	go c.resubscribe(disconnectCtx) // Sends subscription message.
+	return true
+}
+
+// This function send a subscription message, and the path_find create request of the
+// open session, which responses are read in readMessages().
 // So if an error occurs, it will trigger exit from readMessages() and reconnect in connectionManager().
-func (c *Client) resubscribe() error {
+func (c *Client) resubscribe(ctx context.Context) error {
 	subscribeRequest := c.subscriptions.buildSubscribeRequest()
-	_, err := c.Subscribe(subscribeRequest)
+	c.fanout.addTo(subscribeRequest)
+	_, err := c.RequestContext(ctx, subscribeRequest)
 	if err != nil {
-		c.error(fmt.Errorf("error while resubscribing: %w", err))
+		c.error("error while resubscribing", err)
 		return err
 	}
+	if s := c.pathFindSession(); s != nil {
+		if err := s.resume(ctx); err != nil {
+			c.error("error while resuming path_find", err)
+			return err
+		}
+	}
+	c.fanout.resume()
 
+	c.resubscribed()
 	return nil
 }
 
-// Disconnect closes the websocket connection.
+// Disconnect closes the websocket connection and the path_find session.
+// Requests still waiting for a response fail with ErrConnectionClosed.
 func (c *Client) Disconnect() error {
-	c.disconnectChan <- struct{}{}
-	return c.conn.Disconnect()
+	c.mu.Lock()
+	wasConnected := c.disconnect != nil
+	if c.disconnect != nil {
+		c.disconnect()
+		c.disconnect = nil
+	}
+	c.mu.Unlock()
+	if s := c.pathFindSession(); s != nil {
+		s.detach()
+	}
+
+	err := c.conn.Disconnect()
+	c.requests.failAll()
+	if wasConnected {
+		c.disconnected(nil)
+	}
+	return err
 }
 
 // IsConnected returns true if the client is connected to the server.
@@ -187,77 +262,24 @@ func (c *Client) FaucetProvider() commonconstants.FaucetProvider {
 
 // Autofill fills in the missing fields in a transaction.
 func (c *Client) Autofill(tx *transaction.FlatTransaction) error {
-	if err := c.setValidTransactionAddresses(tx); err != nil {
-		return err
-	}
-
-	err := c.setTransactionFlags(tx)
-	if err != nil {
-		return err
-	}
-
-	if _, ok := (*tx)["NetworkID"]; !ok {
-		if c.NetworkID != 0 {
-			(*tx)["NetworkID"] = c.NetworkID
-		}
-	}
-	if _, ok := (*tx)["Sequence"]; !ok {
-		err := c.setTransactionNextValidSequenceNumber(tx)
-		if err != nil {
-			return err
-		}
-	}
-	if _, ok := (*tx)["Fee"]; !ok {
-		err := c.calculateFeePerTransactionType(tx, 0)
-		if err != nil {
-			return err
-		}
-	}
-	if _, ok := (*tx)["LastLedgerSequence"]; !ok {
-		err := c.setLastLedgerSequence(tx)
-		if err != nil {
-			return err
-		}
-	}
+	return c.AutofillContext(context.Background(), tx)
+}
 
-	if txType, ok := (*tx)["TransactionType"].(string); ok {
-		if acc, ok := (*tx)["Account"].(types.Address); txType == transaction.AccountDeleteTx.String() && ok {
-			err := c.checkAccountDeleteBlockers(acc)
-			if err != nil {
-				return err
-			}
-		}
-		if txType == transaction.PaymentTx.String() {
-			err := c.checkPaymentAmounts(tx)
-			if err != nil {
-				return err
-			}
-		}
-		if txType == transaction.BatchTx.String() {
-			err := c.autofillRawTransactions(tx)
-			if err != nil {
-				return err
-			}
-		}
-	}
-	return nil
+// AutofillContext is like Autofill but uses ctx to cancel the queries it issues.
+func (c *Client) AutofillContext(ctx context.Context, tx *transaction.FlatTransaction) error {
+	return c.autofiller().Autofill(ctx, tx)
 }
 
 // AutofillMultisigned fills in the missing fields in a multisigned transaction.
 // This function is used to fill in the missing fields in a multisigned transaction.
 // It fills in the missing fields in the transaction and calculates the fee per number of signers.
 func (c *Client) AutofillMultisigned(tx *transaction.FlatTransaction, nSigners uint64) error {
-	err := c.Autofill(tx)
-	if err != nil {
-		return err
-	}
-
-	err = c.calculateFeePerTransactionType(tx, nSigners)
-	if err != nil {
-		return err
-	}
+	return c.AutofillMultisignedContext(context.Background(), tx, nSigners)
+}
 
-	return nil
+// AutofillMultisignedContext is like AutofillMultisigned but uses ctx to cancel the queries it issues.
+func (c *Client) AutofillMultisignedContext(ctx context.Context, tx *transaction.FlatTransaction, nSigners uint64) error {
+	return c.autofiller().AutofillMultisigned(ctx, tx, nSigners)
 }
 
 // FundWallet funds a wallet with XRP from the faucet.
@@ -279,15 +301,44 @@ func (c *Client) FundWallet(wallet *wallet.Wallet) error {
 // This function is used to send requests to the server.
 // It returns the response from the server.
 func (c *Client) Request(req interfaces.Request) (*ClientResponse, error) {
+	return c.RequestContext(context.Background(), req)
+}
+
+// RequestContext is like Request but stops waiting for the response as soon as ctx is done,
+// returning the context error. The client timeout still applies when ctx has no earlier deadline.
+//
+// The request goes through the configured middlewares first. Middlewares answering
+// without calling the next handler must return a *ClientResponse.
+func (c *Client) RequestContext(ctx context.Context, req interfaces.Request) (*ClientResponse, error) {
+	res, err := xrpl.Chain(c.do, c.cfg.middlewares...)(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	clientRes, ok := res.(*ClientResponse)
+	if !ok {
+		return nil, ErrUnexpectedResponse
+	}
+	return clientRes, nil
+}
+
+// do is the last handler of the middleware chain: it sends the request to the server
+// and waits for its response.
+func (c *Client) do(ctx context.Context, req xrpl.Request) (xrpl.Response, error) {
 	err := req.Validate()
 	if err != nil {
 		return nil, err
 	}
+	if err := xrpl.CheckEndpoint(req, c.isAdmin()); err != nil {
+		return nil, err
+	}
+	if err := c.capabilities.Check(req); err != nil {
+		return nil, err
+	}
 
 	id := c.idCounter.Add(1)
-	c.debug(fmt.Sprintf("id: %v, req: %+v", id, req))
+	c.debug("sending request", slog.Int("id", int(id)), slog.String("method", req.Method()))
 
-	msg, err := c.formatRequest(req, int(id), nil)
+	msg, err := c.formatRequest(req, int(id))
 	if err != nil {
 		return nil, err
 	}
@@ -296,12 +347,26 @@ func (c *Client) Request(req interfaces.Request) (*ClientResponse, error) {
 		return nil, ErrNotConnectedToServer
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if c.cfg.rateLimiter != nil {
+		if err := c.cfg.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// Register before writing so a fast response can't miss its waiter.
+	resChan := c.requests.register(int(id))
+
 	err = c.conn.WriteMessage(msg)
 	if err != nil {
+		c.requests.forget(int(id))
 		return nil, err
 	}
 
-	res, err := c.awaitResponse(int(id))
+	res, err := c.awaitResponse(ctx, int(id), resChan)
 	if err != nil {
 		return nil, err
 	}
@@ -309,75 +374,104 @@ func (c *Client) Request(req interfaces.Request) (*ClientResponse, error) {
 	if res.ID != int(id) {
 		return nil, ErrIncorrectID
 	}
+	if c.cfg.rateLimiter != nil {
+		c.cfg.rateLimiter.Observe(ratelimit.SignalFor(res.Warning, res.Error))
+	}
 	if err := res.CheckError(); err != nil {
-		return nil, err
+		return nil, xrpl.WrapClioError(req, err)
 	}
 
 	return res, nil
 }
 
+// Requester returns the client as an xrpl.Requester, so transport independent helpers
+// can send requests through it.
+func (c *Client) Requester() xrpl.Requester {
+	return xrpl.RequesterFunc(func(ctx context.Context, req xrpl.Request) (xrpl.Response, error) {
+		res, err := c.RequestContext(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	})
+}
+
+// Definitions returns the binary codec definitions of the server, fetched with the
+// server_definitions method. They are cached, and only fetched again when the server
+// reports a new hash.
+func (c *Client) Definitions(ctx context.Context) (*definitions.Definitions, error) {
+	return c.definitions.Get(ctx, c.Requester())
+}
+
+// Codec returns a codec that encodes and decodes with the definitions of the server,
+// see Definitions.
+func (c *Client) Codec(ctx context.Context) (*binarycodec.Codec, error) {
+	return c.definitions.Codec(ctx, c.Requester())
+}
+
+// Capabilities tells whether the server is a Clio or a rippled server and, for Clio, its
+// forwarding and cache state. The server is probed with server_info the first time, after
+// which the client refuses Clio-only requests to rippled servers with an
+// xrpl.ClioOnlyError without sending them. Use xrpl.ProbeCapabilities for a fresh state.
+func (c *Client) Capabilities(ctx context.Context) (*xrpl.Capabilities, error) {
+	return c.capabilities.Get(ctx, c.Requester())
+}
+
+// isAdmin reports whether the server accepts admin methods from the client.
+func (c *Client) isAdmin() bool {
+	if c.cfg.admin != nil {
+		return *c.cfg.admin
+	}
+	return xrpl.IsLocalURL(c.cfg.host)
+}
+
+// autofiller returns the autofill engine configured with the current client settings.
+func (c *Client) autofiller() *autofill.Engine {
+	return autofill.NewEngine(c.Requester(), autofill.Config{
+		NetworkID:   c.NetworkID,
+		MaxFeeXRP:   c.cfg.maxFeeXRP,
+		FeeCushion:  c.cfg.feeCushion,
+		FeeStrategy: c.cfg.feeStrategy,
+		FeeReporter: c.cfg.feeReporter,
+		Sequences:   c.cfg.sequences,
+	})
+}
+
 // SubmitTxBlob sends a pre-signed transaction blob to the server.
 // It decodes the blob to confirm that it contains either a signature
 // or a signing public key, and then submits it using a submission request.
 // The failHard flag determines how strictly errors are handled.
 func (c *Client) SubmitTxBlob(txBlob string, failHard bool) (*requests.SubmitResponse, error) {
-	tx, err := binarycodec.Decode(txBlob)
-	if err != nil {
-		return nil, err
-	}
-
-	_, okTxSig := tx["TxSignature"].(string)
-	_, okPubKey := tx["SigningPubKey"].(string)
-
-	if !okTxSig && !okPubKey {
-		return nil, ErrMissingTxSignatureOrSigningPubKey
-	}
+	return c.SubmitTxBlobContext(context.Background(), txBlob, failHard)
+}
 
-	return c.submitRequest(&requests.SubmitRequest{
-		TxBlob:   txBlob,
-		FailHard: failHard,
-	})
+// SubmitTxBlobContext is like SubmitTxBlob but uses ctx to cancel the request or bound its duration.
+func (c *Client) SubmitTxBlobContext(ctx context.Context, txBlob string, failHard bool) (*requests.SubmitResponse, error) {
+	return c.autofiller().SubmitTxBlob(ctx, txBlob, failHard)
 }
 
 // SubmitTx signs the transaction (if necessary) and submits it to the server
 // via a submission request. It applies the provided submit options to decide whether
 // to autofill missing fields and enforce failHard mode during submission.
-func (c *Client) SubmitTx(tx transaction.FlatTransaction, opts *wstypes.SubmitOptions) (*requests.SubmitResponse, error) {
-	txBlob, err := c.getSignedTx(tx, opts.Autofill, opts.Wallet)
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) SubmitTx(tx transaction.FlatTransaction, opts *xrpl.SubmitOptions) (*requests.SubmitResponse, error) {
+	return c.SubmitTxContext(context.Background(), tx, opts)
+}
 
-	return c.submitRequest(&requests.SubmitRequest{
-		TxBlob:   txBlob,
-		FailHard: opts.FailHard,
-	})
+// SubmitTxContext is like SubmitTx but uses ctx to cancel autofill and submission.
+func (c *Client) SubmitTxContext(ctx context.Context, tx transaction.FlatTransaction, opts *xrpl.SubmitOptions) (*requests.SubmitResponse, error) {
+	return c.autofiller().SubmitTx(ctx, tx, opts)
 }
 
 // SubmitMultisigned sends a multisigned transaction to the server and returns the response.
 // This function is used to send multisigned transactions to the server.
 // It returns the response from the server.
 func (c *Client) SubmitMultisigned(txBlob string, failHard bool) (*requests.SubmitMultisignedResponse, error) {
-	tx, err := binarycodec.Decode(txBlob)
-	if err != nil {
-		return nil, err
-	}
-	signers, okSigners := tx["Signers"].([]interface{})
-
-	if okSigners && len(signers) > 0 {
-		for _, sig := range signers {
-			signer := sig.(map[string]any)
-			signerData := signer["Signer"].(map[string]any)
-			if signerData["SigningPubKey"] == "" && signerData["TxnSignature"] == "" {
-				return nil, errors.New("signer data is empty")
-			}
-		}
-	}
+	return c.SubmitMultisignedContext(context.Background(), txBlob, failHard)
+}
 
-	return c.submitMultisignedRequest(&requests.SubmitMultisignedRequest{
-		Tx:       tx,
-		FailHard: failHard,
-	})
+// SubmitMultisignedContext is like SubmitMultisigned but uses ctx to cancel the request or bound its duration.
+func (c *Client) SubmitMultisignedContext(ctx context.Context, txBlob string, failHard bool) (*requests.SubmitMultisignedResponse, error) {
+	return c.autofiller().SubmitMultisigned(ctx, txBlob, failHard)
 }
 
 // SubmitTxBlobAndWait sends a pre-signed transaction blob to the server,
@@ -385,130 +479,61 @@ func (c *Client) SubmitMultisigned(txBlob string, failHard bool) (*requests.Subm
 // and then waits until the transaction is confirmed in a ledger. It returns
 // the transaction response if the submission is successful.
 func (c *Client) SubmitTxBlobAndWait(txBlob string, failHard bool) (*requests.TxResponse, error) {
+	return c.SubmitTxBlobAndWaitContext(context.Background(), txBlob, failHard)
+}
+
+// SubmitTxBlobAndWaitContext is like SubmitTxBlobAndWait but uses ctx to cancel the
+// submission and the wait for ledger confirmation.
+func (c *Client) SubmitTxBlobAndWaitContext(ctx context.Context, txBlob string, failHard bool) (*requests.TxResponse, error) {
 	tx, err := binarycodec.Decode(txBlob)
 	if err != nil {
 		return nil, err
 	}
 
-	lastLedgerSequence, ok := tx["LastLedgerSequence"].(uint32)
-	if !ok {
-
+	if _, ok := tx["LastLedgerSequence"].(uint32); !ok {
 		return nil, ErrMissingLastLedgerSequenceInTransaction
+	}
 
+	m := submission.NewManager(c.Requester(), submission.Config{
+		PollInterval: c.cfg.retryDelay,
+		MaxChecks:    c.cfg.maxRetries,
+		FailHard:     failHard,
+	})
+	var rec *submission.Record
+	if c.cfg.confirmation == ConfirmByStream {
+		account, _ := tx["Account"].(string)
+		rec, err = c.confirmByStream(ctx, m, txBlob, account)
+	} else {
+		rec, err = m.SubmitAndWait(ctx, txBlob)
 	}
-	txResponse, err := c.SubmitTxBlob(txBlob, failHard)
 	if err != nil {
 		return nil, err
 	}
-
-	if txResponse.EngineResult != "tesSUCCESS" {
-		return nil, &ClientError{ErrorString: "transaction failed to submit with engine result: " + txResponse.EngineResult}
-	}
-
-	txHash, err := hash.SignTxBlob(txBlob)
-	if err != nil {
+	if err := rec.Result.Err(); err != nil {
 		return nil, err
 	}
-
-	return c.waitForTransaction(txHash, lastLedgerSequence)
+	return rec.Result.Tx, nil
 }
 
 // SubmitTxAndWait prepares a transaction by ensuring it is fully signed,
 // submits it to the server, and waits for ledger confirmation.
 // It validates that the transaction's EngineResult is successful before returning
 // the transaction response.
-func (c *Client) SubmitTxAndWait(tx transaction.FlatTransaction, opts *wstypes.SubmitOptions) (*requests.TxResponse, error) {
-	// Get the signed transaction blob.
-	txBlob, err := c.getSignedTx(tx, opts.Autofill, opts.Wallet)
-	if err != nil {
-		return nil, err
-	}
-
-	// Delegate to SubmitTxBlobAndWait to handle submission, engine result check,
-	// ledger sequence validation, and waiting for confirmation.
-	return c.SubmitTxBlobAndWait(txBlob, opts.FailHard)
-}
-
-func (c *Client) waitForTransaction(txHash string, lastLedgerSequence uint32) (*requests.TxResponse, error) {
-	var txResponse *requests.TxResponse
-	i := 0
-
-	for i < c.cfg.maxRetries {
-		// Get the current ledger index
-		currentLedger, err := c.GetLedgerIndex()
-		if err != nil {
-			return nil, err
-		}
-
-		// Check if the transaction has been included in the current ledger
-		if currentLedger.Int() >= int(lastLedgerSequence) {
-			break
-		}
-
-		// Request the transaction from the server
-		res, err := c.Request(&requests.TxRequest{
-			Transaction: txHash,
-		})
-		if err != nil {
-			return nil, err
-		}
-
-		err = res.GetResult(&txResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		// Check if the transaction has been included in the current ledger
-		if txResponse.LedgerIndex.Int() >= int(lastLedgerSequence) {
-			break
-		}
-
-		// Wait for the retry delay before retrying
-		time.Sleep(c.cfg.retryDelay)
-		i++
-	}
-
-	if txResponse == nil {
-		return nil, errors.New("transaction not found")
-	}
-
-	return txResponse, nil
-}
-
-func (c *Client) submitMultisignedRequest(req *requests.SubmitMultisignedRequest) (*requests.SubmitMultisignedResponse, error) {
-	res, err := c.Request(req)
-	if err != nil {
-		return nil, err
-	}
-	var subRes requests.SubmitMultisignedResponse
-	err = res.GetResult(&subRes)
-	if err != nil {
-		return nil, err
-	}
-	return &subRes, nil
+func (c *Client) SubmitTxAndWait(tx transaction.FlatTransaction, opts *xrpl.SubmitOptions) (*requests.TxResponse, error) {
+	return c.SubmitTxAndWaitContext(context.Background(), tx, opts)
 }
 
-func (c *Client) submitRequest(req *requests.SubmitRequest) (*requests.SubmitResponse, error) {
-	res, err := c.Request(req)
-	if err != nil {
-		return nil, err
-	}
-	var subRes requests.SubmitResponse
-	err = res.GetResult(&subRes)
-	if err != nil {
-		return nil, err
-	}
-	return &subRes, nil
+// SubmitTxAndWaitContext is like SubmitTxAndWait but uses ctx to cancel autofill,
+// submission and the wait for ledger confirmation.
+func (c *Client) SubmitTxAndWaitContext(ctx context.Context, tx transaction.FlatTransaction, opts *xrpl.SubmitOptions) (*requests.TxResponse, error) {
+	return c.autofiller().SubmitTxAndWait(ctx, tx, opts, c.SubmitTxBlobAndWaitContext)
 }
 
-func (c *Client) formatRequest(req interfaces.Request, id int, marker any) ([]byte, error) {
+func (c *Client) formatRequest(req interfaces.Request, id int) ([]byte, error) {
 	m := make(map[string]any)
 	m["id"] = id
 	m["command"] = req.Method()
 	m["api_version"] = req.APIVersion()
-	if marker != nil {
-		m["marker"] = marker
-	}
 	dec, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{TagName: "json", Result: &m})
 	err := dec.Decode(req)
 	if err != nil {
@@ -518,638 +543,206 @@ func (c *Client) formatRequest(req interfaces.Request, id int, marker any) ([]by
 	return json.Marshal(m)
 }
 
-// TODO: Implement this when IsValidXAddress is implemented
-func (c *Client) getClassicAccountAndTag(address string) (string, uint32) {
-	return address, 0
-}
-
-func (c *Client) convertTransactionAddressToClassicAddress(tx *transaction.FlatTransaction, fieldName string) {
-	if address, ok := (*tx)[fieldName].(string); ok {
-		classicAddress, _ := c.getClassicAccountAndTag(address)
-		(*tx)[fieldName] = classicAddress
-	}
-}
-
-func (c *Client) validateTransactionAddress(tx *transaction.FlatTransaction, addressField, tagField string) error {
-	classicAddress, tag := c.getClassicAccountAndTag((*tx)[addressField].(string))
-	(*tx)[addressField] = classicAddress
-
-	if tag != uint32(0) {
-		if txTag, ok := (*tx)[tagField].(uint32); ok && txTag != tag {
-			return fmt.Errorf("the %s, if present, must be equal to the tag of the %s", addressField, tagField)
-		}
-		(*tx)[tagField] = tag
-	}
-
-	return nil
-}
-
-// Sets valid addresses for the transaction.
-func (c *Client) setValidTransactionAddresses(tx *transaction.FlatTransaction) error {
-	// Validate if "Account" address is an xAddress
-	if err := c.validateTransactionAddress(tx, "Account", "SourceTag"); err != nil {
-		return err
-	}
-
-	if _, ok := (*tx)["Destination"]; ok {
-		if err := c.validateTransactionAddress(tx, "Destination", "DestinationTag"); err != nil {
-			return err
-		}
-	}
-
-	// DepositPreuaht
-	c.convertTransactionAddressToClassicAddress(tx, "Authorize")
-	c.convertTransactionAddressToClassicAddress(tx, "Unauthorize")
-	// EscrowCancel, EscrowFinish
-	c.convertTransactionAddressToClassicAddress(tx, "Owner")
-	// SetRegularKey
-	c.convertTransactionAddressToClassicAddress(tx, "RegularKey")
-
-	return nil
-}
-
-// Sets the next valid sequence number for a given transaction.
-func (c *Client) setTransactionNextValidSequenceNumber(tx *transaction.FlatTransaction) error {
-	if _, ok := (*tx)["Account"].(string); !ok {
-		return errors.New("missing Account in transaction")
-	}
-	res, err := c.GetAccountInfo(&account.InfoRequest{
-		Account:     types.Address((*tx)["Account"].(string)),
-		LedgerIndex: common.LedgerTitle("current"),
-	})
-
-	if err != nil {
-		return err
-	}
-
-	(*tx)["Sequence"] = uint32(res.AccountData.Sequence)
-	return nil
-}
-
-// Calculates the current transaction fee for the ledger.
-// Note: This is a public API that can be called directly.
-func (c *Client) getFeeXrp(cushion float32) (string, error) {
-	res, err := c.GetServerInfo(&server.InfoRequest{})
-	if err != nil {
-		return "", err
-	}
-
-	if res.Info.ValidatedLedger.BaseFeeXRP == 0 {
-		return "", errors.New("getFeeXrp: could not get BaseFeeXrp from ServerInfo")
-	}
-
-	loadFactor := res.Info.LoadFactor
-	if res.Info.LoadFactor == 0 {
-		loadFactor = 1
-	}
-
-	fee := res.Info.ValidatedLedger.BaseFeeXRP * float32(loadFactor) * cushion
-
-	if fee > c.cfg.maxFeeXRP {
-		fee = c.cfg.maxFeeXRP
-	}
-
-	// Round fee to NUM_DECIMAL_PLACES
-	roundedFee := float32(math.Round(float64(fee)*math.Pow10(int(currency.MaxFractionLength)))) / float32(math.Pow10(int(currency.MaxFractionLength)))
-
-	// Convert the rounded fee back to a string with NUM_DECIMAL_PLACES
-	return fmt.Sprintf("%.*f", currency.MaxFractionLength, roundedFee), nil
-}
-
-// Calculates the fee per transaction type.
-//
-// Enhanced implementation that replicates xrpl.js calculateFeePerTransactionType logic,
-// including special cases for EscrowFinish, AccountDelete, AMMCreate, Batch, and multi-signing.
-func (c *Client) calculateFeePerTransactionType(tx *transaction.FlatTransaction, nSigners uint64) error {
-	// Get base network fee
-	netFeeXRP, err := c.getFeeXrp(c.cfg.feeCushion)
-	if err != nil {
-		return err
-	}
-
-	netFeeDrops, err := currency.XrpToDrops(netFeeXRP)
-	if err != nil {
-		return err
-	}
-
-	// Convert to uint64 for calculations
-	baseFeeUint, err := strconv.ParseUint(netFeeDrops, 10, 64)
-	if err != nil {
-		return err
-	}
-
-	baseFee := baseFeeUint
-
-	// Get transaction type
-	transactionType := ""
-	if txType, ok := (*tx)["TransactionType"]; ok {
-		if str, ok := txType.(string); ok {
-			transactionType = str
-		}
-	}
-
-	// Check if this is a special transaction cost type
-	isSpecialTxCost := transactionType == "AccountDelete" || transactionType == "AMMCreate"
-
-	switch transactionType {
-	case "EscrowFinish":
-		if fulfillment, ok := (*tx)["Fulfillment"]; ok && fulfillment != nil {
-			if fulfillmentStr, ok := fulfillment.(string); ok && fulfillmentStr != "" {
-				fulfillmentBytesSize := (len(fulfillmentStr) + 1) / 2 // Math.ceil(length / 2)
-				if fulfillmentBytesSize < 0 {
-					return fmt.Errorf("invalid fulfillment length")
-				}
-				// BaseFee × (33 + ceil(Fulfillment size in bytes / 16))
-				chunks := (uint64(fulfillmentBytesSize) + 15) / 16 // ceil division
-				baseFee = baseFeeUint * (33 + chunks)
-			}
-		}
-	case "AccountDelete", "AMMCreate":
-		reserveFee, err := c.fetchOwnerReserveFee()
-		if err != nil {
-			return err
-		}
-		baseFee = reserveFee
-	case "Batch":
-		rawTxFees, err := c.calculateBatchFees(tx)
-		if err != nil {
-			return err
-		}
-		baseFee = baseFeeUint*2 + rawTxFees
-	}
-
-	// Multi-signed Transaction: BaseFee × (1 + Number of Signatures Provided)
-	if nSigners > 0 {
-		signersFee := baseFeeUint * nSigners
-		baseFee += signersFee
-	}
-
-	// Apply max fee limit (but not for special transaction cost types)
-	var totalFee uint64
-	if isSpecialTxCost {
-		totalFee = baseFee
-	} else {
-		maxFeeDrops, err := currency.XrpToDrops(fmt.Sprintf("%.6f", c.cfg.maxFeeXRP))
-		if err != nil {
-			return err
-		}
-		maxFeeUint, err := strconv.ParseUint(maxFeeDrops, 10, 64)
-		if err != nil {
-			return err
-		}
-		if baseFee < maxFeeUint {
-			totalFee = baseFee
-		} else {
-			totalFee = maxFeeUint
-		}
-	}
-
-	(*tx)["Fee"] = strconv.FormatUint(totalFee, 10)
-	return nil
-}
-
-// Sets the latest validated ledger sequence for the transaction.
-// Modifies the `LastLedgerSequence` field in the tx.
-func (c *Client) setLastLedgerSequence(tx *transaction.FlatTransaction) error {
-	index, err := c.GetLedgerIndex()
-	if err != nil {
-		return err
-	}
-
-	(*tx)["LastLedgerSequence"] = index.Uint32() + commonconstants.LedgerOffset
-	return err
-}
-
-// Checks for any blockers that prevent the deletion of an account.
-// Returns nil if there are no blockers, otherwise returns an error.
-func (c *Client) checkAccountDeleteBlockers(address types.Address) error {
-	accObjects, err := c.GetAccountObjects(&account.ObjectsRequest{
-		Account:              address,
-		LedgerIndex:          common.LedgerTitle("validated"),
-		DeletionBlockersOnly: true,
-	})
-	if err != nil {
-		return err
-	}
-
-	if len(accObjects.AccountObjects) > 0 {
-		return errors.New("account %s cannot be deleted; there are Escrows, PayChannels, RippleStates, or Checks associated with the account")
-	}
-	return nil
-}
-
-func (c *Client) checkPaymentAmounts(tx *transaction.FlatTransaction) error {
-	if _, ok := (*tx)["DeliverMax"]; ok {
-		if _, ok := (*tx)["Amount"]; !ok {
-			(*tx)["Amount"] = (*tx)["DeliverMax"]
-		} else if (*tx)["Amount"] != (*tx)["DeliverMax"] {
-			return errors.New("payment transaction: Amount and DeliverMax fields must be identical when both are provided")
-		}
-	}
-	return nil
-}
-
-// Sets a transaction's flags to its numeric representation.
-// TODO: Add flag support for AMMDeposit, AMMWithdraw,
-// NFTTOkenCreateOffer, NFTokenMint, OfferCreate, XChainModifyBridge (not supported).
-func (c *Client) setTransactionFlags(tx *transaction.FlatTransaction) error {
-	flags, ok := (*tx)["Flags"].(uint32)
-	if !ok && flags > 0 {
-		(*tx)["Flags"] = int(0)
-		return nil
-	}
-
-	_, ok = (*tx)["TransactionType"].(string)
-	if !ok {
-		return errors.New("transaction type is missing in transaction")
-	}
-
-	return nil
-}
+// awaitResponse waits for the response of the request with the given id on resChan.
+// The request is removed from the in-flight table if it stops waiting early.
+func (c *Client) awaitResponse(ctx context.Context, id int, resChan <-chan *ClientResponse) (*ClientResponse, error) {
+	timeout := time.NewTimer(c.cfg.timeout)
+	defer timeout.Stop()
 
-func (c *Client) awaitResponse(id int) (*ClientResponse, error) {
-	for {
-		select {
-		case res := <-c.requestChan:
-			c.debug(fmt.Sprintf("res ID: %+v, waiting for %+v", res.ID, id))
-			if res.ID == id {
-				return res, nil
-			}
-		case <-time.After(c.cfg.timeout):
-			return nil, ErrRequestTimedOut
+	select {
+	case res, ok := <-resChan:
+		if !ok {
+			return nil, ErrConnectionClosed
 		}
+		return res, nil
+	case <-ctx.Done():
+		c.requests.forget(id)
+		return nil, ctx.Err()
+	case <-timeout.C:
+		c.requests.forget(id)
+		return nil, ErrRequestTimedOut
 	}
 }
 
-func (c *Client) handleMessage(message []byte) {
+// handleMessage routes a message to its request or stream handlers. It returns an error
+// if the connection must be closed.
+func (c *Client) handleMessage(message []byte) error {
 	var stream wstypes.Message
 	c.unmarshalMessage(message, &stream)
-	if stream.IsRequest() {
+	if stream.Type == streamtypes.PathFindStreamType {
+		// Asynchronous path_find updates carry the ID of their create request.
+		return c.handleStream(stream.Type, message)
+	} else if stream.IsRequest() {
 		c.handleRequest(message)
 	} else if stream.IsStream() {
-		c.handleStream(stream.Type, message)
+		return c.handleStream(stream.Type, message)
 	}
+	return nil
 }
 
 func (c *Client) handleRequest(message []byte) {
 	var res ClientResponse
 	c.unmarshalMessage(message, &res)
-	c.requestChan <- &res
+	if res.Error != "" {
+		// The fields of an error, e.g. searched_all, are at the top level of the response.
+		c.unmarshalMessage(message, &res.errorResult)
+	}
+	if !c.requests.resolve(&res) {
+		c.debug("no pending request for response", slog.Int("id", res.ID))
+	}
 }
 
 func (c *Client) unmarshalMessage(message []byte, v any) {
 	if err := json.Unmarshal(message, v); err != nil {
-		if c.errChan == nil {
-			c.errChan = make(chan error)
-		}
-		c.error(err)
+		c.error("failed to decode message", err)
 	}
 }
 
-func (c *Client) handleStream(t streamtypes.Type, message []byte) {
+// handleStream sends a stream message to its handlers. It returns an error if the
+// connection must be closed, see OverflowDisconnect.
+func (c *Client) handleStream(t streamtypes.Type, message []byte) error {
 	switch t {
 	case streamtypes.LedgerStreamType:
 		var ledger streamtypes.LedgerStream
 		c.unmarshalMessage(message, &ledger)
 
 		if c.ledgerClosedChan != nil {
-			c.ledgerClosedChan <- &ledger
+			if err := sendStream(c, t, c.ledgerClosedChan, &ledger, nil); err != nil {
+				return err
+			}
+		}
+		if err := c.fanout.dispatch(c, t, &ledger); err != nil {
+			return err
 		}
 	case streamtypes.TransactionStreamType:
 		var transaction streamtypes.TransactionStream
 		c.unmarshalMessage(message, &transaction)
 		if c.transactionChan != nil {
-			c.transactionChan <- &transaction
+			if err := sendStream(c, t, c.transactionChan, &transaction, nil); err != nil {
+				return err
+			}
+		}
+		// Order book streams send transaction messages too, which the server does not
+		// tell apart from the other transaction messages: they are told by their offers.
+		if c.orderBookChan != nil && c.subscriptions.affectsBooks(&transaction) {
+			var orderBook streamtypes.OrderBookStream
+			c.unmarshalMessage(message, &orderBook)
+			if err := sendStream(c, t, c.orderBookChan, &orderBook, nil); err != nil {
+				return err
+			}
+		}
+		if err := c.fanout.dispatch(c, t, &transaction); err != nil {
+			return err
 		}
 	case streamtypes.ValidationStreamType:
 		var validation streamtypes.ValidationStream
 		c.unmarshalMessage(message, &validation)
 		if c.validationChan != nil {
-			c.validationChan <- &validation
+			if err := sendStream(c, t, c.validationChan, &validation, nil); err != nil {
+				return err
+			}
+		}
+		if err := c.fanout.dispatch(c, t, &validation); err != nil {
+			return err
 		}
 	case streamtypes.PeerStatusStreamType:
 		var peerStatus streamtypes.PeerStatusStream
 		c.unmarshalMessage(message, &peerStatus)
 		if c.peerStatusChan != nil {
-			c.peerStatusChan <- &peerStatus
+			if err := sendStream(c, t, c.peerStatusChan, &peerStatus, nil); err != nil {
+				return err
+			}
+		}
+		if err := c.fanout.dispatch(c, t, &peerStatus); err != nil {
+			return err
 		}
 	case streamtypes.ConsensusStreamType:
 		var consensus streamtypes.ConsensusStream
 		c.unmarshalMessage(message, &consensus)
 		if c.consensusChan != nil {
-			c.consensusChan <- &consensus
-		}
-	default:
-		if c.errChan == nil {
-			c.errChan = make(chan error)
-		}
-		c.error(fmt.Errorf("unknown stream type: %v", t))
-	}
-}
-
-func (c *Client) readMessages() error {
-	for {
-		if !c.IsConnected() {
-			return fmt.Errorf("no connection, stop reading messages")
-		}
-		message, err := c.conn.ReadMessage()
-		if err != nil {
-			return fmt.Errorf("error in read message: %w", err)
-		}
-		// Send the message to a respective channel (ledgerClosedChan, transactionChan, validationChan, peerStatusChan, consensusChan)
-		c.handleMessage(message)
-	}
-}
-
-// getSignedTx ensures the transaction is fully signed and returns the transaction blob.
-// If the transaction is already signed, it encodes and returns it. Otherwise, it autofills (if enabled)
-// and signs the transaction using the provided wallet.
-func (c *Client) getSignedTx(tx transaction.FlatTransaction, autofill bool, wallet *wallet.Wallet) (string, error) {
-	// Check if the transaction is already signed: both fields must be non-empty.
-	sig, sigOk := tx["TxSignature"].(string)
-	pubKey, pubKeyOk := tx["SigningPubKey"].(string)
-	if sigOk && sig != "" && pubKeyOk && pubKey != "" {
-		blob, err := binarycodec.Encode(tx)
-		if err != nil {
-			return "", err
-		}
-		return blob, nil
-	}
-
-	// If not signed, ensure a wallet is provided.
-	if wallet == nil {
-		return "", ErrMissingWallet
-	}
-
-	// Optionally autofill the transaction.
-	if autofill {
-		if err := c.Autofill(&tx); err != nil {
-			return "", err
-		}
-	}
-
-	// Sign the transaction.
-	txBlob, _, err := wallet.Sign(tx)
-	if err != nil {
-		return "", err
-	}
-	return txBlob, nil
-}
-
-// fetchOwnerReserveFee fetches the owner reserve fee from the server state.
-// Replicates the JavaScript fetchOwnerReserveFee function.
-func (c *Client) fetchOwnerReserveFee() (uint64, error) {
-	response, err := c.GetServerState(&server.StateRequest{})
-	if err != nil {
-		return 0, err
-	}
-
-	reserveInc := response.State.ValidatedLedger.ReserveInc
-	if reserveInc == 0 {
-		return 0, errors.New("could not fetch Owner Reserve")
-	}
-
-	return uint64(reserveInc), nil
-}
-
-// calculateBatchFees calculates the total fees for all inner transactions in a Batch.
-// Replicates the JavaScript logic for Batch transaction fee calculation.
-func (c *Client) calculateBatchFees(tx *transaction.FlatTransaction) (uint64, error) {
-	var totalFees uint64
-
-	// Get RawTransactions from the batch transaction
-	rawTransactions, ok := (*tx)["RawTransactions"].([]map[string]any)
-	if !ok {
-		return 0, errors.New("RawTransactions field missing from Batch transaction")
-	}
-
-	// Iterate through each raw transaction
-	for _, rawTx := range rawTransactions {
-		// Extract the actual transaction from the wrapper
-		innerTx, ok := rawTx["RawTransaction"].(map[string]any)
-		if !ok {
-			return 0, errors.New("RawTransaction field missing from wrapper")
-		}
-
-		// Calculate fee for this inner transaction (no multi-signing for inner transactions)
-		innerTxFlat := transaction.FlatTransaction(innerTx)
-		err := c.calculateFeePerTransactionType(&innerTxFlat, 0)
-		if err != nil {
-			return 0, err
-		}
-
-		// Extract the calculated fee
-		feeStr, ok := innerTx["Fee"].(string)
-		if !ok {
-			return 0, errors.New("fee field missing after calculation")
+			if err := sendStream(c, t, c.consensusChan, &consensus, nil); err != nil {
+				return err
+			}
 		}
-
-		innerTx["Fee"] = "0"
-
-		// Convert fee string to uint64 and add to total
-		feeUint, err := strconv.ParseUint(feeStr, 10, 64)
-		if err != nil {
-			return 0, fmt.Errorf("failed to parse fee '%s': %w", feeStr, err)
+		if err := c.fanout.dispatch(c, t, &consensus); err != nil {
+			return err
 		}
-
-		totalFees += feeUint
-	}
-
-	return totalFees, nil
-}
-
-func (c *Client) autofillRawTransactions(tx *transaction.FlatTransaction) error {
-	needsNetworkID, err := c.txNeedsNetworkID()
-	if err != nil {
-		return err
-	}
-
-	rawTxs, ok := (*tx)["RawTransactions"].([]map[string]any)
-	if !ok {
-		return ErrRawTransactionsFieldIsNotAnArray
-	}
-
-	accountSeq := make(map[string]uint32, len(rawTxs))
-
-	for _, rawTx := range rawTxs {
-		innerRawTx, ok := rawTx["RawTransaction"].(map[string]any)
-		if !ok {
-			return ErrRawTransactionFieldIsNotAnObject
+	case streamtypes.BookChangesStreamType:
+		var bookChanges streamtypes.BookChangesStream
+		c.unmarshalMessage(message, &bookChanges)
+		if c.bookChangesChan != nil {
+			if err := sendStream(c, t, c.bookChangesChan, &bookChanges, nil); err != nil {
+				return err
+			}
 		}
-
-		// Validate `Fee` field
-		if innerRawTx["Fee"] == nil {
-			innerRawTx["Fee"] = "0"
-		} else if innerRawTx["Fee"] != "0" {
-			return types.ErrBatchInnerTransactionInvalid
+		if err := c.fanout.dispatch(c, t, &bookChanges); err != nil {
+			return err
 		}
-
-		// Validate `SigningPubKey` field
-		if innerRawTx["SigningPubKey"] == nil {
-			innerRawTx["SigningPubKey"] = ""
-		} else if innerRawTx["SigningPubKey"] != "" {
-			return ErrSigningPubKeyFieldMustBeEmpty
+	case streamtypes.ManifestStreamType:
+		var manifest streamtypes.ManifestStream
+		c.unmarshalMessage(message, &manifest)
+		if c.manifestChan != nil {
+			if err := sendStream(c, t, c.manifestChan, &manifest, nil); err != nil {
+				return err
+			}
 		}
-
-		// Validate `TxnSignature` field
-		if innerRawTx["TxnSignature"] != nil {
-			return ErrTxnSignatureFieldMustBeEmpty
+		if err := c.fanout.dispatch(c, t, &manifest); err != nil {
+			return err
 		}
-		if innerRawTx["Signers"] != nil {
-			return ErrSignersFieldMustBeEmpty
+	case streamtypes.ServerStreamType:
+		var serverStatus streamtypes.ServerStream
+		c.unmarshalMessage(message, &serverStatus)
+		if c.serverStatusChan != nil {
+			if err := sendStream(c, t, c.serverStatusChan, &serverStatus, nil); err != nil {
+				return err
+			}
 		}
-
-		// Validate `NetworkID` field
-		if innerRawTx["NetworkID"] == nil && needsNetworkID {
-			innerRawTx["NetworkID"] = c.NetworkID
+		if err := c.fanout.dispatch(c, t, &serverStatus); err != nil {
+			return err
 		}
-
-		// Validate `Sequence` field
-		if innerRawTx["Sequence"] == nil && innerRawTx["TicketSequence"] == nil {
-
-			acc, ok := innerRawTx["Account"].(string)
-			if !ok {
-				return ErrAccountFieldIsNotAString
-			}
-
-			if accountSeq[acc] != 0 {
-				innerRawTx["Sequence"] = accountSeq[acc]
-				accountSeq[acc]++
-			} else {
-				accountInfo, err := c.GetAccountInfo(&account.InfoRequest{
-					Account: types.Address(acc),
-				})
-				if err != nil {
-					return err
-				}
-				var seq uint32
-				if innerRawTx["Account"] == (*tx)["Account"] {
-					seq = accountInfo.AccountData.Sequence + 1
-				} else {
-					seq = accountInfo.AccountData.Sequence
-				}
-				accountSeq[acc] = seq + 1
-				innerRawTx["Sequence"] = seq
+	case streamtypes.PathFindStreamType:
+		var pathFind streamtypes.PathFindStream
+		c.unmarshalMessage(message, &pathFind)
+		if c.pathFindChan != nil {
+			if err := sendStream(c, t, c.pathFindChan, &pathFind, nil); err != nil {
+				return err
 			}
 		}
+		if s := c.pathFindSession(); s != nil {
+			s.deliver(&pathFind)
+		}
+	default:
+		c.log(slog.LevelWarn, "unknown stream type", slog.String("type", string(t)))
 	}
-
 	return nil
 }
 
-// isNotLaterRippledVersion determines whether the source rippled version is not later than the target rippled version.
-// Example usage: isNotLaterRippledVersion("1.10.0", "1.11.0") returns true.
-//
-//	isNotLaterRippledVersion("1.10.0", "1.10.0-b1") returns false.
-func isNotLaterRippledVersion(source, target string) bool {
-	if source == target {
-		return true
-	}
-
-	sourceDecomp := strings.Split(source, ".")
-	targetDecomp := strings.Split(target, ".")
-
-	if len(sourceDecomp) < 3 || len(targetDecomp) < 3 {
-		return false
-	}
-
-	sourceMajor, err := strconv.Atoi(sourceDecomp[0])
-	if err != nil {
-		return false
-	}
-	sourceMinor, err := strconv.Atoi(sourceDecomp[1])
-	if err != nil {
-		return false
-	}
-	targetMajor, err := strconv.Atoi(targetDecomp[0])
-	if err != nil {
-		return false
-	}
-	targetMinor, err := strconv.Atoi(targetDecomp[1])
-	if err != nil {
-		return false
-	}
-
-	// Compare major version
-	if sourceMajor != targetMajor {
-		return sourceMajor < targetMajor
-	}
-
-	// Compare minor version
-	if sourceMinor != targetMinor {
-		return sourceMinor < targetMinor
-	}
-
-	sourcePatch := strings.Split(sourceDecomp[2], "-")
-	targetPatch := strings.Split(targetDecomp[2], "-")
-
-	sourcePatchVersion, err := strconv.Atoi(sourcePatch[0])
-	if err != nil {
-		return false
-	}
-	targetPatchVersion, err := strconv.Atoi(targetPatch[0])
-	if err != nil {
-		return false
-	}
-
-	// Compare patch version
-	if sourcePatchVersion != targetPatchVersion {
-		return sourcePatchVersion < targetPatchVersion
-	}
-
-	// Compare release version
-	if len(sourcePatch) != len(targetPatch) {
-		return len(sourcePatch) > len(targetPatch)
-	}
-
-	if len(sourcePatch) == 2 {
-		// Compare different release types
-		if !strings.HasPrefix(sourcePatch[1], string(targetPatch[1][0])) {
-			return sourcePatch[1] < targetPatch[1]
+func (c *Client) readMessages() error {
+	for {
+		if !c.IsConnected() {
+			return fmt.Errorf("no connection, stop reading messages")
 		}
-
-		// Compare beta version
-		if strings.HasPrefix(sourcePatch[1], "b") {
-			sourceBeta, err := strconv.Atoi(sourcePatch[1][1:])
-			if err != nil {
-				return false
-			}
-			targetBeta, err := strconv.Atoi(targetPatch[1][1:])
-			if err != nil {
-				return false
-			}
-			return sourceBeta < targetBeta
+		message, err := c.conn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("error in read message: %w", err)
 		}
-
-		// Compare rc version
-		if strings.HasPrefix(sourcePatch[1], "rc") {
-			sourceRC, err := strconv.Atoi(sourcePatch[1][2:])
-			if err != nil {
-				return false
-			}
-			targetRC, err := strconv.Atoi(targetPatch[1][2:])
-			if err != nil {
-				return false
-			}
-			return sourceRC < targetRC
+		// Send the message to a respective channel (ledgerClosedChan, transactionChan, orderBookChan, ...)
+		if err := c.handleMessage(message); err != nil {
+			return err
 		}
 	}
-
-	return false
 }
 
-// txNeedsNetworkID determines if the transaction required a networkID to be valid.
-// Transaction needs networkID if later than restricted ID and build version is >= 1.11.0
-func (c *Client) txNeedsNetworkID() (bool, error) {
-	if c.NetworkID != 0 && c.NetworkID > RestrictedNetworks {
-		res, err := c.GetServerInfo(&server.InfoRequest{})
-		if err != nil {
-			return false, err
-		}
+// sleepContext pauses for d or until ctx is done, whichever happens first.
+// It returns the context error if ctx finished before d elapsed.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
 
-		if res.Info.BuildVersion != "" {
-			return isNotLaterRippledVersion(RequiredNetworkIDVersion, res.Info.BuildVersion), nil
-		}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
 	}
-	return false, nil
 }
@@ -0,0 +1,71 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/clio"
+)
+
+func TestClient_GetNFTInfo(t *testing.T) {
+	cl, cleanup := setupTestClient(t, []map[string]any{
+		{
+			"id": 1,
+			"result": map[string]any{
+				"nft_id":       "00080000B4F4AFC5FBCBD76873F18006173D2193467D3EE70000099B00000000",
+				"ledger_index": 270,
+				"owner":        "rG9gdNygQ6npA9JvDFWBoeXbiUcTYJnEnk",
+				"is_burned":    true,
+				"flags":        8,
+				"issuer":       "rHVokeuSnjPjz718qdb47bGXBBHNMP3KDQ",
+				"nft_taxon":    0,
+				"nft_sequence": 0,
+			},
+		},
+	})
+	defer cleanup()
+
+	res, err := cl.GetNFTInfo(&clio.NFTInfoRequest{NFTokenID: "00080000B4F4AFC5FBCBD76873F18006173D2193467D3EE70000099B00000000"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := &clio.NFTInfoResponse{
+		NFTokenID:   "00080000B4F4AFC5FBCBD76873F18006173D2193467D3EE70000099B00000000",
+		LedgerIndex: 270,
+		Owner:       "rG9gdNygQ6npA9JvDFWBoeXbiUcTYJnEnk",
+		IsBurned:    true,
+		Flags:       8,
+		Issuer:      "rHVokeuSnjPjz718qdb47bGXBBHNMP3KDQ",
+	}
+	if !reflect.DeepEqual(expected, res) {
+		t.Errorf("Expected %+v, but got %+v", expected, res)
+	}
+}
+
+func TestClient_ClioOnlyOnRippled(t *testing.T) {
+	cl, cleanup := setupTestClient(t, []map[string]any{
+		{
+			"id": 1,
+			"result": map[string]any{
+				"info": map[string]any{"build_version": "2.3.0"},
+			},
+		},
+	})
+	defer cleanup()
+
+	caps, err := cl.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if caps.Clio {
+		t.Errorf("Expected a rippled server, but got %+v", caps)
+	}
+
+	_, err = cl.GetNFTsByIssuer(&clio.NFTsByIssuerRequest{Issuer: "rHVokeuSnjPjz718qdb47bGXBBHNMP3KDQ"})
+	if !errors.Is(err, xrpl.ErrClioOnly) {
+		t.Errorf("Expected error %v, but got %v", xrpl.ErrClioOnly, err)
+	}
+}
@@ -1,9 +1,17 @@
 package websocket
 
 import (
+	"log/slog"
+	"maps"
+	"slices"
 	"time"
 
+	"github.com/Peersyst/xrpl-go/xrpl"
+	"github.com/Peersyst/xrpl-go/xrpl/autofill"
 	"github.com/Peersyst/xrpl-go/xrpl/common"
+	streamtypes "github.com/Peersyst/xrpl-go/xrpl/queries/subscription/types"
+	"github.com/Peersyst/xrpl-go/xrpl/ratelimit"
+	"github.com/Peersyst/xrpl-go/xrpl/sequence"
 )
 
 type ClientConfig struct {
@@ -14,12 +22,34 @@ type ClientConfig struct {
 	retryDelay    time.Duration
 	timeout       time.Duration
 
+	// Logging config
+	logger *slog.Logger
+
+	// Middleware config
+	middlewares []xrpl.Middleware
+
+	// Rate limit config
+	rateLimiter *ratelimit.Limiter
+
 	// Fee config
-	feeCushion float32
-	maxFeeXRP  float32
+	feeCushion  float32
+	maxFeeXRP   float32
+	feeStrategy autofill.FeeStrategy
+	feeReporter autofill.FeeReporter
 
 	// Faucet config
 	faucetProvider common.FaucetProvider
+
+	// Admin config, nil if the host decides
+	admin *bool
+
+	// Stream config
+	streamBuffers       map[streamtypes.Type]StreamBuffer
+	defaultStreamBuffer *StreamBuffer
+
+	// Submission config
+	confirmation Confirmation
+	sequences    *sequence.Allocator
 }
 
 func NewClientConfig() *ClientConfig {
@@ -55,6 +85,22 @@ func (wc ClientConfig) WithMaxFeeXRP(maxFeeXrp float32) ClientConfig {
 	return wc
 }
 
+// WithFeeStrategy sets the strategy estimating the network fee of autofilled transactions,
+// e.g. autofill.OpenLedgerFeeStrategy to skip the queue during fee spikes. The fee is
+// still capped by WithMaxFeeXRP.
+// Default: autofill.BaseFeeStrategy with the fee cushion
+func (wc ClientConfig) WithFeeStrategy(s autofill.FeeStrategy) ClientConfig {
+	wc.feeStrategy = s
+	return wc
+}
+
+// WithFeeReporter sets the function called with every fee set on autofilled transactions,
+// along with the reason the fee strategy chose it, e.g. to log it.
+func (wc ClientConfig) WithFeeReporter(r autofill.FeeReporter) ClientConfig {
+	wc.feeReporter = r
+	return wc
+}
+
 // WithFaucetProvider sets the faucet provider of the websocket client.
 // Default: faucet.NewLocalFaucetProvider()
 func (wc ClientConfig) WithFaucetProvider(fp common.FaucetProvider) ClientConfig {
@@ -62,7 +108,9 @@ func (wc ClientConfig) WithFaucetProvider(fp common.FaucetProvider) ClientConfig
 	return wc
 }
 
-// WithMaxRetries sets the maximum number of retries for a transaction.
+// WithMaxRetries sets the number of times SubmitTxBlobAndWait checks a transaction
+// whose LastLedgerSequence has passed, before giving up if the server lacks the
+// ledgers proving it expired.
 // Default: 10
 func (wc ClientConfig) WithMaxRetries(maxRetries int) ClientConfig {
 	wc.maxRetries = maxRetries
@@ -76,7 +124,7 @@ func (wc ClientConfig) WithMaxReconnects(maxReconnects int) ClientConfig {
 	return wc
 }
 
-// WithRetryDelay sets the delay between retries for a transaction.
+// WithRetryDelay sets the delay between checks of a submitted transaction.
 // Default: 1 second
 func (wc ClientConfig) WithRetryDelay(retryDelay time.Duration) ClientConfig {
 	wc.retryDelay = retryDelay
@@ -89,3 +137,76 @@ func (wc ClientConfig) WithTimeout(timeout time.Duration) ClientConfig {
 	wc.timeout = timeout
 	return wc
 }
+
+// WithRateLimiter sets the limiter every request waits for before being sent. The limiter
+// backs off when responses carry load warnings, and can be shared by several clients
+// talking to the same server.
+// Default: nil, no rate limit
+func (wc ClientConfig) WithRateLimiter(l *ratelimit.Limiter) ClientConfig {
+	wc.rateLimiter = l
+	return wc
+}
+
+// WithMiddleware adds middlewares run around every request, in the given order: the
+// first one sees the request first. It can be called several times.
+// Default: no middleware
+func (wc ClientConfig) WithMiddleware(mws ...xrpl.Middleware) ClientConfig {
+	wc.middlewares = slices.Concat(wc.middlewares, mws)
+	return wc
+}
+
+// WithLogger sets the logger of the websocket client. Records carry the host of the
+// client, and the id and method of requests.
+// Default: nil, no logging
+func (wc ClientConfig) WithLogger(logger *slog.Logger) ClientConfig {
+	wc.logger = logger
+	return wc
+}
+
+// WithAdmin sets whether the server accepts admin methods from the client, such as
+// ledger_accept. Admin requests are refused by the client otherwise.
+// Default: true if the host is a local one (localhost, a loopback or unspecified address)
+func (wc ClientConfig) WithAdmin(admin bool) ClientConfig {
+	wc.admin = &admin
+	return wc
+}
+
+// WithStreamBuffer sets the buffer size of the handler of the streams of type t, and the
+// policy applied when it is full. Order book and transaction messages share the
+// streamtypes.TransactionStreamType settings.
+// Default: DefaultStreamBuffer, 10 messages and OverflowBlock
+func (wc ClientConfig) WithStreamBuffer(t streamtypes.Type, size int, policy OverflowPolicy) ClientConfig {
+	wc.streamBuffers = maps.Clone(wc.streamBuffers)
+	if wc.streamBuffers == nil {
+		wc.streamBuffers = make(map[streamtypes.Type]StreamBuffer)
+	}
+	wc.streamBuffers[t] = StreamBuffer{Size: size, Policy: policy}
+	return wc
+}
+
+// WithDefaultStreamBuffer sets the buffer size and overflow policy of the streams without
+// settings of their own, see WithStreamBuffer. The size also applies to the channels of
+// subscription handles.
+// Default: DefaultStreamBuffer, 10 messages and OverflowBlock
+func (wc ClientConfig) WithDefaultStreamBuffer(size int, policy OverflowPolicy) ClientConfig {
+	wc.defaultStreamBuffer = &StreamBuffer{Size: size, Policy: policy}
+	return wc
+}
+
+// WithConfirmation sets how SubmitTxBlobAndWait and SubmitTxAndWait wait for the outcome
+// of a transaction. ConfirmByStream resolves as soon as the validated transaction is
+// streamed, with fewer requests.
+// Default: ConfirmByPolling
+func (wc ClientConfig) WithConfirmation(confirmation Confirmation) ClientConfig {
+	wc.confirmation = confirmation
+	return wc
+}
+
+// WithSequenceAllocator sets the allocator handing out the sequences of autofilled
+// transactions, instead of querying account_info for each of them. Share it with the
+// other clients submitting for the same accounts.
+// Default: nil
+func (wc ClientConfig) WithSequenceAllocator(a *sequence.Allocator) ClientConfig {
+	wc.sequences = a
+	return wc
+}
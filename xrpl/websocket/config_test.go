@@ -4,8 +4,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Peersyst/xrpl-go/xrpl"
 	"github.com/Peersyst/xrpl-go/xrpl/common"
 	"github.com/Peersyst/xrpl-go/xrpl/faucet"
+	streamtypes "github.com/Peersyst/xrpl-go/xrpl/queries/subscription/types"
+	"github.com/Peersyst/xrpl-go/xrpl/ratelimit"
 	"github.com/stretchr/testify/require"
 )
 
@@ -48,3 +51,42 @@ func TestWithTimeout(t *testing.T) {
 	config := NewClientConfig().WithTimeout(10 * time.Second)
 	require.Equal(t, config.timeout, 10*time.Second)
 }
+
+func TestWithRateLimiter(t *testing.T) {
+	require.Nil(t, NewClientConfig().rateLimiter)
+
+	l := ratelimit.NewLimiter(5, 1)
+	config := NewClientConfig().WithRateLimiter(l)
+	require.Same(t, l, config.rateLimiter)
+}
+
+func TestWithMiddleware(t *testing.T) {
+	noop := func(next xrpl.Handler) xrpl.Handler { return next }
+
+	base := NewClientConfig().WithMiddleware(noop)
+	a := base.WithMiddleware(noop)
+	b := base.WithMiddleware(noop, noop)
+
+	require.Len(t, base.middlewares, 1)
+	require.Len(t, a.middlewares, 2)
+	require.Len(t, b.middlewares, 3)
+}
+
+func TestWithStreamBuffer(t *testing.T) {
+	base := NewClientConfig().WithStreamBuffer(streamtypes.LedgerStreamType, 5, OverflowDropOldest)
+	a := base.WithStreamBuffer(streamtypes.TransactionStreamType, 100, OverflowDropNewest)
+
+	require.Equal(t, map[streamtypes.Type]StreamBuffer{
+		streamtypes.LedgerStreamType: {Size: 5, Policy: OverflowDropOldest},
+	}, base.streamBuffers)
+	require.Len(t, a.streamBuffers, 2)
+
+	c := NewClient(a.WithDefaultStreamBuffer(1, OverflowDisconnect))
+	require.Equal(t, StreamBuffer{Size: 5, Policy: OverflowDropOldest}, c.streamBuffer(streamtypes.LedgerStreamType))
+	require.Equal(t, StreamBuffer{Size: 100, Policy: OverflowDropNewest}, c.streamBuffer(streamtypes.TransactionStreamType))
+	require.Equal(t, StreamBuffer{Size: 1, Policy: OverflowDisconnect}, c.streamBuffer(streamtypes.ServerStreamType))
+
+	c = NewClient(*NewClientConfig())
+	require.Equal(t, DefaultStreamBuffer, c.streamBuffer(streamtypes.LedgerStreamType))
+	require.Equal(t, 40, c.streamBuffer(streamtypes.TransactionStreamType).Size)
+}
@@ -0,0 +1,241 @@
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Peersyst/xrpl-go/xrpl/hash"
+	subscribe "github.com/Peersyst/xrpl-go/xrpl/queries/subscription"
+	streamtypes "github.com/Peersyst/xrpl-go/xrpl/queries/subscription/types"
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	"github.com/Peersyst/xrpl-go/xrpl/submission"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+// Confirmation is how SubmitTxBlobAndWait and SubmitTxAndWait wait for the outcome of a
+// transaction.
+type Confirmation int
+
+const (
+	// ConfirmByPolling checks the transaction with server_info and tx requests every
+	// retry delay, see ClientConfig.WithRetryDelay.
+	ConfirmByPolling Confirmation = iota
+	// ConfirmByStream subscribes to the ledger stream and to the account of the
+	// transaction, and resolves as soon as the validated transaction is streamed. The
+	// transaction is only checked with requests when it may have to be resubmitted, once
+	// its LastLedgerSequence has passed, and after a reconnection, as messages sent while
+	// the client was disconnected are lost.
+	ConfirmByStream
+)
+
+func (m Confirmation) String() string {
+	switch m {
+	case ConfirmByPolling:
+		return "polling"
+	case ConfirmByStream:
+		return "stream"
+	}
+	return fmt.Sprintf("Confirmation(%d)", int(m))
+}
+
+// confirmByStream submits txBlob with m, sent by account, and waits for its final outcome
+// with the ledger and account streams, see ConfirmByStream.
+func (c *Client) confirmByStream(ctx context.Context, m *submission.Manager, txBlob string, account string) (*submission.Record, error) {
+	txHash, err := hash.SignTxBlob(txBlob)
+	if err != nil {
+		return nil, err
+	}
+	// Waiting before submitting, so that the transaction can't be validated unseen.
+	w, err := c.confirmations.wait(ctx, c, types.Address(account), txHash)
+	if err != nil {
+		return nil, err
+	}
+	defer c.confirmations.done(context.WithoutCancel(ctx), w)
+
+	rec, err := m.Submit(ctx, txBlob)
+	if err != nil || rec.Result.Final() {
+		return rec, err
+	}
+
+	unproven := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-w.resumed:
+			rec, err = m.Check(ctx, rec.Hash)
+		case tx := <-w.tx:
+			rec, err = m.Observe(ctx, &requests.TxResponse{
+				Hash:        types.Hash256(tx.Hash),
+				LedgerIndex: tx.LedgerIndex,
+				Meta:        tx.Meta,
+				Validated:   tx.Validated,
+				Tx:          tx.Transaction,
+			})
+		case ledgerIndex := <-w.ledgers:
+			if ledgerIndex <= rec.LastLedgerSequence && rec.EngineResult == "tesSUCCESS" {
+				// Applied: the transaction will be streamed once validated.
+				continue
+			}
+			rec, err = m.Check(ctx, rec.Hash)
+			if err == nil && !rec.Result.Final() && rec.ValidatedLedger > rec.LastLedgerSequence {
+				unproven++
+				if c.cfg.maxRetries > 0 && unproven >= c.cfg.maxRetries {
+					return rec, nil
+				}
+			}
+		}
+		if err != nil || rec.Result.Final() {
+			return rec, err
+		}
+	}
+}
+
+// confirmations routes the validated transactions and ledgers streamed to the
+// transactions waited for with ConfirmByStream. The waits for the transactions of an
+// account share one subscription to the ledger stream and to the account, whose
+// transactions are matched by hash as they are received: a busy account never delays or
+// drops the one waited for.
+type confirmations struct {
+	mu       sync.Mutex
+	accounts map[types.Address]*accountConfirmations
+}
+
+func newConfirmations() *confirmations {
+	return &confirmations{accounts: make(map[types.Address]*accountConfirmations)}
+}
+
+// accountConfirmations are the waits for the transactions of an account.
+type accountConfirmations struct {
+	c       *confirmations
+	account types.Address
+	// waiters are the waits, by transaction hash.
+	waiters map[string]*confirmWaiter
+	// ready is closed once the subscription is done, with err if it failed.
+	ready chan struct{}
+	sub   *Subscription
+	err   error
+}
+
+// confirmWaiter receives the messages a wait for a transaction needs. Its channels hold
+// one message: the transaction, the last validated ledger, and whether the subscription
+// was restored after a reconnection.
+type confirmWaiter struct {
+	a       *accountConfirmations
+	hash    string
+	tx      chan *streamtypes.TransactionStream
+	ledgers chan uint32
+	resumed chan struct{}
+}
+
+// wait registers a wait for the transaction of account with txHash, subscribing to the
+// ledger stream and to the account if no other wait for the account did. It must be
+// followed by done.
+func (cs *confirmations) wait(ctx context.Context, c *Client, account types.Address, txHash string) (*confirmWaiter, error) {
+	cs.mu.Lock()
+	a, joined := cs.accounts[account]
+	if !joined {
+		a = &accountConfirmations{
+			c:       cs,
+			account: account,
+			waiters: make(map[string]*confirmWaiter),
+			ready:   make(chan struct{}),
+		}
+		cs.accounts[account] = a
+	}
+	w := &confirmWaiter{
+		a:       a,
+		hash:    strings.ToUpper(txHash),
+		tx:      make(chan *streamtypes.TransactionStream, 1),
+		ledgers: make(chan uint32, 1),
+		resumed: make(chan struct{}, 1),
+	}
+	a.waiters[w.hash] = w
+	cs.mu.Unlock()
+
+	if !joined {
+		a.sub, a.err = c.subscribeHandle(ctx, &subscribe.Request{
+			Streams:  []string{"ledger"},
+			Accounts: []types.Address{account},
+		}, a)
+		if a.err != nil {
+			// The next waits subscribe again.
+			cs.mu.Lock()
+			if cs.accounts[account] == a {
+				delete(cs.accounts, account)
+			}
+			cs.mu.Unlock()
+		}
+		close(a.ready)
+	}
+	select {
+	case <-a.ready:
+	case <-ctx.Done():
+		cs.done(ctx, w)
+		return nil, ctx.Err()
+	}
+	if a.err != nil {
+		cs.done(ctx, w)
+		return nil, a.err
+	}
+	return w, nil
+}
+
+// done ends the wait of w, and unsubscribes from the account once no wait needs it.
+func (cs *confirmations) done(ctx context.Context, w *confirmWaiter) {
+	a := w.a
+	cs.mu.Lock()
+	delete(a.waiters, w.hash)
+	last := len(a.waiters) == 0
+	if last && cs.accounts[a.account] == a {
+		delete(cs.accounts, a.account)
+	}
+	cs.mu.Unlock()
+	if !last {
+		return
+	}
+
+	<-a.ready
+	if a.sub != nil {
+		_ = a.sub.UnsubscribeContext(ctx)
+	}
+}
+
+// message routes a message of the subscription of the account. It is called by the
+// goroutine reading the connection, so it never blocks.
+func (a *accountConfirmations) message(v any) {
+	a.c.mu.Lock()
+	defer a.c.mu.Unlock()
+	switch v := v.(type) {
+	case *streamtypes.TransactionStream:
+		if w, ok := a.waiters[strings.ToUpper(string(v.Hash))]; ok && v.Validated {
+			select {
+			case w.tx <- v:
+			default:
+			}
+		}
+	case *streamtypes.LedgerStream:
+		for _, w := range a.waiters {
+			// Only the last ledger matters.
+			select {
+			case <-w.ledgers:
+			default:
+			}
+			w.ledgers <- uint32(v.LedgerIndex)
+		}
+	}
+}
+
+// resume signals every wait that the subscription was restored after a reconnection.
+func (a *accountConfirmations) resume() {
+	a.c.mu.Lock()
+	defer a.c.mu.Unlock()
+	for _, w := range a.waiters {
+		select {
+		case w.resumed <- struct{}{}:
+		default:
+		}
+	}
+}
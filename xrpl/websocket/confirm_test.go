@@ -0,0 +1,310 @@
+package websocket
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	binarycodec "github.com/Peersyst/xrpl-go/binary-codec"
+	"github.com/Peersyst/xrpl-go/xrpl/hash"
+	streamtypes "github.com/Peersyst/xrpl-go/xrpl/queries/subscription/types"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket/testutil"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// confirmServer answers the requests of a stream confirmation. The transaction is
+// validated in ledger 11, and streamed after its submission if stream is true, or
+// returned by tx requests otherwise. It counts the requests by command.
+func confirmServer(t *testing.T, c *websocket.Conn, txHash string, stream bool, counts map[string]int) {
+	validated := map[string]any{
+		"hash":         txHash,
+		"ledger_index": 11,
+		"validated":    true,
+		"meta":         map[string]any{"TransactionResult": "tesSUCCESS"},
+		"tx_json":      map[string]any{"Account": testAccount1},
+	}
+	for {
+		var req map[string]any
+		if err := c.ReadJSON(&req); err != nil {
+			return
+		}
+		command, _ := req["command"].(string)
+		counts[command]++
+
+		var res map[string]any
+		switch command {
+		case "server_info":
+			res = map[string]any{"id": req["id"], "result": map[string]any{"info": map[string]any{
+				"complete_ledgers": "1-10",
+				"validated_ledger": map[string]any{"seq": 10},
+			}}}
+		case "submit":
+			res = map[string]any{"id": req["id"], "result": map[string]any{"engine_result": "tesSUCCESS"}}
+		case "tx":
+			if stream {
+				res = map[string]any{"id": req["id"], "error": "txnNotFound"}
+			} else {
+				res = map[string]any{"id": req["id"], "result": validated}
+			}
+		default:
+			res = map[string]any{"id": req["id"], "result": map[string]any{}}
+		}
+		if err := c.WriteJSON(res); err != nil {
+			t.Errorf("error writing message: %v", err)
+		}
+
+		if command == "submit" && stream {
+			_ = c.WriteJSON(map[string]any{"type": "ledgerClosed", "ledger_index": 11})
+			tx := map[string]any{"type": "transaction"}
+			for k, v := range validated {
+				tx[k] = v
+			}
+			_ = c.WriteJSON(tx)
+		}
+	}
+}
+
+func testConfirmTxBlob(t *testing.T) (string, string) {
+	t.Helper()
+	blob, err := binarycodec.Encode(map[string]any{
+		"TransactionType":    "AccountSet",
+		"Account":            testAccount1,
+		"Fee":                "12",
+		"Sequence":           uint32(1),
+		"LastLedgerSequence": uint32(20),
+		"SigningPubKey":      "",
+	})
+	require.NoError(t, err)
+	txHash, err := hash.SignTxBlob(blob)
+	require.NoError(t, err)
+	return blob, txHash
+}
+
+func TestClient_SubmitTxBlobAndWait_ConfirmByStream(t *testing.T) {
+	blob, txHash := testConfirmTxBlob(t)
+	counts := make(map[string]int)
+	ws := &testutil.MockWebSocketServer{}
+	s := ws.TestWebSocketServer(func(c *websocket.Conn) {
+		confirmServer(t, c, txHash, true, counts)
+	})
+	defer s.Close()
+
+	url, _ := testutil.ConvertHTTPToWS(s.URL)
+	cl := NewClient(NewClientConfig().WithHost(url).WithConfirmation(ConfirmByStream))
+	require.NoError(t, cl.Connect())
+	defer cl.Disconnect()
+
+	res, err := cl.SubmitTxBlobAndWait(blob, false)
+	require.NoError(t, err)
+	require.True(t, res.Validated)
+	require.EqualValues(t, 11, res.LedgerIndex)
+	require.Equal(t, txHash, string(res.Hash))
+
+	require.NoError(t, cl.Disconnect())
+	// Resolved from the streams, without any tx request.
+	require.Equal(t, 1, counts["subscribe"])
+	require.Equal(t, 1, counts["submit"])
+	require.Equal(t, 0, counts["tx"])
+}
+
+func TestClient_SubmitTxBlobAndWait_ConfirmByStreamAfterReconnection(t *testing.T) {
+	blob, txHash := testConfirmTxBlob(t)
+	var connections atomic.Int32
+	counts := make(map[string]int)
+	ws := &testutil.MockWebSocketServer{}
+	s := ws.TestWebSocketServer(func(c *websocket.Conn) {
+		if connections.Add(1) == 1 {
+			// Submit, then drop the connection before the transaction is streamed.
+			for {
+				var req map[string]any
+				if err := c.ReadJSON(&req); err != nil {
+					return
+				}
+				res := map[string]any{"id": req["id"], "result": map[string]any{}}
+				switch req["command"] {
+				case "server_info":
+					res["result"] = map[string]any{"info": map[string]any{"validated_ledger": map[string]any{"seq": 10}}}
+				case "submit":
+					res["result"] = map[string]any{"engine_result": "tesSUCCESS"}
+				}
+				_ = c.WriteJSON(res)
+				if req["command"] == "submit" {
+					c.Close()
+					return
+				}
+			}
+		}
+		// The transaction is found with a tx request once resubscribed.
+		confirmServer(t, c, txHash, false, counts)
+	})
+	defer s.Close()
+
+	url, _ := testutil.ConvertHTTPToWS(s.URL)
+	cl := NewClient(NewClientConfig().WithHost(url).WithConfirmation(ConfirmByStream))
+	require.NoError(t, cl.Connect())
+	defer cl.Disconnect()
+
+	res, err := cl.SubmitTxBlobAndWait(blob, false)
+	require.NoError(t, err)
+	require.True(t, res.Validated)
+	require.EqualValues(t, 11, res.LedgerIndex)
+
+	require.NoError(t, cl.Disconnect())
+	require.Equal(t, 1, counts["tx"])
+	require.Equal(t, 0, counts["submit"])
+}
+
+func TestClient_SubmitTxBlobAndWait_ConfirmByStreamBusyAccount(t *testing.T) {
+	blob, txHash := testConfirmTxBlob(t)
+	ws := &testutil.MockWebSocketServer{}
+	s := ws.TestWebSocketServer(func(c *websocket.Conn) {
+		for {
+			var req map[string]any
+			if err := c.ReadJSON(&req); err != nil {
+				return
+			}
+			res := map[string]any{"id": req["id"], "result": map[string]any{}}
+			switch req["command"] {
+			case "server_info":
+				// Other transactions of the account fill the buffer of the subscription
+				// before the check is answered.
+				for i := 0; i < 3*DefaultStreamBuffer.Size; i++ {
+					_ = c.WriteJSON(map[string]any{
+						"type":      "transaction",
+						"hash":      fmt.Sprintf("%064X", i),
+						"validated": true,
+						"tx_json":   map[string]any{"Account": testAccount1},
+					})
+				}
+				res["result"] = map[string]any{"info": map[string]any{
+					"complete_ledgers": "1-21",
+					"validated_ledger": map[string]any{"seq": 21},
+				}}
+			case "submit":
+				res["result"] = map[string]any{"engine_result": "tesSUCCESS"}
+			case "tx":
+				res["result"] = map[string]any{
+					"hash":         txHash,
+					"ledger_index": 11,
+					"validated":    true,
+					"meta":         map[string]any{"TransactionResult": "tesSUCCESS"},
+					"tx_json":      map[string]any{"Account": testAccount1},
+				}
+			}
+			_ = c.WriteJSON(res)
+			if req["command"] == "submit" {
+				// The transaction is not streamed, so it is checked once its
+				// LastLedgerSequence has passed.
+				_ = c.WriteJSON(map[string]any{"type": "ledgerClosed", "ledger_index": 21})
+			}
+		}
+	})
+	defer s.Close()
+
+	url, _ := testutil.ConvertHTTPToWS(s.URL)
+	cl := NewClient(NewClientConfig().WithHost(url).WithConfirmation(ConfirmByStream).WithTimeout(5 * time.Second))
+	require.NoError(t, cl.Connect())
+	defer cl.Disconnect()
+
+	res, err := cl.SubmitTxBlobAndWait(blob, false)
+	require.NoError(t, err)
+	require.True(t, res.Validated)
+	require.EqualValues(t, 11, res.LedgerIndex)
+}
+
+func TestClient_SubmitTxBlobAndWait_ConfirmByStreamConcurrent(t *testing.T) {
+	blobs := make([]string, 2)
+	for i := range blobs {
+		blob, err := binarycodec.Encode(map[string]any{
+			"TransactionType":    "AccountSet",
+			"Account":            testAccount1,
+			"Fee":                "12",
+			"Sequence":           uint32(i + 1),
+			"LastLedgerSequence": uint32(20),
+			"SigningPubKey":      "",
+		})
+		require.NoError(t, err)
+		blobs[i] = blob
+	}
+
+	var subscribes atomic.Int32
+	ws := &testutil.MockWebSocketServer{}
+	s := ws.TestWebSocketServer(func(c *websocket.Conn) {
+		var hashes []string
+		for {
+			var req map[string]any
+			if err := c.ReadJSON(&req); err != nil {
+				return
+			}
+			res := map[string]any{"id": req["id"], "result": map[string]any{}}
+			switch req["command"] {
+			case "subscribe":
+				subscribes.Add(1)
+			case "server_info":
+				res["result"] = map[string]any{"info": map[string]any{"validated_ledger": map[string]any{"seq": 10}}}
+			case "submit":
+				txHash, err := hash.SignTxBlob(req["tx_blob"].(string))
+				require.NoError(t, err)
+				hashes = append(hashes, txHash)
+				res["result"] = map[string]any{"engine_result": "tesSUCCESS"}
+			}
+			_ = c.WriteJSON(res)
+			if len(hashes) < len(blobs) || req["command"] != "submit" {
+				continue
+			}
+
+			// Both transactions are streamed once both are waited for, among many other
+			// transactions of the account.
+			for i := 0; i < 3*DefaultStreamBuffer.Size; i++ {
+				_ = c.WriteJSON(map[string]any{
+					"type":      "transaction",
+					"hash":      fmt.Sprintf("%064X", i),
+					"validated": true,
+					"tx_json":   map[string]any{"Account": testAccount1},
+				})
+			}
+			for _, txHash := range hashes {
+				_ = c.WriteJSON(map[string]any{
+					"type":         "transaction",
+					"hash":         txHash,
+					"ledger_index": 11,
+					"validated":    true,
+					"meta":         map[string]any{"TransactionResult": "tesSUCCESS"},
+					"tx_json":      map[string]any{"Account": testAccount1},
+				})
+			}
+		}
+	})
+	defer s.Close()
+
+	url, _ := testutil.ConvertHTTPToWS(s.URL)
+	cl := NewClient(NewClientConfig().WithHost(url).WithConfirmation(ConfirmByStream).WithTimeout(5 * time.Second))
+	require.NoError(t, cl.Connect())
+	defer cl.Disconnect()
+
+	var wg sync.WaitGroup
+	for _, blob := range blobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := cl.SubmitTxBlobAndWait(blob, false)
+			if assert.NoError(t, err) {
+				assert.True(t, res.Validated)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// One subscription for the account, and no message dropped.
+	require.EqualValues(t, 1, subscribes.Load())
+	require.Zero(t, cl.Dropped(streamtypes.TransactionStreamType))
+}
+
+func TestConfirmation_String(t *testing.T) {
+	require.Equal(t, "stream", ConfirmByStream.String())
+	require.Equal(t, "Confirmation(5)", Confirmation(5).String())
+}
@@ -19,6 +19,8 @@ type Connection struct {
 	url  string
 
 	mu sync.Mutex
+	// The underlying connection supports a single concurrent writer.
+	writeMu sync.Mutex
 }
 
 // NewConnection creates a new Connection.
@@ -47,7 +49,7 @@ func (c *Connection) Disconnect() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if !c.IsConnected() {
+	if c.conn == nil {
 		return ErrNotConnected
 	}
 
@@ -60,17 +62,18 @@ func (c *Connection) Disconnect() error {
 
 // IsConnected returns true if the connection is connected.
 func (c *Connection) IsConnected() bool {
-	return c.conn != nil
+	return c.current() != nil
 }
 
 // ReadMessage reads a message from the connection.
 // It returns the message and an error if the message is not read.
 // This method is blocking, it will block until a message is read.
 func (c *Connection) ReadMessage() ([]byte, error) {
-	if !c.IsConnected() {
+	conn := c.current()
+	if conn == nil {
 		return nil, ErrNotConnected
 	}
-	_, message, err := c.conn.ReadMessage()
+	_, message, err := conn.ReadMessage()
 	if err != nil {
 		return nil, err
 	}
@@ -80,8 +83,21 @@ func (c *Connection) ReadMessage() ([]byte, error) {
 // WriteMessage writes a message to the connection.
 // It returns an error if the message is not written.
 func (c *Connection) WriteMessage(message []byte) error {
-	if !c.IsConnected() {
+	conn := c.current()
+	if conn == nil {
 		return ErrNotConnected
 	}
-	return c.conn.WriteMessage(websocket.TextMessage, message)
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	return conn.WriteMessage(websocket.TextMessage, message)
+}
+
+// current returns the underlying websocket connection, or nil if not connected.
+func (c *Connection) current() *websocket.Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.conn
 }
@@ -1,20 +1,30 @@
 package websocket
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/Peersyst/xrpl-go/xrpl/autofill"
+)
 
 // Static errors
 var (
-	ErrMissingTxSignatureOrSigningPubKey      = errors.New("transaction must have a TxSignature or SigningPubKey set")
 	ErrMissingLastLedgerSequenceInTransaction = errors.New("missing LastLedgerSequence in transaction")
-	ErrMissingWallet                          = errors.New("wallet must be provided when submitting an unsigned transaction")
+	ErrUnexpectedResponse                     = errors.New("middleware returned a response that is not a *ClientResponse")
+
+	// Autofill errors, shared with the JSON-RPC client.
+	ErrMissingTxSignatureOrSigningPubKey = autofill.ErrMissingTxSignatureOrSigningPubKey
+	ErrMissingWallet                     = autofill.ErrMissingWallet
+
+	ErrRawTransactionsFieldIsNotAnArray = autofill.ErrRawTransactionsFieldIsNotAnArray
+	ErrRawTransactionFieldIsNotAnObject = autofill.ErrRawTransactionFieldIsNotAnObject
 
-	ErrRawTransactionsFieldIsNotAnArray = errors.New("RawTransactions field is not an array")
-	ErrRawTransactionFieldIsNotAnObject = errors.New("RawTransaction field is not an object")
+	ErrSigningPubKeyFieldMustBeEmpty = autofill.ErrSigningPubKeyFieldMustBeEmpty
+	ErrTxnSignatureFieldMustBeEmpty  = autofill.ErrTxnSignatureFieldMustBeEmpty
+	ErrSignersFieldMustBeEmpty       = autofill.ErrSignersFieldMustBeEmpty
+	ErrAccountFieldIsNotAString      = autofill.ErrAccountFieldIsNotAString
 
-	ErrSigningPubKeyFieldMustBeEmpty = errors.New("SigningPubKey field must be empty")
-	ErrTxnSignatureFieldMustBeEmpty  = errors.New("TxnSignature field must be empty")
-	ErrSignersFieldMustBeEmpty       = errors.New("Signers field must be empty")
-	ErrAccountFieldIsNotAString      = errors.New("Account field is not a string")
+	ErrSignerIsNotAnObject = autofill.ErrSignerIsNotAnObject
+	ErrSignerDataIsEmpty   = autofill.ErrSignerDataIsEmpty
 )
 
 // Dynamic errors
@@ -0,0 +1,156 @@
+package websocket
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// lifecycle holds the connection lifecycle callbacks of a client.
+type lifecycle struct {
+	mu             sync.RWMutex
+	onConnected    func()
+	onDisconnected func(err error)
+	onReconnecting func(attempt int)
+	onResubscribed func()
+
+	// Deprecated handlers, see OnError and OnDebug.
+	onError func(err error)
+	onDebug func(message string)
+}
+
+// OnConnected sets the callback run every time the client connects to the server,
+// including after a reconnection.
+func (c *Client) OnConnected(handler func()) {
+	c.events.mu.Lock()
+	defer c.events.mu.Unlock()
+	c.events.onConnected = handler
+}
+
+// OnDisconnected sets the callback run when the connection is closed. err is the reason
+// the connection was lost, or nil when it was closed with Disconnect. A lost connection
+// is followed by reconnection attempts.
+func (c *Client) OnDisconnected(handler func(err error)) {
+	c.events.mu.Lock()
+	defer c.events.mu.Unlock()
+	c.events.onDisconnected = handler
+}
+
+// OnReconnecting sets the callback run before every reconnection attempt. attempt
+// starts at 1 and is reset once the client is connected again.
+func (c *Client) OnReconnecting(handler func(attempt int)) {
+	c.events.mu.Lock()
+	defer c.events.mu.Unlock()
+	c.events.onReconnecting = handler
+}
+
+// OnResubscribed sets the callback run once the subscriptions of the client are
+// restored after a reconnection.
+func (c *Client) OnResubscribed(handler func()) {
+	c.events.mu.Lock()
+	defer c.events.mu.Unlock()
+	c.events.onResubscribed = handler
+}
+
+// OnError sets the handler of the errors that happen in the background, such as
+// failed reconnection attempts or undecodable messages.
+//
+// Deprecated: set a logger with ClientConfig.WithLogger, and use the lifecycle
+// callbacks (OnConnected, OnDisconnected, OnReconnecting, OnResubscribed) instead.
+func (c *Client) OnError(errHandler func(err error)) {
+	c.events.mu.Lock()
+	defer c.events.mu.Unlock()
+	c.events.onError = errHandler
+}
+
+// OnDebug sets the handler of debug messages.
+//
+// Deprecated: set a logger with ClientConfig.WithLogger instead.
+func (c *Client) OnDebug(debugHandler func(message string)) {
+	c.events.mu.Lock()
+	defer c.events.mu.Unlock()
+	c.events.onDebug = debugHandler
+}
+
+func (c *Client) connected() {
+	c.log(slog.LevelInfo, "connected")
+	c.events.mu.RLock()
+	handler := c.events.onConnected
+	c.events.mu.RUnlock()
+	if handler != nil {
+		handler()
+	}
+}
+
+func (c *Client) disconnected(err error) {
+	if err != nil {
+		c.log(slog.LevelWarn, "connection lost", slog.Any("error", err))
+	} else {
+		c.log(slog.LevelInfo, "disconnected")
+	}
+	c.events.mu.RLock()
+	handler := c.events.onDisconnected
+	c.events.mu.RUnlock()
+	if handler != nil {
+		handler(err)
+	}
+}
+
+func (c *Client) reconnecting(attempt int) {
+	c.log(slog.LevelInfo, "reconnecting", slog.Int("attempt", attempt))
+	c.events.mu.RLock()
+	handler := c.events.onReconnecting
+	c.events.mu.RUnlock()
+	if handler != nil {
+		handler(attempt)
+	}
+}
+
+func (c *Client) resubscribed() {
+	c.log(slog.LevelInfo, "resubscribed")
+	c.events.mu.RLock()
+	handler := c.events.onResubscribed
+	c.events.mu.RUnlock()
+	if handler != nil {
+		handler()
+	}
+}
+
+// error logs err and reports it to the deprecated OnError handler.
+func (c *Client) error(msg string, err error) {
+	c.log(slog.LevelError, msg, slog.Any("error", err))
+	c.events.mu.RLock()
+	handler := c.events.onError
+	c.events.mu.RUnlock()
+	if handler != nil {
+		handler(err)
+	}
+}
+
+// debug logs msg and reports it to the deprecated OnDebug handler.
+func (c *Client) debug(msg string, attrs ...slog.Attr) {
+	c.events.mu.RLock()
+	handler := c.events.onDebug
+	c.events.mu.RUnlock()
+
+	args := make([]any, 0, len(attrs))
+	parts := []string{msg}
+	for _, a := range attrs {
+		args = append(args, a)
+		parts = append(parts, a.String())
+	}
+
+	c.log(slog.LevelDebug, msg, args...)
+	if handler != nil {
+		handler(strings.Join(parts, " "))
+	}
+}
+
+// log writes a record to the configured logger, if any.
+func (c *Client) log(level slog.Level, msg string, args ...any) {
+	if c.logger == nil {
+		return
+	}
+	c.logger.Log(context.Background(), level, msg, args...)
+}
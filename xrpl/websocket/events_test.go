@@ -0,0 +1,151 @@
+package websocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	account "github.com/Peersyst/xrpl-go/xrpl/queries/account"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket/testutil"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_LifecycleEvents(t *testing.T) {
+	var connections atomic.Int32
+	ws := &testutil.MockWebSocketServer{}
+	s := ws.TestWebSocketServer(func(c *websocket.Conn) {
+		if connections.Add(1) == 1 {
+			// Drop the first connection to trigger a reconnection.
+			c.Close()
+			return
+		}
+		for {
+			var req map[string]any
+			if err := c.ReadJSON(&req); err != nil {
+				return
+			}
+			if err := c.WriteJSON(map[string]any{"id": req["id"], "result": map[string]any{}}); err != nil {
+				return
+			}
+		}
+	})
+	defer s.Close()
+
+	url, _ := testutil.ConvertHTTPToWS(s.URL)
+	cl := NewClient(NewClientConfig().WithHost(url))
+
+	events := make(chan string, 10)
+	cl.OnConnected(func() { events <- "connected" })
+	cl.OnDisconnected(func(err error) {
+		if err != nil {
+			events <- "lost"
+		} else {
+			events <- "disconnected"
+		}
+	})
+	cl.OnReconnecting(func(attempt int) { events <- fmt.Sprintf("reconnecting %d", attempt) })
+	cl.OnResubscribed(func() { events <- "resubscribed" })
+
+	require.NoError(t, cl.Connect())
+
+	want := []string{"connected", "lost", "reconnecting 1", "connected", "resubscribed"}
+	for _, w := range want {
+		select {
+		case got := <-events:
+			require.Equal(t, w, got)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %q", w)
+		}
+	}
+
+	require.NoError(t, cl.Disconnect())
+	require.Equal(t, "disconnected", <-events)
+}
+
+func TestClient_Logger(t *testing.T) {
+	ws := &testutil.MockWebSocketServer{}
+	s := ws.TestWebSocketServer(func(c *websocket.Conn) {
+		var req map[string]any
+		if err := c.ReadJSON(&req); err != nil {
+			return
+		}
+		_ = c.WriteJSON(map[string]any{"id": req["id"], "result": map[string]any{}})
+	})
+	defer s.Close()
+
+	var buf lockedBuffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	url, _ := testutil.ConvertHTTPToWS(s.URL)
+	cl := NewClient(NewClientConfig().WithHost(url).WithLogger(logger))
+	require.NoError(t, cl.Connect())
+	defer cl.Disconnect()
+
+	_, err := cl.Request(&account.ChannelsRequest{Account: "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59"})
+	require.NoError(t, err)
+
+	var sending map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		if record["msg"] == "sending request" {
+			sending = record
+		}
+	}
+	require.NotNil(t, sending)
+	require.Equal(t, url, sending["host"])
+	require.Equal(t, "account_channels", sending["method"])
+	require.Equal(t, float64(1), sending["id"])
+}
+
+func TestClient_InvalidMessageDoesNotBlock(t *testing.T) {
+	cl := NewClient(*NewClientConfig())
+
+	done := make(chan struct{})
+	go func() {
+		cl.handleMessage([]byte("not json"))
+		cl.handleMessage([]byte(`{"type": "unknownStream"}`))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("handling an invalid message blocked")
+	}
+}
+
+func TestClient_OnError(t *testing.T) {
+	cl := NewClient(*NewClientConfig())
+
+	var errs []error
+	cl.OnError(func(err error) { errs = append(errs, err) })
+	cl.handleMessage([]byte("not json"))
+
+	require.Len(t, errs, 1)
+}
+
+// lockedBuffer is a bytes.Buffer safe for concurrent use.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
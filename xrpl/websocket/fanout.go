@@ -0,0 +1,580 @@
+package websocket
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	ledger "github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
+	subscribe "github.com/Peersyst/xrpl-go/xrpl/queries/subscription"
+	streamtypes "github.com/Peersyst/xrpl-go/xrpl/queries/subscription/types"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+)
+
+// streamNames maps the type of stream messages to the stream they are sent by.
+// Transaction messages are matched separately, see Subscription.matchesTransaction.
+var streamNames = map[streamtypes.Type]string{
+	streamtypes.LedgerStreamType:      "ledger",
+	streamtypes.ValidationStreamType:  "validations",
+	streamtypes.PeerStatusStreamType:  "peer_status",
+	streamtypes.ConsensusStreamType:   "consensus",
+	streamtypes.BookChangesStreamType: "book_changes",
+	streamtypes.ManifestStreamType:    "manifests",
+	streamtypes.ServerStreamType:      "server",
+}
+
+// Subscription is a subscription of its own to streams, accounts and order books, whose
+// messages are delivered on its Messages channel. Several subscriptions can share the same
+// streams, accounts and books: the client subscribes the server to them once, and only
+// unsubscribes it when no subscription needs them anymore.
+type Subscription struct {
+	client           *Client
+	streams          map[string]bool
+	accounts         map[types.Address]bool
+	accountsProposed map[types.Address]bool
+	books            map[string]streamtypes.OrderBook
+
+	messages chan any
+	// handler, if not nil, is handed the messages instead of the messages channel.
+	handler subscriptionHandler
+	done    chan struct{}
+	once    sync.Once
+	// sendMu orders the deliveries of messages with the closing of the channel.
+	sendMu sync.Mutex
+	closed bool
+}
+
+// subscriptionHandler handles the messages of the internal subscriptions of the client.
+// Its methods are called by the goroutine reading the connection, and must not block.
+type subscriptionHandler interface {
+	message(v any)
+	// resume is called when the subscription is restored after a reconnection.
+	resume()
+}
+
+// SubscribeHandle is like Subscribe, but returns a Subscription delivering the messages of
+// the streams, accounts and books of req on a channel of its own. The server is only sent
+// the streams, accounts and books no other subscription is subscribed to yet, so the
+// snapshot of books is not supported: use the book_offers method instead. While another
+// subscription is still subscribing the server to some of them, it waits for it, and
+// fails with it.
+//
+// Unsubscribe requests sent with Unsubscribe apply to the server, and stop the messages of
+// every subscription too.
+func (c *Client) SubscribeHandle(req *subscribe.Request) (*Subscription, error) {
+	return c.SubscribeHandleContext(context.Background(), req)
+}
+
+// SubscribeHandleContext is like SubscribeHandle but uses ctx to cancel the request or bound its duration.
+func (c *Client) SubscribeHandleContext(ctx context.Context, req *subscribe.Request) (*Subscription, error) {
+	return c.subscribeHandle(ctx, req, nil)
+}
+
+// subscribeHandle is like SubscribeHandleContext, but hands the messages to h instead of
+// the Messages channel if h is not nil. The client uses it for its own subscriptions,
+// which must never block the connection nor drop messages.
+func (c *Client) subscribeHandle(ctx context.Context, req *subscribe.Request, h subscriptionHandler) (*Subscription, error) {
+	s := newSubscription(c, req)
+	if h != nil {
+		s.handler = h
+		s.messages = make(chan any)
+	} else {
+		s.messages = make(chan any, c.defaultStreamBuffer().Size)
+	}
+	acquired, pending, joined := c.fanout.acquire(s)
+	if acquired != nil {
+		_, err := c.RequestContext(ctx, acquired)
+		c.fanout.subscribed(pending, err)
+		if err != nil {
+			s.stop()
+			c.fanout.release(s)
+			return nil, err
+		}
+	}
+	// The server may not be subscribed yet to what other subscriptions are subscribing to.
+	for _, p := range joined {
+		if err := p.wait(ctx); err != nil {
+			_ = s.UnsubscribeContext(context.WithoutCancel(ctx))
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func newSubscription(c *Client, req *subscribe.Request) *Subscription {
+	s := &Subscription{
+		client:           c,
+		streams:          make(map[string]bool),
+		accounts:         make(map[types.Address]bool),
+		accountsProposed: make(map[types.Address]bool),
+		books:            make(map[string]streamtypes.OrderBook),
+		done:             make(chan struct{}),
+	}
+	for _, stream := range req.Streams {
+		s.streams[stream] = true
+	}
+	for _, account := range req.Accounts {
+		s.accounts[account] = true
+	}
+	for _, account := range req.AccountsProposed {
+		s.accountsProposed[account] = true
+	}
+	for _, book := range req.Books {
+		book.Snapshot = false
+		s.books[handleBookKey(book)] = book
+	}
+	return s
+}
+
+// Messages returns the channel of the messages of the subscription, which is closed by
+// Unsubscribe. Its values are the stream types of the messages, such as
+// *streamtypes.LedgerStream or *streamtypes.TransactionStream. Transactions of accounts
+// and order books are delivered as *streamtypes.TransactionStream.
+func (s *Subscription) Messages() <-chan any {
+	return s.messages
+}
+
+// Unsubscribe closes the subscription, and unsubscribes the server from the streams,
+// accounts and books no other subscription needs.
+func (s *Subscription) Unsubscribe() error {
+	return s.UnsubscribeContext(context.Background())
+}
+
+// UnsubscribeContext is like Unsubscribe but uses ctx to cancel the request or bound its duration.
+func (s *Subscription) UnsubscribeContext(ctx context.Context) error {
+	if !s.stop() {
+		return nil
+	}
+
+	c := s.client
+	released := c.fanout.release(s)
+	if released == nil {
+		return nil
+	}
+	// Keep what was subscribed to with Subscribe.
+	released = c.subscriptions.unused(released)
+	if released == nil || !c.IsConnected() {
+		return nil
+	}
+	_, err := c.RequestContext(ctx, released)
+	return err
+}
+
+// stop closes done, which stops a blocked delivery, and reports whether it is the first call.
+func (s *Subscription) stop() bool {
+	stopped := false
+	s.once.Do(func() {
+		close(s.done)
+		stopped = true
+	})
+	return stopped
+}
+
+// deliver sends a message of type t, decoded as v, unless the subscription is closed,
+// with the overflow policy of t. It returns an error if the connection must be closed.
+func (s *Subscription) deliver(c *Client, t streamtypes.Type, v any) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	if s.closed {
+		return nil
+	}
+	if s.handler != nil {
+		s.handler.message(v)
+		return nil
+	}
+	return sendStream(c, t, s.messages, v, s.done)
+}
+
+// matches reports whether a message of type t, decoded as v, is for the subscription.
+func (s *Subscription) matches(t streamtypes.Type, v any) bool {
+	if tx, ok := v.(*streamtypes.TransactionStream); ok {
+		return s.matchesTransaction(tx)
+	}
+	name, ok := streamNames[t]
+	return ok && s.streams[name]
+}
+
+// matchesTransaction reports whether tx is sent by one of the streams, accounts or books
+// of the subscription. Like the server, accounts match the transactions that affect them,
+// and books the transactions that affect their offers.
+func (s *Subscription) matchesTransaction(tx *streamtypes.TransactionStream) bool {
+	if s.streams["transactions_proposed"] || (tx.Validated && s.streams["transactions"]) {
+		return true
+	}
+	if len(s.accounts) > 0 || len(s.accountsProposed) > 0 {
+		for account := range affectedAccounts(tx) {
+			if s.accountsProposed[account] || (tx.Validated && s.accounts[account]) {
+				return true
+			}
+		}
+	}
+	if tx.Validated {
+		for _, book := range s.books {
+			if affectsBook(tx, book) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fanout counts the subscriptions to each stream, account and book, and delivers stream
+// messages to every matching subscription.
+type fanout struct {
+	mu               sync.RWMutex
+	subs             map[*Subscription]struct{}
+	streams          map[string]int
+	accounts         map[types.Address]int
+	accountsProposed map[types.Address]int
+	books            map[string]int
+	// pending holds the subscribe requests in flight, by key of their streams, accounts
+	// and books. A failed request stays until no subscription counts its keys anymore.
+	pending map[string]*pendingSubscribe
+}
+
+// pendingSubscribe is a subscribe request in flight, which the subscriptions to its
+// streams, accounts and books wait for.
+type pendingSubscribe struct {
+	done chan struct{}
+	err  error
+}
+
+// wait returns the error of the request once it is answered, or the error of ctx.
+func (p *pendingSubscribe) wait(ctx context.Context) error {
+	select {
+	case <-p.done:
+		return p.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func newFanout() *fanout {
+	return &fanout{
+		subs:             make(map[*Subscription]struct{}),
+		streams:          make(map[string]int),
+		accounts:         make(map[types.Address]int),
+		accountsProposed: make(map[types.Address]int),
+		books:            make(map[string]int),
+		pending:          make(map[string]*pendingSubscribe),
+	}
+}
+
+// acquire adds s, and returns the request subscribing the server to what no other
+// subscription was subscribed to, or nil if there is none, along with its pending
+// subscribe, to be answered with subscribed. It also returns the pending subscribes of
+// other subscriptions to the rest, which s must wait for.
+func (f *fanout) acquire(s *Subscription) (*subscribe.Request, *pendingSubscribe, []*pendingSubscribe) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subs[s] = struct{}{}
+
+	var keys []string
+	var joined []*pendingSubscribe
+	join := func(key string, first bool) {
+		if first {
+			keys = append(keys, key)
+		} else if p, ok := f.pending[key]; ok && !slices.Contains(joined, p) {
+			joined = append(joined, p)
+		}
+	}
+
+	req := &subscribe.Request{}
+	for stream := range s.streams {
+		f.streams[stream]++
+		first := f.streams[stream] == 1
+		if first {
+			req.Streams = append(req.Streams, stream)
+		}
+		join("streams/"+stream, first)
+	}
+	for account := range s.accounts {
+		f.accounts[account]++
+		first := f.accounts[account] == 1
+		if first {
+			req.Accounts = append(req.Accounts, account)
+		}
+		join("accounts/"+string(account), first)
+	}
+	for account := range s.accountsProposed {
+		f.accountsProposed[account]++
+		first := f.accountsProposed[account] == 1
+		if first {
+			req.AccountsProposed = append(req.AccountsProposed, account)
+		}
+		join("accounts_proposed/"+string(account), first)
+	}
+	for key, book := range s.books {
+		f.books[key]++
+		first := f.books[key] == 1
+		if first {
+			req.Books = append(req.Books, book)
+		}
+		join("books/"+key, first)
+	}
+	if len(keys) == 0 {
+		return nil, nil, joined
+	}
+
+	p := &pendingSubscribe{done: make(chan struct{})}
+	for _, key := range keys {
+		f.pending[key] = p
+	}
+	return req, p, joined
+}
+
+// subscribed answers the pending subscribe p with the error of its request, and wakes up
+// the subscriptions waiting for it. The keys of a failed request stay pending, so that
+// the subscriptions still counting them fail too instead of assuming the server is
+// subscribed to them.
+func (f *fanout) subscribed(p *pendingSubscribe, err error) {
+	f.mu.Lock()
+	if err == nil {
+		for key, q := range f.pending {
+			if q == p {
+				delete(f.pending, key)
+			}
+		}
+	}
+	p.err = err
+	f.mu.Unlock()
+	close(p.done)
+}
+
+// forget drops the key no subscription counts anymore from the pending subscribes, and
+// reports whether the server is subscribed to it, i.e. its subscribe didn't fail.
+func (f *fanout) forget(key string) bool {
+	_, failed := f.pending[key]
+	delete(f.pending, key)
+	return !failed
+}
+
+// release removes s and closes its Messages channel. s must be stopped first. It returns the request unsubscribing
+// the server from what no other subscription is subscribed to, or nil if there is none.
+func (f *fanout) release(s *Subscription) *subscribe.UnsubscribeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.subs[s]; !ok {
+		return nil
+	}
+	delete(f.subs, s)
+	// A blocked delivery returns once done is closed.
+	s.sendMu.Lock()
+	s.closed = true
+	close(s.messages)
+	s.sendMu.Unlock()
+
+	req := &subscribe.UnsubscribeRequest{}
+	for stream := range s.streams {
+		if f.streams[stream]--; f.streams[stream] == 0 {
+			delete(f.streams, stream)
+			if f.forget("streams/" + stream) {
+				req.Streams = append(req.Streams, stream)
+			}
+		}
+	}
+	for account := range s.accounts {
+		if f.accounts[account]--; f.accounts[account] == 0 {
+			delete(f.accounts, account)
+			if f.forget("accounts/" + string(account)) {
+				req.Accounts = append(req.Accounts, account)
+			}
+		}
+	}
+	for account := range s.accountsProposed {
+		if f.accountsProposed[account]--; f.accountsProposed[account] == 0 {
+			delete(f.accountsProposed, account)
+			if f.forget("accounts_proposed/" + string(account)) {
+				req.AccountsProposed = append(req.AccountsProposed, account)
+			}
+		}
+	}
+	for key, book := range s.books {
+		if f.books[key]--; f.books[key] == 0 {
+			delete(f.books, key)
+			if !f.forget("books/" + key) {
+				continue
+			}
+			req.Books = append(req.Books, subscribe.UnsubscribeOrderBook{
+				TakerGets: book.TakerGets,
+				TakerPays: book.TakerPays,
+				Both:      book.Both,
+			})
+		}
+	}
+	if req.Streams == nil && req.Accounts == nil && req.AccountsProposed == nil && req.Books == nil {
+		return nil
+	}
+	return req
+}
+
+// addTo adds the streams, accounts and books of the subscriptions req is missing, to
+// restore them after a reconnection.
+func (f *fanout) addTo(req *subscribe.Request) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for stream := range f.streams {
+		if !slices.Contains(req.Streams, stream) {
+			req.Streams = append(req.Streams, stream)
+		}
+	}
+	for account := range f.accounts {
+		if !slices.Contains(req.Accounts, account) {
+			req.Accounts = append(req.Accounts, account)
+		}
+	}
+	for account := range f.accountsProposed {
+		if !slices.Contains(req.AccountsProposed, account) {
+			req.AccountsProposed = append(req.AccountsProposed, account)
+		}
+	}
+	for s := range f.subs {
+		for key, book := range s.books {
+			if !containsBook(req.Books, key) {
+				req.Books = append(req.Books, book)
+			}
+		}
+	}
+}
+
+// dispatch delivers a message of type t, decoded as v, to every matching subscription,
+// with the overflow policy of t. It returns an error if the connection must be closed.
+func (f *fanout) dispatch(c *Client, t streamtypes.Type, v any) error {
+	// The lock is released before delivering, as a blocked delivery would otherwise
+	// block the subscriptions and unsubscriptions of every other subscription.
+	f.mu.RLock()
+	var subs []*Subscription
+	for s := range f.subs {
+		if s.matches(t, v) {
+			subs = append(subs, s)
+		}
+	}
+	f.mu.RUnlock()
+
+	for _, s := range subs {
+		if err := s.deliver(c, t, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resume signals the internal subscriptions that they were restored after a reconnection.
+// Their messages sent while the client was disconnected are lost.
+func (f *fanout) resume() {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for s := range f.subs {
+		if s.handler != nil {
+			s.handler.resume()
+		}
+	}
+}
+
+// handleBookKey identifies an order book of subscriptions by its currencies and direction.
+func handleBookKey(book streamtypes.OrderBook) string {
+	key := bookKey(book.TakerGets, book.TakerPays)
+	if book.Both {
+		key += ":both"
+	}
+	return key
+}
+
+func containsBook(books []streamtypes.OrderBook, key string) bool {
+	for _, book := range books {
+		if handleBookKey(book) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// affectedAccounts returns the accounts of tx, and the accounts owning or linked by the
+// ledger entries it affects.
+func affectedAccounts(tx *streamtypes.TransactionStream) map[types.Address]bool {
+	accounts := make(map[types.Address]bool)
+	addFields(accounts, tx.Transaction, "Account", "Destination")
+	for _, node := range tx.Meta.AffectedNodes {
+		for _, fields := range nodeFields(node) {
+			addFields(accounts, fields, "Account", "Destination", "Owner", "Issuer")
+			for _, limit := range []string{"HighLimit", "LowLimit"} {
+				if amount, ok := fields[limit].(map[string]any); ok {
+					addFields(accounts, amount, "issuer")
+				}
+			}
+		}
+	}
+	return accounts
+}
+
+func addFields(accounts map[types.Address]bool, fields map[string]any, names ...string) {
+	for _, name := range names {
+		if account, ok := fields[name].(string); ok && account != "" {
+			accounts[types.Address(account)] = true
+		}
+	}
+}
+
+// nodeFields returns the fields of an affected ledger entry, before and after the transaction.
+func nodeFields(node transaction.AffectedNode) []ledger.FlatLedgerObject {
+	switch {
+	case node.CreatedNode != nil:
+		return []ledger.FlatLedgerObject{node.CreatedNode.NewFields}
+	case node.ModifiedNode != nil:
+		return []ledger.FlatLedgerObject{node.ModifiedNode.FinalFields, node.ModifiedNode.PreviousFields}
+	case node.DeletedNode != nil:
+		return []ledger.FlatLedgerObject{node.DeletedNode.FinalFields}
+	}
+	return nil
+}
+
+// affectsBook reports whether tx affects an offer of book, or of its reverse if the book
+// is subscribed to in both directions.
+func affectsBook(tx *streamtypes.TransactionStream, book streamtypes.OrderBook) bool {
+	for _, node := range tx.Meta.AffectedNodes {
+		var entryType ledger.EntryType
+		switch {
+		case node.CreatedNode != nil:
+			entryType = node.CreatedNode.LedgerEntryType
+		case node.ModifiedNode != nil:
+			entryType = node.ModifiedNode.LedgerEntryType
+		case node.DeletedNode != nil:
+			entryType = node.DeletedNode.LedgerEntryType
+		}
+		if entryType != ledger.OfferEntry {
+			continue
+		}
+		for _, fields := range nodeFields(node) {
+			gets, okGets := offerAsset(fields["TakerGets"])
+			pays, okPays := offerAsset(fields["TakerPays"])
+			if !okGets || !okPays {
+				continue
+			}
+			if sameAsset(gets, book.TakerGets) && sameAsset(pays, book.TakerPays) {
+				return true
+			}
+			if book.Both && sameAsset(gets, book.TakerPays) && sameAsset(pays, book.TakerGets) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// offerAsset returns the currency and issuer of an offer amount.
+func offerAsset(amount any) (types.IssuedCurrencyAmount, bool) {
+	switch a := amount.(type) {
+	case string:
+		return types.IssuedCurrencyAmount{Currency: "XRP"}, true
+	case map[string]any:
+		currency, _ := a["currency"].(string)
+		issuer, _ := a["issuer"].(string)
+		return types.IssuedCurrencyAmount{Currency: currency, Issuer: types.Address(issuer)}, true
+	}
+	return types.IssuedCurrencyAmount{}, false
+}
+
+func sameAsset(a, b types.IssuedCurrencyAmount) bool {
+	return a.Currency == b.Currency && a.Issuer == b.Issuer
+}
@@ -0,0 +1,325 @@
+package websocket
+
+import (
+	"testing"
+	"time"
+
+	subscribe "github.com/Peersyst/xrpl-go/xrpl/queries/subscription"
+	streamtypes "github.com/Peersyst/xrpl-go/xrpl/queries/subscription/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/utility"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket/testutil"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAccount1 = "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59"
+	testAccount2 = "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"
+)
+
+func TestClient_SubscribeHandle(t *testing.T) {
+	streams := []map[string]any{
+		{"type": "ledgerClosed", "ledger_index": 7},
+		{"type": "transaction", "validated": true, "tx_json": map[string]any{"Account": testAccount1}},
+		{"type": "transaction", "validated": true, "tx_json": map[string]any{"Account": "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe", "Destination": testAccount2}},
+	}
+
+	requests := make(chan map[string]any, 10)
+	ws := &testutil.MockWebSocketServer{}
+	s := ws.TestWebSocketServer(func(c *websocket.Conn) {
+		for {
+			var req map[string]any
+			if err := c.ReadJSON(&req); err != nil {
+				return
+			}
+			if err := c.WriteJSON(map[string]any{"id": req["id"], "result": map[string]any{}}); err != nil {
+				t.Errorf("error writing message: %v", err)
+			}
+			if req["command"] != "ping" {
+				requests <- req
+				continue
+			}
+			for _, m := range streams {
+				if err := c.WriteJSON(m); err != nil {
+					t.Errorf("error writing message: %v", err)
+				}
+			}
+		}
+	})
+	defer s.Close()
+
+	url, _ := testutil.ConvertHTTPToWS(s.URL)
+	cl := NewClient(NewClientConfig().WithHost(url))
+	require.NoError(t, cl.Connect())
+	defer cl.Disconnect()
+
+	a, err := cl.SubscribeHandle(&subscribe.Request{Streams: []string{"ledger"}, Accounts: []types.Address{testAccount1}})
+	require.NoError(t, err)
+	req := <-requests
+	require.Equal(t, "subscribe", req["command"])
+	require.ElementsMatch(t, []any{"ledger"}, req["streams"])
+	require.ElementsMatch(t, []any{testAccount1}, req["accounts"])
+
+	b, err := cl.SubscribeHandle(&subscribe.Request{Accounts: []types.Address{testAccount1, testAccount2}})
+	require.NoError(t, err)
+	req = <-requests
+	require.Nil(t, req["streams"])
+	require.ElementsMatch(t, []any{testAccount2}, req["accounts"])
+
+	// Only subscriptions to new streams and accounts are sent.
+	c, err := cl.SubscribeHandle(&subscribe.Request{Accounts: []types.Address{testAccount2}})
+	require.NoError(t, err)
+	require.NoError(t, c.Unsubscribe())
+	require.Empty(t, requests)
+
+	_, err = cl.Ping(&utility.PingRequest{})
+	require.NoError(t, err)
+
+	ledger := receive(t, a).(*streamtypes.LedgerStream)
+	require.EqualValues(t, 7, ledger.LedgerIndex)
+	tx := receive(t, a).(*streamtypes.TransactionStream)
+	require.Equal(t, testAccount1, tx.Transaction["Account"])
+
+	tx = receive(t, b).(*streamtypes.TransactionStream)
+	require.Equal(t, testAccount1, tx.Transaction["Account"])
+	tx = receive(t, b).(*streamtypes.TransactionStream)
+	require.Equal(t, testAccount2, tx.Transaction["Destination"])
+
+	require.NoError(t, a.Unsubscribe())
+	req = <-requests
+	require.Equal(t, "unsubscribe", req["command"])
+	require.ElementsMatch(t, []any{"ledger"}, req["streams"])
+	require.Nil(t, req["accounts"])
+	_, ok := <-a.Messages()
+	require.False(t, ok)
+
+	require.NoError(t, b.Unsubscribe())
+	req = <-requests
+	require.Nil(t, req["streams"])
+	require.ElementsMatch(t, []any{testAccount1, testAccount2}, req["accounts"])
+
+	// Unsubscribing twice sends nothing.
+	require.NoError(t, b.Unsubscribe())
+	require.Empty(t, requests)
+}
+
+func TestClient_SubscribeHandleJoinsPendingSubscribe(t *testing.T) {
+	requests := make(chan map[string]any, 10)
+	answer := make(chan map[string]any)
+	ws := &testutil.MockWebSocketServer{}
+	s := ws.TestWebSocketServer(func(c *websocket.Conn) {
+		for {
+			var req map[string]any
+			if err := c.ReadJSON(&req); err != nil {
+				return
+			}
+			requests <- req
+			res := <-answer
+			res["id"] = req["id"]
+			if err := c.WriteJSON(res); err != nil {
+				t.Errorf("error writing message: %v", err)
+			}
+		}
+	})
+	defer s.Close()
+
+	url, _ := testutil.ConvertHTTPToWS(s.URL)
+	cl := NewClient(NewClientConfig().WithHost(url))
+	require.NoError(t, cl.Connect())
+	defer cl.Disconnect()
+
+	subscribed := make(chan error, 2)
+	subscribeLedger := func() {
+		_, err := cl.SubscribeHandle(&subscribe.Request{Streams: []string{"ledger"}})
+		subscribed <- err
+	}
+	go subscribeLedger()
+	require.Equal(t, "subscribe", (<-requests)["command"])
+
+	// The second subscription sends no request, and waits for the first one.
+	go subscribeLedger()
+	require.Eventually(t, func() bool {
+		cl.fanout.mu.RLock()
+		defer cl.fanout.mu.RUnlock()
+		return cl.fanout.streams["ledger"] == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Empty(t, requests)
+
+	// Both fail with the subscribe request, and nothing is left to unsubscribe from.
+	answer <- map[string]any{"error": "noPermission"}
+	require.Error(t, <-subscribed)
+	require.Error(t, <-subscribed)
+	require.Empty(t, requests)
+
+	// The next subscription subscribes the server again.
+	go subscribeLedger()
+	req := <-requests
+	require.Equal(t, "subscribe", req["command"])
+	require.ElementsMatch(t, []any{"ledger"}, req["streams"])
+	answer <- map[string]any{"result": map[string]any{}}
+	require.NoError(t, <-subscribed)
+}
+
+func TestSubscription_matchesTransaction(t *testing.T) {
+	usd := types.IssuedCurrencyAmount{Currency: "USD", Issuer: testAccount2}
+	offer := &streamtypes.TransactionStream{
+		Validated:   true,
+		Transaction: transaction.FlatTransaction{"Account": testAccount1},
+		Meta: transaction.TxObjMeta{AffectedNodes: []transaction.AffectedNode{{
+			CreatedNode: &transaction.CreatedNode{
+				LedgerEntryType: "Offer",
+				NewFields: map[string]any{
+					"TakerGets": "1000000",
+					"TakerPays": map[string]any{"currency": "USD", "issuer": testAccount2, "value": "1"},
+				},
+			},
+		}}},
+	}
+	proposed := &streamtypes.TransactionStream{
+		Transaction: transaction.FlatTransaction{"Account": testAccount1},
+	}
+	trustLine := &streamtypes.TransactionStream{
+		Validated:   true,
+		Transaction: transaction.FlatTransaction{"Account": "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe"},
+		Meta: transaction.TxObjMeta{AffectedNodes: []transaction.AffectedNode{{
+			ModifiedNode: &transaction.ModifiedNode{
+				LedgerEntryType: "RippleState",
+				FinalFields: map[string]any{
+					"HighLimit": map[string]any{"currency": "USD", "issuer": testAccount1, "value": "0"},
+				},
+			},
+		}}},
+	}
+
+	tests := []struct {
+		name     string
+		req      subscribe.Request
+		tx       *streamtypes.TransactionStream
+		expected bool
+	}{
+		{
+			name:     "book",
+			req:      subscribe.Request{Books: []streamtypes.OrderBook{{TakerGets: types.IssuedCurrencyAmount{Currency: "XRP"}, TakerPays: usd}}},
+			tx:       offer,
+			expected: true,
+		},
+		{
+			name:     "reversed book",
+			req:      subscribe.Request{Books: []streamtypes.OrderBook{{TakerGets: usd, TakerPays: types.IssuedCurrencyAmount{Currency: "XRP"}}}},
+			tx:       offer,
+			expected: false,
+		},
+		{
+			name:     "both books",
+			req:      subscribe.Request{Books: []streamtypes.OrderBook{{TakerGets: usd, TakerPays: types.IssuedCurrencyAmount{Currency: "XRP"}, Both: true}}},
+			tx:       offer,
+			expected: true,
+		},
+		{
+			name:     "proposed transaction of an account",
+			req:      subscribe.Request{Accounts: []types.Address{testAccount1}},
+			tx:       proposed,
+			expected: false,
+		},
+		{
+			name:     "proposed transaction of a proposed account",
+			req:      subscribe.Request{AccountsProposed: []types.Address{testAccount1}},
+			tx:       proposed,
+			expected: true,
+		},
+		{
+			name:     "trust line issuer",
+			req:      subscribe.Request{Accounts: []types.Address{testAccount1}},
+			tx:       trustLine,
+			expected: true,
+		},
+		{
+			name:     "transactions stream",
+			req:      subscribe.Request{Streams: []string{"transactions"}},
+			tx:       proposed,
+			expected: false,
+		},
+		{
+			name:     "transactions_proposed stream",
+			req:      subscribe.Request{Streams: []string{"transactions_proposed"}},
+			tx:       proposed,
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSubscription(nil, &tt.req)
+			require.Equal(t, tt.expected, s.matches(streamtypes.TransactionStreamType, tt.tx))
+		})
+	}
+}
+
+func TestFanout_addTo(t *testing.T) {
+	book := streamtypes.OrderBook{
+		TakerGets: types.IssuedCurrencyAmount{Currency: "XRP"},
+		TakerPays: types.IssuedCurrencyAmount{Currency: "USD", Issuer: testAccount2},
+	}
+	f := newFanout()
+	f.acquire(newSubscription(nil, &subscribe.Request{Streams: []string{"ledger"}, Books: []streamtypes.OrderBook{book}}))
+	f.acquire(newSubscription(nil, &subscribe.Request{Streams: []string{"ledger"}, Accounts: []types.Address{testAccount1}}))
+
+	// Restored along with the subscriptions made with Subscribe.
+	req := &subscribe.Request{Streams: []string{"ledger", "server"}}
+	f.addTo(req)
+	require.Equal(t, &subscribe.Request{
+		Streams:  []string{"ledger", "server"},
+		Accounts: []types.Address{testAccount1},
+		Books:    []streamtypes.OrderBook{book},
+	}, req)
+}
+
+func TestFanout_dispatchDoesNotBlockOtherSubscriptions(t *testing.T) {
+	c := NewClient(NewClientConfig().WithHost("ws://localhost"))
+	f := newFanout()
+	full := newSubscription(c, &subscribe.Request{Streams: []string{"ledger"}})
+	full.messages = make(chan any)
+	f.acquire(full)
+
+	dispatched := make(chan error)
+	go func() {
+		dispatched <- f.dispatch(c, streamtypes.LedgerStreamType, &streamtypes.LedgerStream{})
+	}()
+
+	// The delivery blocks until full is read from, other subscriptions still come and go.
+	other := newSubscription(c, &subscribe.Request{Streams: []string{"ledger"}})
+	other.messages = make(chan any, 1)
+	acquired := make(chan struct{})
+	go func() {
+		f.acquire(other)
+		other.stop()
+		f.release(other)
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscribing blocked behind a full subscription")
+	}
+
+	// Unsubscribing stops the blocked delivery.
+	full.stop()
+	f.release(full)
+	require.NoError(t, <-dispatched)
+	_, ok := <-full.messages
+	require.False(t, ok)
+}
+
+// receive returns the next message of s.
+func receive(t *testing.T, s *Subscription) any {
+	t.Helper()
+	select {
+	case m := <-s.Messages():
+		return m
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a message")
+		return nil
+	}
+}
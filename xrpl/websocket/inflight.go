@@ -0,0 +1,70 @@
+package websocket
+
+import "sync"
+
+// inflight is the table of requests waiting for a response, keyed by request ID.
+// Every request gets its own buffered channel, so concurrent callers sharing one
+// connection never consume each other's responses.
+// All methods are safe for concurrent use.
+type inflight struct {
+	mu      sync.Mutex
+	pending map[int]chan *ClientResponse
+}
+
+func newInflight() *inflight {
+	return &inflight{pending: make(map[int]chan *ClientResponse)}
+}
+
+// register adds a pending request with the given ID and returns the channel its response
+// is delivered on. The channel is closed without a value if the connection is lost first.
+func (f *inflight) register(id int) <-chan *ClientResponse {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ch := make(chan *ClientResponse, 1)
+	f.pending[id] = ch
+	return ch
+}
+
+// forget removes a pending request that is no longer waiting for its response.
+func (f *inflight) forget(id int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.pending, id)
+}
+
+// resolve delivers res to the request waiting for it.
+// It reports whether a pending request with the response ID was found. Requests register
+// before they are written, so a response without one belongs to a request that gave up.
+func (f *inflight) resolve(res *ClientResponse) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ch, ok := f.pending[res.ID]
+	if !ok {
+		return false
+	}
+	delete(f.pending, res.ID)
+	ch <- res
+	return true
+}
+
+// failAll releases every pending request by closing its channel.
+func (f *inflight) failAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for id, ch := range f.pending {
+		close(ch)
+		delete(f.pending, id)
+	}
+}
+
+// len returns the number of requests waiting for a response.
+func (f *inflight) len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.pending)
+}
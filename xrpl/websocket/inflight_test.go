@@ -0,0 +1,46 @@
+package websocket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInflight_Resolve(t *testing.T) {
+	f := newInflight()
+
+	ch1 := f.register(1)
+	ch2 := f.register(2)
+	require.Equal(t, 2, f.len())
+
+	require.True(t, f.resolve(&ClientResponse{ID: 2}))
+	require.True(t, f.resolve(&ClientResponse{ID: 1}))
+
+	require.Equal(t, 1, (<-ch1).ID)
+	require.Equal(t, 2, (<-ch2).ID)
+	require.Equal(t, 0, f.len())
+}
+
+func TestInflight_Forget(t *testing.T) {
+	f := newInflight()
+
+	f.register(1)
+	f.forget(1)
+
+	require.Equal(t, 0, f.len())
+	require.False(t, f.resolve(&ClientResponse{ID: 1}))
+}
+
+func TestInflight_FailAll(t *testing.T) {
+	f := newInflight()
+
+	ch1 := f.register(1)
+	ch2 := f.register(2)
+	f.failAll()
+
+	_, ok := <-ch1
+	require.False(t, ok)
+	_, ok = <-ch2
+	require.False(t, ok)
+	require.Equal(t, 0, f.len())
+}
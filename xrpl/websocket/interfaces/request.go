@@ -1,7 +1,6 @@
 package interfaces
 
-type Request interface {
-	Method() string
-	Validate() error
-	APIVersion() int
-}
+import "github.com/Peersyst/xrpl-go/xrpl"
+
+// Request is an alias of xrpl.Request, the contract every websocket request fulfils.
+type Request = xrpl.Request
@@ -0,0 +1,217 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/path"
+	streamtypes "github.com/Peersyst/xrpl-go/xrpl/queries/subscription/types"
+)
+
+var (
+	ErrPathFindSessionClosed = errors.New("path_find session closed")
+)
+
+// PathFindSession is a path_find create request kept open on the client's connection.
+// The server sends updated payment paths to it every time the ledger changes, which the
+// session delivers on its Updates channel.
+//
+// Servers keep a single path_find request per connection: a client has at most one open
+// session, and opening another one closes the previous one. After a reconnection, the
+// session is restored by sending its create request again.
+type PathFindSession struct {
+	client  *Client
+	updates chan *streamtypes.PathFindStream
+
+	mu  sync.Mutex
+	req path.FindCreateRequest
+	// Updates of create requests with a lower ID belong to a previous request.
+	minID int
+	// The ID of the last update delivered.
+	lastID int
+	closed bool
+}
+
+// PathFind sends a path_find create request and returns the session delivering its
+// updates. The reply to the request is the first update of the session.
+func (c *Client) PathFind(req *path.FindCreateRequest) (*PathFindSession, error) {
+	return c.PathFindContext(context.Background(), req)
+}
+
+// PathFindContext is like PathFind but uses ctx to cancel the create request or bound its duration.
+func (c *Client) PathFindContext(ctx context.Context, req *path.FindCreateRequest) (*PathFindSession, error) {
+	s := &PathFindSession{
+		client:  c,
+		updates: make(chan *streamtypes.PathFindStream, 1),
+	}
+
+	c.mu.Lock()
+	previous := c.pathFind
+	c.pathFind = s
+	c.mu.Unlock()
+	if previous != nil {
+		previous.closeUpdates()
+	}
+
+	if err := s.create(ctx, req); err != nil {
+		s.detach()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Updates returns the channel of the updates of the session, which is closed with the
+// session. Only the latest update is kept: an update the receiver did not read in time
+// is replaced by the next one.
+//
+// An update with FullReply set to false is the result of an incomplete search, which the
+// server follows with better paths once the search completes.
+func (s *PathFindSession) Updates() <-chan *streamtypes.PathFindStream {
+	return s.updates
+}
+
+// Modify replaces the path_find request of the session, by sending a create request
+// again. The updates of the previous request are no longer delivered.
+func (s *PathFindSession) Modify(req *path.FindCreateRequest) error {
+	return s.ModifyContext(context.Background(), req)
+}
+
+// ModifyContext is like Modify but uses ctx to cancel the create request or bound its duration.
+func (s *PathFindSession) ModifyContext(ctx context.Context, req *path.FindCreateRequest) error {
+	s.mu.Lock()
+	closed := s.closed
+	s.mu.Unlock()
+	if closed {
+		return ErrPathFindSessionClosed
+	}
+	return s.create(ctx, req)
+}
+
+// Close sends a path_find close request and closes the Updates channel. The session is
+// closed even if the request fails.
+func (s *PathFindSession) Close() error {
+	return s.CloseContext(context.Background())
+}
+
+// CloseContext is like Close but uses ctx to cancel the close request or bound its duration.
+func (s *PathFindSession) CloseContext(ctx context.Context) error {
+	if !s.detach() {
+		return nil
+	}
+	_, err := s.client.RequestContext(ctx, &path.FindCloseRequest{Subcommand: path.Close})
+	return err
+}
+
+// create sends req as a path_find create request, and delivers its reply as an update.
+func (s *PathFindSession) create(ctx context.Context, req *path.FindCreateRequest) error {
+	r := *req
+	r.Subcommand = path.Create
+
+	// IDs only grow, so the create request gets at least this ID.
+	s.mu.Lock()
+	s.req = r
+	s.minID = int(s.client.idCounter.Load()) + 1
+	s.mu.Unlock()
+
+	res, err := s.client.RequestContext(ctx, &r)
+	if err != nil {
+		return err
+	}
+	var fr path.FindResponse
+	if err := res.GetResult(&fr); err != nil {
+		return err
+	}
+
+	s.deliverReply(&streamtypes.PathFindStream{
+		Type:               streamtypes.PathFindStreamType,
+		ID:                 res.ID,
+		SourceAccount:      fr.SourceAccount,
+		DestinationAccount: fr.DestinationAccount,
+		DestinationAmount:  fr.DestinationAmount,
+		FullReply:          fr.FullReply,
+		Alternatives:       fr.Alternatives,
+	})
+	return nil
+}
+
+// resume sends the create request of the session again, on a new connection.
+func (s *PathFindSession) resume(ctx context.Context) error {
+	s.mu.Lock()
+	req := s.req
+	closed := s.closed
+	s.mu.Unlock()
+	if closed {
+		return nil
+	}
+	return s.create(ctx, &req)
+}
+
+// deliver sends an asynchronous update to the Updates channel.
+func (s *PathFindSession) deliver(update *streamtypes.PathFindStream) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || update.ID < s.minID {
+		return
+	}
+	s.send(update)
+}
+
+// deliverReply sends the reply of a create request to the Updates channel, unless an
+// update of the request, which is read after the reply, was delivered first.
+func (s *PathFindSession) deliverReply(reply *streamtypes.PathFindStream) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || reply.ID < s.minID || reply.ID <= s.lastID {
+		return
+	}
+	s.send(reply)
+}
+
+// send sends update to the Updates channel, replacing the update not read yet.
+// s.mu must be held.
+func (s *PathFindSession) send(update *streamtypes.PathFindStream) {
+	s.lastID = update.ID
+	for {
+		select {
+		case s.updates <- update:
+			return
+		default:
+		}
+		select {
+		case <-s.updates:
+		default:
+		}
+	}
+}
+
+// detach removes the session from its client and closes it. It returns false if the
+// session was already closed.
+func (s *PathFindSession) detach() bool {
+	c := s.client
+	c.mu.Lock()
+	if c.pathFind == s {
+		c.pathFind = nil
+	}
+	c.mu.Unlock()
+	return s.closeUpdates()
+}
+
+// closeUpdates closes the Updates channel. It returns false if it was already closed.
+func (s *PathFindSession) closeUpdates() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.closed = true
+	close(s.updates)
+	return true
+}
+
+// pathFindSession returns the open path_find session of the client, if any.
+func (c *Client) pathFindSession() *PathFindSession {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pathFind
+}
@@ -0,0 +1,162 @@
+package websocket
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Peersyst/xrpl-go/xrpl/queries/path"
+	streamtypes "github.com/Peersyst/xrpl-go/xrpl/queries/subscription/types"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
+	"github.com/Peersyst/xrpl-go/xrpl/websocket/testutil"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// pathFindServer answers path_find create requests with a partial reply, followed by
+// a full update of the request and an update of a previous request. The source amount
+// of the alternatives is the destination amount of the request.
+func pathFindServer(t *testing.T, c *websocket.Conn, closed chan<- struct{}) {
+	for {
+		var req map[string]any
+		if err := c.ReadJSON(&req); err != nil {
+			return
+		}
+		if req["command"] != "path_find" {
+			_ = c.WriteJSON(map[string]any{"id": req["id"], "result": map[string]any{}})
+			continue
+		}
+		id := int(req["id"].(float64))
+		switch req["subcommand"] {
+		case "create":
+			alternatives := []any{map[string]any{"paths_computed": []any{}, "source_amount": req["destination_amount"]}}
+			msgs := []map[string]any{
+				{"id": id, "result": map[string]any{"alternatives": []any{}, "full_reply": false}},
+				{"type": "path_find", "id": id, "full_reply": true, "alternatives": alternatives},
+				{"type": "path_find", "id": id - 1, "full_reply": true, "alternatives": []any{}},
+			}
+			for _, m := range msgs {
+				if err := c.WriteJSON(m); err != nil {
+					t.Errorf("error writing message: %v", err)
+				}
+			}
+		case "close":
+			_ = c.WriteJSON(map[string]any{"id": id, "result": map[string]any{"closed": true}})
+			closed <- struct{}{}
+		}
+	}
+}
+
+// awaitFullReply returns the first full update of the session.
+func awaitFullReply(t *testing.T, s *PathFindSession) *streamtypes.PathFindStream {
+	t.Helper()
+	for {
+		select {
+		case update, ok := <-s.Updates():
+			require.True(t, ok, "updates channel closed")
+			if update.FullReply {
+				return update
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a full reply")
+		}
+	}
+}
+
+func TestClient_PathFind(t *testing.T) {
+	closed := make(chan struct{}, 1)
+	ws := &testutil.MockWebSocketServer{}
+	s := ws.TestWebSocketServer(func(c *websocket.Conn) {
+		pathFindServer(t, c, closed)
+	})
+	defer s.Close()
+
+	url, _ := testutil.ConvertHTTPToWS(s.URL)
+	cl := NewClient(NewClientConfig().WithHost(url))
+	require.NoError(t, cl.Connect())
+	defer cl.Disconnect()
+
+	req := &path.FindCreateRequest{
+		SourceAccount:      "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59",
+		DestinationAccount: "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+		DestinationAmount:  types.XRPCurrencyAmount(100),
+	}
+	session, err := cl.PathFind(req)
+	require.NoError(t, err)
+
+	update := awaitFullReply(t, session)
+	require.Equal(t, 1, update.ID)
+	require.Equal(t, "100", update.Alternatives[0].SourceAmount)
+
+	req.DestinationAmount = types.XRPCurrencyAmount(200)
+	require.NoError(t, session.Modify(req))
+
+	update = awaitFullReply(t, session)
+	require.Equal(t, 2, update.ID)
+	require.Equal(t, "200", update.Alternatives[0].SourceAmount)
+
+	require.NoError(t, session.Close())
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the close request")
+	}
+	_, ok := <-session.Updates()
+	require.False(t, ok)
+	require.ErrorIs(t, session.Modify(req), ErrPathFindSessionClosed)
+}
+
+func TestClient_PathFindReplacesSession(t *testing.T) {
+	ws := &testutil.MockWebSocketServer{}
+	s := ws.TestWebSocketServer(func(c *websocket.Conn) {
+		pathFindServer(t, c, nil)
+	})
+	defer s.Close()
+
+	url, _ := testutil.ConvertHTTPToWS(s.URL)
+	cl := NewClient(NewClientConfig().WithHost(url))
+	require.NoError(t, cl.Connect())
+	defer cl.Disconnect()
+
+	req := &path.FindCreateRequest{DestinationAmount: types.XRPCurrencyAmount(100)}
+	first, err := cl.PathFind(req)
+	require.NoError(t, err)
+	second, err := cl.PathFind(req)
+	require.NoError(t, err)
+
+	for range first.Updates() {
+	}
+	require.Equal(t, 2, awaitFullReply(t, second).ID)
+}
+
+func TestClient_PathFindResumesAfterReconnection(t *testing.T) {
+	var connections atomic.Int32
+	ws := &testutil.MockWebSocketServer{}
+	s := ws.TestWebSocketServer(func(c *websocket.Conn) {
+		if connections.Add(1) == 1 {
+			// Answer the first create request, then drop the connection.
+			var req map[string]any
+			if err := c.ReadJSON(&req); err != nil {
+				return
+			}
+			_ = c.WriteJSON(map[string]any{"id": req["id"], "result": map[string]any{"alternatives": []any{}, "full_reply": false}})
+			c.Close()
+			return
+		}
+		pathFindServer(t, c, nil)
+	})
+	defer s.Close()
+
+	url, _ := testutil.ConvertHTTPToWS(s.URL)
+	cl := NewClient(NewClientConfig().WithHost(url))
+	require.NoError(t, cl.Connect())
+	defer cl.Disconnect()
+
+	session, err := cl.PathFind(&path.FindCreateRequest{DestinationAmount: types.XRPCurrencyAmount(100)})
+	require.NoError(t, err)
+
+	// The create request is sent again after the subscribe request of the reconnection.
+	update := awaitFullReply(t, session)
+	require.Equal(t, 3, update.ID)
+	require.Equal(t, "100", update.Alternatives[0].SourceAmount)
+}
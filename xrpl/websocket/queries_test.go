@@ -10,6 +10,8 @@ import (
 	"github.com/Peersyst/xrpl-go/xrpl/ledger-entry-types"
 	"github.com/Peersyst/xrpl-go/xrpl/queries/account"
 	accounttypes "github.com/Peersyst/xrpl-go/xrpl/queries/account/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/amm"
+	ammtypes "github.com/Peersyst/xrpl-go/xrpl/queries/amm/types"
 	"github.com/Peersyst/xrpl-go/xrpl/queries/channel"
 	"github.com/Peersyst/xrpl-go/xrpl/queries/common"
 	ledgerqueries "github.com/Peersyst/xrpl-go/xrpl/queries/ledger"
@@ -1317,6 +1319,45 @@ func TestClient_GetCurrentLedger(t *testing.T) {
 	}
 }
 
+func TestClient_GetAMMInfo(t *testing.T) {
+	cl, cleanup := setupTestClient(t, []map[string]any{
+		{
+			"id": 1,
+			"result": map[string]any{
+				"amm": map[string]any{
+					"account":     "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM",
+					"amount":      "296890496",
+					"amount2":     map[string]any{"currency": "TST", "issuer": "rP9jPyP5kyvFRb6ZiRghAGw5u8SGAmU4bd", "value": "25.81656470648473"},
+					"lp_token":    map[string]any{"currency": "039C99CD9AB0B70B32ECDA51EAAE471625608EA2", "issuer": "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM", "value": "87533.41976112682"},
+					"trading_fee": 600,
+				},
+				"ledger_current_index": 316745,
+				"validated":            false,
+			},
+		},
+	})
+	defer cleanup()
+
+	res, err := cl.GetAMMInfo(&amm.InfoRequest{AMMAccount: "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := ammtypes.AMM{
+		Account:    "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM",
+		Amount:     types.XRPCurrencyAmount(296890496),
+		Amount2:    types.IssuedCurrencyAmount{Currency: "TST", Issuer: "rP9jPyP5kyvFRb6ZiRghAGw5u8SGAmU4bd", Value: "25.81656470648473"},
+		LPToken:    types.IssuedCurrencyAmount{Currency: "039C99CD9AB0B70B32ECDA51EAAE471625608EA2", Issuer: "rp9E3FN3gNmvePGhYnf414T2TkUuoxu8vM", Value: "87533.41976112682"},
+		TradingFee: 600,
+	}
+	if !reflect.DeepEqual(expected, res.AMM) {
+		t.Errorf("Expected %+v, but got %+v", expected, res.AMM)
+	}
+	if res.LedgerCurrentIndex != 316745 {
+		t.Errorf("Expected ledger current index 316745, but got %d", res.LedgerCurrentIndex)
+	}
+}
+
 func TestClient_GetLedgerData(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -2753,3 +2794,41 @@ func TestClient_GetRandom(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_GetServerDefinitions(t *testing.T) {
+	cl, cleanup := setupTestClient(t, []map[string]any{
+		{
+			"id": 1,
+			"result": map[string]any{
+				"hash":               "56ADA2A0E7A2F3CE34ABF7C9C2C2F8E0F5CD6A4A54F4D5CE7D4AEC0AD9C7C3AD",
+				"TYPES":              map[string]any{"UInt16": 1},
+				"LEDGER_ENTRY_TYPES": map[string]any{"AccountRoot": 97},
+				"FIELDS": []any{
+					[]any{"TransactionType", map[string]any{"nth": 2, "isVLEncoded": false, "isSerialized": true, "isSigningField": true, "type": "UInt16"}},
+				},
+				"TRANSACTION_RESULTS": map[string]any{"tesSUCCESS": 0},
+				"TRANSACTION_TYPES":   map[string]any{"Payment": 0},
+			},
+		},
+	})
+	defer cleanup()
+
+	res, err := cl.GetServerDefinitions(&server.DefinitionsRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if res.Hash != "56ADA2A0E7A2F3CE34ABF7C9C2C2F8E0F5CD6A4A54F4D5CE7D4AEC0AD9C7C3AD" {
+		t.Errorf("Expected hash 56ADA2A0E7A2F3CE34ABF7C9C2C2F8E0F5CD6A4A54F4D5CE7D4AEC0AD9C7C3AD, but got %s", res.Hash)
+	}
+	if !reflect.DeepEqual(map[string]int32{"Payment": 0}, res.TransactionTypes) {
+		t.Errorf("Expected transaction types %v, but got %v", map[string]int32{"Payment": 0}, res.TransactionTypes)
+	}
+
+	defs, err := res.Definitions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if name := defs.FieldIDNameMap[*defs.Fields["TransactionType"].FieldHeader]; name != "TransactionType" {
+		t.Errorf("Expected field TransactionType, but got %s", name)
+	}
+}
@@ -13,12 +13,24 @@ type ResponseWarning struct {
 type ErrorWebsocketClientXrplResponse struct {
 	Type    string
 	Request map[string]any
+	// Result holds the fields of the error response, e.g. searched_all for txnNotFound.
+	Result map[string]any
 }
 
 func (e *ErrorWebsocketClientXrplResponse) Error() string {
 	return e.Type
 }
 
+// ErrorCode returns the rippled error code of the response.
+func (e *ErrorWebsocketClientXrplResponse) ErrorCode() string {
+	return e.Type
+}
+
+// ErrorResult returns the fields of the error response.
+func (e *ErrorWebsocketClientXrplResponse) ErrorResult() map[string]any {
+	return e.Result
+}
+
 type ClientResponse struct {
 	ID        int               `json:"id"`
 	Status    string            `json:"status"`
@@ -29,6 +41,9 @@ type ClientResponse struct {
 	Warning   string            `json:"warning,omitempty"`
 	Warnings  []ResponseWarning `json:"warnings,omitempty"`
 	Forwarded bool              `json:"forwarded,omitempty"`
+
+	// errorResult holds every field of an error response.
+	errorResult map[string]any
 }
 
 func (r *ClientResponse) GetResult(v any) error {
@@ -48,6 +63,7 @@ func (r *ClientResponse) CheckError() error {
 		return &ErrorWebsocketClientXrplResponse{
 			Type:    r.Error,
 			Request: r.Value,
+			Result:  r.errorResult,
 		}
 	}
 	return nil
@@ -0,0 +1,123 @@
+package websocket
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Peersyst/xrpl-go/xrpl"
+	requests "github.com/Peersyst/xrpl-go/xrpl/queries/transactions"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+)
+
+func TestClient_Sign(t *testing.T) {
+	cl, cleanup := setupTestClient(t, []map[string]any{
+		{
+			"id": 1,
+			"result": map[string]any{
+				"tx_blob": "1200032200000000",
+				"tx_json": map[string]any{
+					"Account":         "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn",
+					"TransactionType": "AccountSet",
+					"hash":            "C7E1E0B1B0A6EA3FA0C7E48AC9F3F2AE1CF75D72A5D7A7D9F8A0CBB7A1D3CB46",
+				},
+			},
+		},
+	})
+	defer cleanup()
+
+	res, err := cl.Sign(&requests.SignRequest{
+		TxJSON: transaction.FlatTransaction{
+			"Account":         "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn",
+			"TransactionType": "AccountSet",
+		},
+		SigningCredentials: requests.SigningCredentials{Secret: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if res.TxBlob != "1200032200000000" {
+		t.Errorf("Expected tx blob %q, but got %q", "1200032200000000", res.TxBlob)
+	}
+	expectedHash := "C7E1E0B1B0A6EA3FA0C7E48AC9F3F2AE1CF75D72A5D7A7D9F8A0CBB7A1D3CB46"
+	if res.Hash() != expectedHash {
+		t.Errorf("Expected hash %q, but got %q", expectedHash, res.Hash())
+	}
+}
+
+func TestClient_SignRefused(t *testing.T) {
+	cl := NewClient(NewClientConfig().WithHost("wss://s.altnet.rippletest.net:51233"))
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{
+			name: "sign",
+			call: func() error {
+				_, err := cl.Sign(&requests.SignRequest{
+					TxJSON:             transaction.FlatTransaction{},
+					SigningCredentials: requests.SigningCredentials{Secret: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"},
+				})
+				return err
+			},
+		},
+		{
+			name: "sign_for",
+			call: func() error {
+				_, err := cl.SignFor(&requests.SignForRequest{
+					Account:            "rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn",
+					TxJSON:             transaction.FlatTransaction{},
+					SigningCredentials: requests.SigningCredentials{Passphrase: "masterpassphrase"},
+				})
+				return err
+			},
+		},
+		{
+			name: "submit",
+			call: func() error {
+				_, err := cl.SignAndSubmit(&requests.SignAndSubmitRequest{
+					TxJSON:             transaction.FlatTransaction{},
+					SigningCredentials: requests.SigningCredentials{Seed: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"},
+				})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if !errors.Is(err, xrpl.ErrSecretRequest) {
+				t.Errorf("Expected error %v, but got %v", xrpl.ErrSecretRequest, err)
+			}
+		})
+	}
+}
+
+func TestClient_SignAndSubmit(t *testing.T) {
+	cl, cleanup := setupTestClient(t, []map[string]any{
+		{
+			"id": 1,
+			"result": map[string]any{
+				"engine_result":      "tesSUCCESS",
+				"engine_result_code": 0,
+				"tx_blob":            "1200032200000000",
+				"accepted":           true,
+			},
+		},
+	})
+	defer cleanup()
+
+	res, err := cl.SignAndSubmit(&requests.SignAndSubmitRequest{
+		TxJSON:             transaction.FlatTransaction{"TransactionType": "AccountSet"},
+		SigningCredentials: requests.SigningCredentials{Secret: "snoPBrXtMeMyMHUVTgbuqAfg1SUTb"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := &requests.SubmitResponse{EngineResult: "tesSUCCESS", TxBlob: "1200032200000000", Accepted: true}
+	if !reflect.DeepEqual(expected, res) {
+		t.Errorf("Expected %+v, but got %+v", expected, res)
+	}
+}
@@ -1,17 +1,22 @@
 package websocket
 
 import (
+	"context"
+	"sync"
+
 	subscribe "github.com/Peersyst/xrpl-go/xrpl/queries/subscription"
 	streamtypes "github.com/Peersyst/xrpl-go/xrpl/queries/subscription/types"
 	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
 )
 
-// Supports Streams, Accounts, AccountsProposed only. Does not support Books
-// TODO: add Books subscription support
+// subscriptions holds the streams, accounts and books the client is subscribed to, so
+// that they can be restored after a reconnection. It is safe for concurrent use.
 type subscriptions struct {
+	mu               sync.Mutex
 	streams          map[string]bool
 	accounts         map[types.Address]bool
 	accountsProposed map[types.Address]bool
+	books            map[string]streamtypes.OrderBook
 }
 
 func buildNewSubscriptions() *subscriptions {
@@ -19,10 +24,18 @@ func buildNewSubscriptions() *subscriptions {
 		streams:          make(map[string]bool),
 		accounts:         make(map[types.Address]bool),
 		accountsProposed: make(map[types.Address]bool),
+		books:            make(map[string]streamtypes.OrderBook),
 	}
 }
 
+// bookKey identifies an order book subscription by its currencies, as unsubscribe does.
+func bookKey(gets, pays types.IssuedCurrencyAmount) string {
+	return gets.Currency + "/" + gets.Issuer.String() + ":" + pays.Currency + "/" + pays.Issuer.String()
+}
+
 func (s *subscriptions) Add(req *subscribe.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	for _, item := range req.Streams {
 		s.streams[item] = true
 	}
@@ -32,21 +45,82 @@ func (s *subscriptions) Add(req *subscribe.Request) {
 	for _, item := range req.AccountsProposed {
 		s.accountsProposed[item] = true
 	}
+	for _, book := range req.Books {
+		// The snapshot is only wanted once, not on every reconnection.
+		book.Snapshot = false
+		s.books[bookKey(book.TakerGets, book.TakerPays)] = book
+	}
 }
 
 func (s *subscriptions) Remove(req *subscribe.UnsubscribeRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	for _, item := range req.Streams {
-		s.streams[item] = false
+		delete(s.streams, item)
 	}
 	for _, item := range req.Accounts {
-		s.accounts[item] = false
+		delete(s.accounts, item)
 	}
 	for _, item := range req.AccountsProposed {
-		s.accountsProposed[item] = false
+		delete(s.accountsProposed, item)
+	}
+	for _, book := range req.Books {
+		delete(s.books, bookKey(book.TakerGets, book.TakerPays))
+	}
+}
+
+// affectsBooks reports whether tx is sent by one of the order books the client is
+// subscribed to: it is validated and affects the offers of the book, see affectsBook.
+func (s *subscriptions) affectsBooks(tx *streamtypes.TransactionStream) bool {
+	if !tx.Validated {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, book := range s.books {
+		if affectsBook(tx, book) {
+			return true
+		}
 	}
+	return false
+}
+
+// unused returns the part of req the client is not subscribed to, or nil if there is none.
+func (s *subscriptions) unused(req *subscribe.UnsubscribeRequest) *subscribe.UnsubscribeRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := &subscribe.UnsubscribeRequest{}
+	for _, item := range req.Streams {
+		if !s.streams[item] {
+			res.Streams = append(res.Streams, item)
+		}
+	}
+	for _, item := range req.Accounts {
+		if !s.accounts[item] {
+			res.Accounts = append(res.Accounts, item)
+		}
+	}
+	for _, item := range req.AccountsProposed {
+		if !s.accountsProposed[item] {
+			res.AccountsProposed = append(res.AccountsProposed, item)
+		}
+	}
+	for _, book := range req.Books {
+		if _, ok := s.books[bookKey(book.TakerGets, book.TakerPays)]; !ok {
+			res.Books = append(res.Books, book)
+		}
+	}
+	if res.Streams == nil && res.Accounts == nil && res.AccountsProposed == nil && res.Books == nil {
+		return nil
+	}
+	return res
 }
 
 func (s *subscriptions) buildSubscribeRequest() *subscribe.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	var streams []string
 	if len(s.streams) > 0 { // This is need to create streams as []string{nil} in case of empty array. it will be omitted by json serialization
 		streams = make([]string, 0, len(s.streams))
@@ -71,14 +145,26 @@ func (s *subscriptions) buildSubscribeRequest() *subscribe.Request {
 		}
 	}
 
+	var books []streamtypes.OrderBook
+	if len(s.books) > 0 {
+		books = make([]streamtypes.OrderBook, 0, len(s.books))
+		for _, b := range s.books {
+			books = append(books, b)
+		}
+	}
+
 	return &subscribe.Request{
 		Streams:          streams,
 		Accounts:         accounts,
 		AccountsProposed: accountsProposed,
+		Books:            books,
 	}
 }
 
 func (s *subscriptions) buildUnsubscribeRequest() *subscribe.UnsubscribeRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	var streams []string
 	// This is need to create streams as []string{nil} in case of empty array. it will be omitted by json serialization
 	if len(s.streams) > 0 {
@@ -114,7 +200,12 @@ func (s *subscriptions) buildUnsubscribeRequest() *subscribe.UnsubscribeRequest
 // Subscribe subscribes to the streams and accounts specified in the request.
 // It returns a response from the server.
 func (c *Client) Subscribe(req *subscribe.Request) (*subscribe.Response, error) {
-	res, err := c.Request(req)
+	return c.SubscribeContext(context.Background(), req)
+}
+
+// SubscribeContext is like Subscribe but uses ctx to cancel the request or bound its duration.
+func (c *Client) SubscribeContext(ctx context.Context, req *subscribe.Request) (*subscribe.Response, error) {
+	res, err := c.RequestContext(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -131,7 +222,12 @@ func (c *Client) Subscribe(req *subscribe.Request) (*subscribe.Response, error)
 // Unsubscribe unsubscribes from the streams and accounts specified in the request.
 // It returns a response from the server.
 func (c *Client) Unsubscribe(req *subscribe.UnsubscribeRequest) (*subscribe.UnsubscribeResponse, error) {
-	res, err := c.Request(req)
+	return c.UnsubscribeContext(context.Background(), req)
+}
+
+// UnsubscribeContext is like Unsubscribe but uses ctx to cancel the request or bound its duration.
+func (c *Client) UnsubscribeContext(ctx context.Context, req *subscribe.UnsubscribeRequest) (*subscribe.UnsubscribeResponse, error) {
+	res, err := c.RequestContext(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -145,56 +241,6 @@ func (c *Client) Unsubscribe(req *subscribe.UnsubscribeRequest) (*subscribe.Unsu
 	return &lr, nil
 }
 
-// Handle errors
-
-// OnError is needed to provide a mechanism to a library client to handle "error" messages.
-// It creates an error channel and a goroutine to handle the errors.
-func (c *Client) OnError(
-	errHandler func(err error),
-) {
-	if c.errChan != nil {
-		return
-	}
-
-	c.errChan = make(chan error)
-	go func() {
-		defer close(c.errChan)
-		for err := range c.errChan {
-			errHandler(err)
-		}
-	}()
-}
-
-func (c *Client) error(err error) {
-	if c.errChan != nil {
-		c.errChan <- err
-	}
-}
-
-// OnDebug handles "debug" messages.
-// It creates a debug channel and a goroutine to provide messages to the client.
-func (c *Client) OnDebug(
-	debugHandler func(message string),
-) {
-	if c.debugChan != nil {
-		return
-	}
-
-	c.debugChan = make(chan string)
-	go func() {
-		defer close(c.debugChan)
-		for message := range c.debugChan {
-			debugHandler(message)
-		}
-	}()
-}
-
-func (c *Client) debug(message string) {
-	if c.debugChan != nil {
-		c.debugChan <- message
-	}
-}
-
 // Ledger streams
 
 // OnLedgerClosed handles "ledgerClosed" events.
@@ -202,7 +248,7 @@ func (c *Client) debug(message string) {
 func (c *Client) OnLedgerClosed(
 	handler func(ledger *streamtypes.LedgerStream),
 ) {
-	c.ledgerClosedChan = make(chan *streamtypes.LedgerStream, 10)
+	c.ledgerClosedChan = make(chan *streamtypes.LedgerStream, c.streamBuffer(streamtypes.LedgerStreamType).Size)
 	go func() {
 		defer close(c.ledgerClosedChan)
 		for ledger := range c.ledgerClosedChan {
@@ -218,7 +264,7 @@ func (c *Client) OnLedgerClosed(
 func (c *Client) OnValidationReceived(
 	handler func(validation *streamtypes.ValidationStream),
 ) {
-	c.validationChan = make(chan *streamtypes.ValidationStream, 10)
+	c.validationChan = make(chan *streamtypes.ValidationStream, c.streamBuffer(streamtypes.ValidationStreamType).Size)
 	go func() {
 		defer close(c.validationChan)
 		for validation := range c.validationChan {
@@ -234,7 +280,7 @@ func (c *Client) OnValidationReceived(
 func (c *Client) OnTransactions(
 	handler func(transactions *streamtypes.TransactionStream),
 ) {
-	c.transactionChan = make(chan *streamtypes.TransactionStream, 40)
+	c.transactionChan = make(chan *streamtypes.TransactionStream, c.streamBuffer(streamtypes.TransactionStreamType).Size)
 	go func() {
 		defer close(c.transactionChan)
 		for transaction := range c.transactionChan {
@@ -250,7 +296,7 @@ func (c *Client) OnTransactions(
 func (c *Client) OnPeerStatusChange(
 	handler func(peerStatus *streamtypes.PeerStatusStream),
 ) {
-	c.peerStatusChan = make(chan *streamtypes.PeerStatusStream, 10)
+	c.peerStatusChan = make(chan *streamtypes.PeerStatusStream, c.streamBuffer(streamtypes.PeerStatusStreamType).Size)
 	go func() {
 		defer close(c.peerStatusChan)
 		for peerStatus := range c.peerStatusChan {
@@ -261,12 +307,14 @@ func (c *Client) OnPeerStatusChange(
 
 // Orderbook streams
 
-// OnOrderbook handles "orderbook" events.
+// OnOrderBook handles the "transaction" events of order book subscriptions. The server
+// sends them as transaction messages, so the transactions affecting the offers of the
+// books the client is subscribed to are handled, see Subscribe.
 // It returns a stream of orderbook streams. Creates a new channel and a goroutine to handle the stream.
 func (c *Client) OnOrderBook(
 	handler func(orderbook *streamtypes.OrderBookStream),
 ) {
-	c.orderBookChan = make(chan *streamtypes.OrderBookStream, 10)
+	c.orderBookChan = make(chan *streamtypes.OrderBookStream, c.streamBuffer(streamtypes.OrderBookStreamType).Size)
 	go func() {
 		defer close(c.orderBookChan)
 		for orderbook := range c.orderBookChan {
@@ -282,7 +330,7 @@ func (c *Client) OnOrderBook(
 func (c *Client) OnBookChanges(
 	handler func(bookChanges *streamtypes.BookChangesStream),
 ) {
-	c.bookChangesChan = make(chan *streamtypes.BookChangesStream, 10)
+	c.bookChangesChan = make(chan *streamtypes.BookChangesStream, c.streamBuffer(streamtypes.BookChangesStreamType).Size)
 	go func() {
 		defer close(c.bookChangesChan)
 		for bookChanges := range c.bookChangesChan {
@@ -299,7 +347,7 @@ func (c *Client) OnConsensusPhase(
 	handler func(consensusPhase *streamtypes.ConsensusStream),
 ) {
 
-	c.consensusChan = make(chan *streamtypes.ConsensusStream, 10)
+	c.consensusChan = make(chan *streamtypes.ConsensusStream, c.streamBuffer(streamtypes.ConsensusStreamType).Size)
 	go func() {
 		defer close(c.consensusChan)
 		for consensusPhase := range c.consensusChan {
@@ -307,3 +355,51 @@ func (c *Client) OnConsensusPhase(
 		}
 	}()
 }
+
+// Manifest streams
+
+// OnManifestReceived handles "manifestReceived" events.
+// It returns a stream of manifest streams. Creates a new channel and a goroutine to handle the stream.
+func (c *Client) OnManifestReceived(
+	handler func(manifest *streamtypes.ManifestStream),
+) {
+	c.manifestChan = make(chan *streamtypes.ManifestStream, c.streamBuffer(streamtypes.ManifestStreamType).Size)
+	go func() {
+		defer close(c.manifestChan)
+		for manifest := range c.manifestChan {
+			handler(manifest)
+		}
+	}()
+}
+
+// Server streams
+
+// OnServerStatus handles "serverStatus" events.
+// It returns a stream of server streams. Creates a new channel and a goroutine to handle the stream.
+func (c *Client) OnServerStatus(
+	handler func(serverStatus *streamtypes.ServerStream),
+) {
+	c.serverStatusChan = make(chan *streamtypes.ServerStream, c.streamBuffer(streamtypes.ServerStreamType).Size)
+	go func() {
+		defer close(c.serverStatusChan)
+		for serverStatus := range c.serverStatusChan {
+			handler(serverStatus)
+		}
+	}()
+}
+
+// Path find streams
+
+// OnPathFind handles the asynchronous "path_find" updates of path_find create requests.
+// It returns a stream of path find streams. Creates a new channel and a goroutine to handle the stream.
+func (c *Client) OnPathFind(
+	handler func(pathFind *streamtypes.PathFindStream),
+) {
+	c.pathFindChan = make(chan *streamtypes.PathFindStream, c.streamBuffer(streamtypes.PathFindStreamType).Size)
+	go func() {
+		defer close(c.pathFindChan)
+		for pathFind := range c.pathFindChan {
+			handler(pathFind)
+		}
+	}()
+}
@@ -3,8 +3,13 @@ package websocket
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	subscribe "github.com/Peersyst/xrpl-go/xrpl/queries/subscription"
+	streamtypes "github.com/Peersyst/xrpl-go/xrpl/queries/subscription/types"
+	"github.com/Peersyst/xrpl-go/xrpl/queries/utility"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction"
+	"github.com/Peersyst/xrpl-go/xrpl/transaction/types"
 	"github.com/Peersyst/xrpl-go/xrpl/websocket/testutil"
 	"github.com/gorilla/websocket"
 )
@@ -159,3 +164,161 @@ func TestClient_Unsubscribe(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_StreamRouting(t *testing.T) {
+	streams := []map[string]any{
+		{"type": "transaction", "engine_result": "tesSUCCESS", "ledger_index": 7, "validated": true, "meta": map[string]any{
+			"AffectedNodes": []any{map[string]any{"CreatedNode": map[string]any{
+				"LedgerEntryType": "Offer",
+				"NewFields": map[string]any{
+					"TakerGets": "1000000",
+					"TakerPays": map[string]any{"currency": "USD", "issuer": "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "value": "1"},
+				},
+			}}},
+		}},
+		// Not an order book transaction, only handled as a transaction.
+		{"type": "transaction", "engine_result": "tesSUCCESS", "ledger_index": 7, "validated": true},
+		{"type": "bookChanges", "ledger_index": 7, "ledger_time": 800000000, "changes": []any{}},
+		{"type": "manifestReceived", "master_key": "nHUFE9prPXPrHcG3SkwP1UzAQbSphqyQkQK9ATXLZsfkezhhda3p", "seq": 4},
+		{"type": "serverStatus", "base_fee": 10, "load_base": 256, "load_factor": 256, "server_status": "full"},
+		{"type": "path_find", "id": 2, "source_account": "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", "full_reply": true},
+	}
+
+	ws := &testutil.MockWebSocketServer{}
+	s := ws.TestWebSocketServer(func(c *websocket.Conn) {
+		for {
+			var req map[string]any
+			if err := c.ReadJSON(&req); err != nil {
+				return
+			}
+			if err := c.WriteJSON(map[string]any{"id": req["id"], "result": map[string]any{}}); err != nil {
+				t.Errorf("error writing message: %v", err)
+			}
+			// Streams are sent once the subscription is recorded, after the ping that follows it.
+			if req["command"] != "ping" {
+				continue
+			}
+			for _, m := range streams {
+				if err := c.WriteJSON(m); err != nil {
+					t.Errorf("error writing message: %v", err)
+				}
+			}
+		}
+	})
+	defer s.Close()
+
+	url, _ := testutil.ConvertHTTPToWS(s.URL)
+	cl := NewClient(NewClientConfig().WithHost(url))
+
+	received := make(chan any, len(streams)+1)
+	cl.OnTransactions(func(tx *streamtypes.TransactionStream) { received <- tx })
+	cl.OnOrderBook(func(ob *streamtypes.OrderBookStream) { received <- ob })
+	cl.OnBookChanges(func(bc *streamtypes.BookChangesStream) { received <- bc })
+	cl.OnManifestReceived(func(m *streamtypes.ManifestStream) { received <- m })
+	cl.OnServerStatus(func(ss *streamtypes.ServerStream) { received <- ss })
+	cl.OnPathFind(func(pf *streamtypes.PathFindStream) { received <- pf })
+
+	if err := cl.Connect(); err != nil {
+		t.Fatalf("Error connecting to server: %v", err)
+	}
+	defer cl.Disconnect()
+
+	_, err := cl.Subscribe(&subscribe.Request{
+		Streams: []string{"book_changes", "manifests", "server"},
+		Books: []streamtypes.OrderBook{{
+			TakerGets: types.IssuedCurrencyAmount{Currency: "XRP"},
+			TakerPays: types.IssuedCurrencyAmount{Currency: "USD", Issuer: "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"},
+			Snapshot:  true,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := cl.Ping(&utility.PingRequest{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got := make(map[reflect.Type]any)
+	for range len(streams) + 1 {
+		select {
+		case v := <-received:
+			got[reflect.TypeOf(v)] = v
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Timed out waiting for streams, got %d", len(got))
+		}
+	}
+
+	if tx, ok := got[reflect.TypeOf(&streamtypes.TransactionStream{})].(*streamtypes.TransactionStream); !ok || tx.EngineResult != "tesSUCCESS" {
+		t.Errorf("Expected transaction stream, but got %+v", tx)
+	}
+	if ob, ok := got[reflect.TypeOf(&streamtypes.OrderBookStream{})].(*streamtypes.OrderBookStream); !ok || len(ob.Meta.AffectedNodes) != 1 {
+		t.Errorf("Expected order book stream, but got %+v", ob)
+	}
+	select {
+	case v := <-received:
+		t.Errorf("Unexpected stream %+v", v)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if bc, ok := got[reflect.TypeOf(&streamtypes.BookChangesStream{})].(*streamtypes.BookChangesStream); !ok || bc.LedgerIndex != 7 {
+		t.Errorf("Expected book changes stream, but got %+v", bc)
+	}
+	if m, ok := got[reflect.TypeOf(&streamtypes.ManifestStream{})].(*streamtypes.ManifestStream); !ok || m.Seq != 4 {
+		t.Errorf("Expected manifest stream, but got %+v", m)
+	}
+	if ss, ok := got[reflect.TypeOf(&streamtypes.ServerStream{})].(*streamtypes.ServerStream); !ok || ss.ServerStatus != "full" {
+		t.Errorf("Expected server stream, but got %+v", ss)
+	}
+	if pf, ok := got[reflect.TypeOf(&streamtypes.PathFindStream{})].(*streamtypes.PathFindStream); !ok || pf.ID != 2 || !pf.FullReply {
+		t.Errorf("Expected path find stream, but got %+v", pf)
+	}
+}
+
+func TestSubscriptions_Books(t *testing.T) {
+	xrp := types.IssuedCurrencyAmount{Currency: "XRP"}
+	usd := types.IssuedCurrencyAmount{Currency: "USD", Issuer: "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"}
+
+	offer := &streamtypes.TransactionStream{
+		Validated: true,
+		Meta: transaction.TxObjMeta{AffectedNodes: []transaction.AffectedNode{{
+			CreatedNode: &transaction.CreatedNode{
+				LedgerEntryType: "Offer",
+				NewFields: map[string]any{
+					"TakerGets": "1000000",
+					"TakerPays": map[string]any{"currency": "USD", "issuer": "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "value": "1"},
+				},
+			},
+		}}},
+	}
+
+	s := buildNewSubscriptions()
+	s.Add(&subscribe.Request{
+		Streams: []string{"ledger"},
+		Books:   []streamtypes.OrderBook{{TakerGets: xrp, TakerPays: usd, Taker: "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", Snapshot: true}},
+	})
+
+	expected := &subscribe.Request{
+		Streams: []string{"ledger"},
+		Books:   []streamtypes.OrderBook{{TakerGets: xrp, TakerPays: usd, Taker: "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59"}},
+	}
+	if got := s.buildSubscribeRequest(); !reflect.DeepEqual(expected, got) {
+		t.Errorf("Expected %+v, but got %+v", expected, got)
+	}
+	if !s.affectsBooks(offer) {
+		t.Errorf("Expected the offer to affect the book")
+	}
+	if s.affectsBooks(&streamtypes.TransactionStream{Validated: true}) {
+		t.Errorf("Expected a transaction without offers not to affect the book")
+	}
+
+	s.Remove(&subscribe.UnsubscribeRequest{
+		Streams: []string{"ledger"},
+		Books:   []subscribe.UnsubscribeOrderBook{{TakerGets: xrp, TakerPays: usd}},
+	})
+
+	if got := s.buildSubscribeRequest(); !reflect.DeepEqual(&subscribe.Request{}, got) {
+		t.Errorf("Expected an empty request, but got %+v", got)
+	}
+	if s.affectsBooks(offer) {
+		t.Errorf("Expected no books to be subscribed")
+	}
+}